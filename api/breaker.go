@@ -0,0 +1,74 @@
+package api
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen solved.ac가 연속으로 응답하지 않아 요청을 보내지 않고 바로 실패했음을 나타냅니다
+var ErrCircuitOpen = errors.New("solved.ac가 응답하지 않아 잠시 요청을 중단했습니다")
+
+// circuitBreaker 연속 실패가 쌓이면 일정 시간 동안 요청을 차단합니다.
+// 차단 시간이 지나면 한 요청만 시험 삼아 보내고, 성공하면 다시 요청을 허용합니다.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	probing   bool
+	now       func() time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// Allow 지금 요청을 보내도 되는지 확인합니다. 차단 중이면 ErrCircuitOpen을 반환합니다.
+func (b *circuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+	if b.probing || b.now().Before(b.openUntil) {
+		return ErrCircuitOpen
+	}
+	b.probing = true
+	return nil
+}
+
+// Success 요청이 성공했음을 기록하고 차단을 풉니다
+func (b *circuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.probing = false
+}
+
+// Failure solved.ac 장애로 보이는 실패를 기록하고, 연속 실패가 기준에 닿으면 요청을 차단합니다
+func (b *circuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+	}
+}
+
+// Cancel 요청이 취소되어 결과를 알 수 없을 때 시험 요청 표시만 지워 다음 요청이 다시 확인하게 합니다
+func (b *circuitBreaker) Cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"context"
+	"discord-bot/constants"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Client solved.ac 조회 메서드 묶음입니다.
+// interfaces.APIClient와 같은 메서드를 가지며, interfaces가 api를 가져오므로 순환을 피하려고 따로 둡니다.
+type Client interface {
+	GetUserInfo(ctx context.Context, handle string) (*UserInfo, error)
+	GetUserTop100(ctx context.Context, handle string) (*Top100Response, error)
+	GetUserSolvedProblems(ctx context.Context, handle string) (*SolvedProblemsResponse, error)
+}
+
+// 캐시 키에 쓰는 엔드포인트 이름
+const (
+	endpointUserInfo = "user"
+	endpointTop100   = "top100"
+	endpointSolved   = "solved"
+)
+
+// CacheStats 캐시 적중과 요청 합치기 통계입니다
+type CacheStats struct {
+	Hits        uint64 // 유효한 캐시로 응답한 횟수
+	Misses      uint64 // solved.ac에 요청한 횟수
+	Coalesced   uint64 // 같은 조회가 진행 중이라 그 결과를 함께 받은 횟수
+	Revalidated uint64 // ETag로 확인해 변경이 없었던 응답 수 (304)
+}
+
+// String 로그에 남길 수 있는 한 줄 요약을 반환합니다
+func (s CacheStats) String() string {
+	return fmt.Sprintf("적중 %d, 요청 %d, 합친 요청 %d, 변경 없음(304) %d", s.Hits, s.Misses, s.Coalesced, s.Revalidated)
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// flight 진행 중인 조회 하나입니다. 같은 키의 조회는 끝날 때까지 기다렸다가 결과를 나눠 받습니다.
+type flight struct {
+	done     chan struct{}
+	value    interface{}
+	err      error
+	detached bool // Invalidate로 떼어낸 조회입니다. 결과를 캐시에 넣지 않습니다 (c.mu로 보호)
+}
+
+// CachingClient solved.ac 응답을 엔드포인트별 유효 시간 동안 보관하는 APIClient 데코레이터입니다.
+// 같은 조회가 동시에 들어오면 한 번만 요청하고 결과를 함께 돌려줍니다. 실패한 응답은 보관하지 않습니다.
+type CachingClient struct {
+	upstream Client
+	ttls     map[string]time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	flights map[string]*flight
+	now     func() time.Time
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	coalesced atomic.Uint64
+}
+
+// NewCachingClient upstream의 응답을 캐시하는 클라이언트를 생성합니다
+func NewCachingClient(upstream Client) *CachingClient {
+	return &CachingClient{
+		upstream: upstream,
+		ttls: map[string]time.Duration{
+			endpointUserInfo: constants.UserInfoCacheTTL,
+			endpointTop100:   constants.Top100CacheTTL,
+			endpointSolved:   constants.SolvedCacheTTL,
+		},
+		entries: make(map[string]cacheEntry),
+		flights: make(map[string]*flight),
+		now:     time.Now,
+	}
+}
+
+// GetUserInfo 사용자 정보를 캐시에서 찾고, 없으면 solved.ac에서 가져옵니다
+func (c *CachingClient) GetUserInfo(ctx context.Context, handle string) (*UserInfo, error) {
+	value, err := c.get(ctx, endpointUserInfo, handle, func() (interface{}, error) {
+		return c.upstream.GetUserInfo(ctx, handle)
+	})
+	if err != nil {
+		return nil, err
+	}
+	info := *value.(*UserInfo)
+	return &info, nil
+}
+
+// GetUserTop100 사용자의 TOP 100 문제를 캐시에서 찾고, 없으면 solved.ac에서 가져옵니다
+func (c *CachingClient) GetUserTop100(ctx context.Context, handle string) (*Top100Response, error) {
+	value, err := c.get(ctx, endpointTop100, handle, func() (interface{}, error) {
+		return c.upstream.GetUserTop100(ctx, handle)
+	})
+	if err != nil {
+		return nil, err
+	}
+	top100 := *value.(*Top100Response)
+	top100.Items = append([]ProblemInfo(nil), top100.Items...)
+	return &top100, nil
+}
+
+// GetUserSolvedProblems 사용자가 해결한 문제 목록을 캐시에서 찾고, 없으면 solved.ac에서 가져옵니다
+func (c *CachingClient) GetUserSolvedProblems(ctx context.Context, handle string) (*SolvedProblemsResponse, error) {
+	value, err := c.get(ctx, endpointSolved, handle, func() (interface{}, error) {
+		return c.upstream.GetUserSolvedProblems(ctx, handle)
+	})
+	if err != nil {
+		return nil, err
+	}
+	solved := *value.(*SolvedProblemsResponse)
+	solved.Items = append([]ProblemInfo(nil), solved.Items...)
+	return &solved, nil
+}
+
+// Invalidate 사용자의 캐시를 모두 지워 다음 조회 때 solved.ac에서 새로 가져오게 합니다.
+// 자기소개 인증처럼 방금 바뀐 정보를 확인해야 할 때 사용합니다.
+// 진행 중인 조회는 지우기 전의 정보일 수 있으므로 떼어내, 이후 조회가 그 결과를 기다리거나 캐시에서 받지 않게 합니다.
+func (c *CachingClient) Invalidate(handle string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, endpoint := range []string{endpointUserInfo, endpointTop100, endpointSolved} {
+		key := cacheKey(endpoint, handle)
+		delete(c.entries, key)
+		if f, ok := c.flights[key]; ok {
+			f.detached = true
+			delete(c.flights, key)
+		}
+	}
+}
+
+// Stats 지금까지의 캐시 통계를 반환합니다
+func (c *CachingClient) Stats() CacheStats {
+	stats := CacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Coalesced: c.coalesced.Load(),
+	}
+	if counter, ok := c.upstream.(interface{ NotModifiedCount() uint64 }); ok {
+		stats.Revalidated = counter.NotModifiedCount()
+	}
+	return stats
+}
+
+// get 유효한 캐시가 있으면 돌려주고, 같은 조회가 진행 중이면 그 결과를 기다리며, 둘 다 아니면 fetch를 호출합니다.
+// 기다리던 조회가 그 호출자의 취소로 끝났으면 자신의 ctx가 살아 있는 한 다시 조회합니다.
+func (c *CachingClient) get(ctx context.Context, endpoint, handle string, fetch func() (interface{}, error)) (interface{}, error) {
+	key := cacheKey(endpoint, handle)
+
+	for {
+		c.mu.Lock()
+		if entry, ok := c.entries[key]; ok && c.now().Before(entry.expires) {
+			c.mu.Unlock()
+			c.hits.Add(1)
+			return entry.value, nil
+		}
+		f, waiting := c.flights[key]
+		if !waiting {
+			f = &flight{done: make(chan struct{})}
+			c.flights[key] = f
+		}
+		c.mu.Unlock()
+
+		if !waiting {
+			return c.run(key, endpoint, f, fetch)
+		}
+
+		c.coalesced.Add(1)
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if isContextError(f.err) && ctx.Err() == nil {
+			continue
+		}
+		return f.value, f.err
+	}
+}
+
+// run 조회를 직접 수행하고 결과를 캐시와 기다리던 호출자들에게 전달합니다.
+// 조회 중에 Invalidate로 떼어냈으면 결과를 기다리던 호출자에게만 전달하고 캐시에는 넣지 않습니다.
+func (c *CachingClient) run(key, endpoint string, f *flight, fetch func() (interface{}, error)) (interface{}, error) {
+	c.misses.Add(1)
+	f.value, f.err = fetch()
+
+	c.mu.Lock()
+	if !f.detached {
+		delete(c.flights, key)
+		if f.err == nil {
+			c.store(key, cacheEntry{value: f.value, expires: c.now().Add(c.ttls[endpoint])})
+		}
+	}
+	c.mu.Unlock()
+	close(f.done)
+
+	return f.value, f.err
+}
+
+// store 캐시에 응답을 넣습니다. 항목이 너무 많아지면 만료된 항목부터 정리합니다.
+// c.mu를 잡은 상태에서 호출해야 합니다.
+func (c *CachingClient) store(key string, entry cacheEntry) {
+	if len(c.entries) >= constants.MaxCacheEntries {
+		now := c.now()
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		// 모두 유효하면 가장 먼저 만료될 항목을 지웁니다
+		if len(c.entries) >= constants.MaxCacheEntries {
+			oldest := ""
+			for k, e := range c.entries {
+				if oldest == "" || e.expires.Before(c.entries[oldest].expires) {
+					oldest = k
+				}
+			}
+			delete(c.entries, oldest)
+		}
+	}
+	c.entries[key] = entry
+}
+
+func cacheKey(endpoint, handle string) string {
+	return endpoint + ":" + strings.ToLower(handle)
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,242 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingClient 호출 횟수를 세는 가짜 upstream입니다. release가 있으면 닫힐 때까지 응답을 미룹니다.
+type countingClient struct {
+	calls   atomic.Int32
+	fail    atomic.Bool
+	release chan struct{}
+}
+
+func (c *countingClient) GetUserInfo(_ context.Context, handle string) (*UserInfo, error) {
+	c.calls.Add(1)
+	if c.release != nil {
+		<-c.release
+	}
+	if c.fail.Load() {
+		return nil, errors.New("solved.ac unavailable")
+	}
+	return &UserInfo{Handle: handle, Tier: 15}, nil
+}
+
+func (c *countingClient) GetUserTop100(_ context.Context, handle string) (*Top100Response, error) {
+	c.calls.Add(1)
+	return &Top100Response{Count: 1, Items: []ProblemInfo{{ProblemID: 1000}}}, nil
+}
+
+func (c *countingClient) GetUserSolvedProblems(_ context.Context, handle string) (*SolvedProblemsResponse, error) {
+	c.calls.Add(1)
+	return &SolvedProblemsResponse{Count: 1, Items: []ProblemInfo{{ProblemID: 1000}}}, nil
+}
+
+func TestCachingClientTTL(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	upstream := &countingClient{}
+	cache := NewCachingClient(upstream)
+	cache.now = clock.now
+	cache.ttls[endpointUserInfo] = time.Minute
+
+	for i := 0; i < 3; i++ {
+		if _, err := cache.GetUserInfo(context.Background(), "Alice"); err != nil {
+			t.Fatalf("GetUserInfo: %v", err)
+		}
+	}
+	if _, err := cache.GetUserInfo(context.Background(), "alice"); err != nil {
+		t.Fatalf("GetUserInfo: %v", err)
+	}
+	if n := upstream.calls.Load(); n != 1 {
+		t.Fatalf("expected one upstream call within the TTL, got %d", n)
+	}
+
+	clock.advance(time.Minute)
+	if _, err := cache.GetUserInfo(context.Background(), "alice"); err != nil {
+		t.Fatalf("GetUserInfo: %v", err)
+	}
+	if n := upstream.calls.Load(); n != 2 {
+		t.Fatalf("expected an expired entry to be refetched, got %d calls", n)
+	}
+
+	cache.Invalidate("ALICE")
+	if _, err := cache.GetUserInfo(context.Background(), "alice"); err != nil {
+		t.Fatalf("GetUserInfo: %v", err)
+	}
+	if n := upstream.calls.Load(); n != 3 {
+		t.Fatalf("expected Invalidate to force a refetch, got %d calls", n)
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 3 || stats.Misses != 3 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestCachingClientDoesNotCacheErrors(t *testing.T) {
+	upstream := &countingClient{}
+	upstream.fail.Store(true)
+	cache := NewCachingClient(upstream)
+
+	if _, err := cache.GetUserInfo(context.Background(), "alice"); err == nil {
+		t.Fatal("expected the upstream error")
+	}
+	upstream.fail.Store(false)
+	if _, err := cache.GetUserInfo(context.Background(), "alice"); err != nil {
+		t.Fatalf("expected a retry after the failure, got %v", err)
+	}
+	if n := upstream.calls.Load(); n != 2 {
+		t.Fatalf("expected two upstream calls, got %d", n)
+	}
+}
+
+func TestCachingClientCoalescesConcurrentCalls(t *testing.T) {
+	upstream := &countingClient{release: make(chan struct{})}
+	cache := NewCachingClient(upstream)
+
+	const callers = 5
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cache.GetUserInfo(context.Background(), "alice"); err != nil {
+				t.Errorf("GetUserInfo: %v", err)
+			}
+		}()
+	}
+
+	// 모든 호출이 진행 중인 조회를 기다리게 된 뒤에 응답합니다
+	deadline := time.Now().Add(time.Second)
+	for cache.Stats().Coalesced < callers-1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	close(upstream.release)
+	wg.Wait()
+
+	if n := upstream.calls.Load(); n != 1 {
+		t.Fatalf("expected concurrent calls to share one request, got %d", n)
+	}
+	if stats := cache.Stats(); stats.Coalesced != callers-1 {
+		t.Errorf("expected %d coalesced calls, got %+v", callers-1, stats)
+	}
+}
+
+func TestCachingClientReturnsCopies(t *testing.T) {
+	cache := NewCachingClient(&countingClient{})
+
+	first, err := cache.GetUserSolvedProblems(context.Background(), "alice")
+	if err != nil {
+		t.Fatalf("GetUserSolvedProblems: %v", err)
+	}
+	first.Items[0].ProblemID = 9999
+
+	second, err := cache.GetUserSolvedProblems(context.Background(), "alice")
+	if err != nil {
+		t.Fatalf("GetUserSolvedProblems: %v", err)
+	}
+	if second.Items[0].ProblemID != 1000 {
+		t.Fatalf("cached response was modified by a caller: %+v", second.Items)
+	}
+}
+
+func TestCachingClientWaiterRetriesAfterCanceledFlight(t *testing.T) {
+	upstream := &countingClient{release: make(chan struct{})}
+	cache := NewCachingClient(upstream)
+
+	// 첫 호출자는 자기 ctx가 취소되어 실패하지만, 기다리던 호출자는 다시 조회해 결과를 받습니다
+	first, cancelFirst := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		_, err := cache.get(first, endpointUserInfo, "alice", func() (interface{}, error) {
+			<-upstream.release
+			return nil, context.Canceled
+		})
+		errc <- err
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		cache.mu.Lock()
+		_, started := cache.flights[cacheKey(endpointUserInfo, "alice")]
+		cache.mu.Unlock()
+		if started || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := cache.GetUserInfo(context.Background(), "alice")
+		done <- err
+	}()
+	for cache.Stats().Coalesced == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	cancelFirst()
+	close(upstream.release)
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the first caller to see its cancellation, got %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("expected the waiting caller to retry, got %v", err)
+	}
+}
+
+func TestCachingClientInvalidateDetachesFlight(t *testing.T) {
+	cache := NewCachingClient(&countingClient{})
+	release := make(chan struct{})
+
+	// 무효화 전에 시작한 조회는 옛 정보를 가져오므로 이후 호출자가 기다리거나 캐시에서 받으면 안 됩니다
+	stale := make(chan interface{}, 1)
+	go func() {
+		value, _ := cache.get(context.Background(), endpointUserInfo, "alice", func() (interface{}, error) {
+			<-release
+			return "before", nil
+		})
+		stale <- value
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		cache.mu.Lock()
+		_, started := cache.flights[cacheKey(endpointUserInfo, "alice")]
+		cache.mu.Unlock()
+		if started || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	cache.Invalidate("alice")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	value, err := cache.get(ctx, endpointUserInfo, "alice", func() (interface{}, error) {
+		return "after", nil
+	})
+	if err != nil || value != "after" {
+		t.Fatalf("expected a fresh lookup after Invalidate, got %v, %v", value, err)
+	}
+	if stats := cache.Stats(); stats.Coalesced != 0 {
+		t.Fatalf("expected no caller to wait on the detached lookup, got %+v", stats)
+	}
+
+	close(release)
+	if value := <-stale; value != "before" {
+		t.Fatalf("expected the detached caller to get its own result, got %v", value)
+	}
+
+	value, err = cache.get(context.Background(), endpointUserInfo, "alice", func() (interface{}, error) {
+		return nil, errors.New("unexpected upstream call")
+	})
+	if err != nil || value != "after" {
+		t.Fatalf("expected the detached lookup not to overwrite the cache, got %v, %v", value, err)
+	}
+}
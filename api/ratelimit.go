@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateLimiter 클라이언트 전체가 함께 쓰는 토큰 버킷 방식의 요청 제한기입니다.
+// solved.ac가 Retry-After를 보내면 그 시각까지 모든 요청을 멈춥니다.
+type rateLimiter struct {
+	mu         sync.Mutex
+	rate       float64 // 초당 채워지는 토큰 수
+	burst      float64
+	tokens     float64
+	last       time.Time
+	pauseUntil time.Time
+	now        func() time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// Wait 요청을 보낼 차례가 될 때까지 기다립니다. 기다리는 동안 ctx가 취소되면 ctx의 오류를 반환합니다.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return sleepContext(ctx, l.reserve())
+}
+
+// reserve 토큰 하나를 예약하고 예약한 토큰을 쓸 수 있을 때까지 기다려야 할 시간을 반환합니다.
+// 토큰이 모자라면 잔량을 음수로 두어 뒤에 온 요청이 그만큼 더 기다리게 합니다.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	l.tokens--
+
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	if pause := l.pauseUntil.Sub(now); pause > wait {
+		wait = pause
+	}
+	return wait
+}
+
+// PauseFor 서버가 요청 한도 초과를 알리면 d 동안 모든 요청을 멈추고 버킷을 비웁니다
+func (l *rateLimiter) PauseFor(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := l.now().Add(d); until.After(l.pauseUntil) {
+		l.pauseUntil = until
+	}
+	if l.tokens > 0 {
+		l.tokens = 0
+	}
+}
+
+// parseRetryAfter Retry-After 헤더를 기다릴 시간으로 바꿉니다 (초 단위 또는 HTTP 날짜).
+// 헤더가 없거나 해석할 수 없으면 false를 반환합니다.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := at.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// backoffDelay attempt번째(1부터) 재시도 전에 기다릴 시간을 지수적으로 늘리고 지터를 더해 계산합니다.
+// 여러 요청이 동시에 실패해도 한꺼번에 다시 몰리지 않도록 [delay/2, delay) 구간에서 무작위로 고릅니다.
+func backoffDelay(attempt int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + time.Duration(rand.Int63n(int64(half)))
+}
+
+// sleepContext d 동안 기다리되 ctx가 먼저 취소되면 바로 ctx의 오류를 반환합니다
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestRateLimiterReserve(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := newRateLimiter(2, 2)
+	limiter.now = clock.now
+
+	// burst 만큼은 바로 보낼 수 있고 그 뒤로는 초당 2개씩 차례를 기다립니다
+	for i, want := range []time.Duration{0, 0, 500 * time.Millisecond, time.Second} {
+		if got := limiter.reserve(); got != want {
+			t.Fatalf("reserve %d: got %v, want %v", i, got, want)
+		}
+	}
+
+	clock.advance(10 * time.Second)
+	if got := limiter.reserve(); got != 0 {
+		t.Fatalf("expected the bucket to refill, got wait %v", got)
+	}
+}
+
+func TestRateLimiterPauseFor(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := newRateLimiter(10, 5)
+	limiter.now = clock.now
+
+	limiter.PauseFor(3 * time.Second)
+	if got := limiter.reserve(); got != 3*time.Second {
+		t.Fatalf("expected every request to wait for Retry-After, got %v", got)
+	}
+
+	clock.advance(3 * time.Second)
+	if got := limiter.reserve(); got != 0 {
+		t.Fatalf("expected requests to resume after the pause, got wait %v", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"7", 7 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	base, max := time.Second, 5*time.Second
+	for attempt, ceiling := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 6: max} {
+		for i := 0; i < 20; i++ {
+			got := backoffDelay(attempt, base, max)
+			if got < ceiling/2 || got >= ceiling {
+				t.Fatalf("attempt %d: delay %v outside [%v, %v)", attempt, got, ceiling/2, ceiling)
+			}
+		}
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	breaker := newCircuitBreaker(2, time.Minute)
+	breaker.now = clock.now
+
+	breaker.Failure()
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("expected requests below the threshold to pass, got %v", err)
+	}
+	breaker.Failure()
+	if err := breaker.Allow(); err != ErrCircuitOpen {
+		t.Fatalf("expected the breaker to open, got %v", err)
+	}
+
+	// 차단 시간이 지나면 시험 요청 하나만 허용합니다
+	clock.advance(time.Minute)
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("expected a probe after the cooldown, got %v", err)
+	}
+	if err := breaker.Allow(); err != ErrCircuitOpen {
+		t.Fatalf("expected other requests to wait for the probe, got %v", err)
+	}
+
+	breaker.Failure()
+	if err := breaker.Allow(); err != ErrCircuitOpen {
+		t.Fatalf("expected a failed probe to reopen the breaker, got %v", err)
+	}
+
+	clock.advance(time.Minute)
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("expected a second probe, got %v", err)
+	}
+	breaker.Success()
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("expected a successful probe to close the breaker, got %v", err)
+	}
+}
@@ -1,19 +1,59 @@
 package api
 
 import (
+	"context"
 	"discord-bot/constants"
 	"discord-bot/utils"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// SolvedACClient solved.ac API와 통신하는 클라이언트입니다
+// SolvedACClient solved.ac API와 통신하는 클라이언트입니다.
+// 모든 요청은 하나의 요청 제한기와 서킷 브레이커를 거치므로 동시에 여러 참가자를 조회해도 한도를 넘지 않습니다.
 type SolvedACClient struct {
-	client  *http.Client
-	baseURL string
+	client     *http.Client
+	baseURL    string
+	retryDelay time.Duration // 첫 재시도 전 대기 시간 (이후 두 배씩 증가)
+	limiter    *rateLimiter
+	breaker    *circuitBreaker
+
+	etagMu      sync.Mutex
+	etags       map[string]etagEntry // URL별 마지막 응답 (If-None-Match 재검증용)
+	notModified atomic.Uint64
+}
+
+// etagEntry ETag와 함께 받은 응답 본문입니다
+type etagEntry struct {
+	etag string
+	body []byte
+}
+
+// ClientOptions solved.ac 클라이언트 설정입니다. 비어 있거나 0 이하의 값은 기본값을 사용합니다.
+type ClientOptions struct {
+	BaseURL           string // 테스트에서 가짜 서버를 가리키도록 바꿀 수 있습니다
+	RequestsPerSecond float64
+	Burst             int
+	RetryDelay        time.Duration
+}
+
+// rateLimitError solved.ac가 요청 한도 초과(429)를 응답했음을 나타냅니다
+type rateLimitError struct {
+	retryAfter    time.Duration
+	hasRetryAfter bool
+}
+
+func (e *rateLimitError) Error() string {
+	if e.hasRetryAfter {
+		return fmt.Sprintf("요청 한도 초과 (%v 후 재시도)", e.retryAfter)
+	}
+	return "요청 한도 초과"
 }
 
 // UserInfo solved.ac 사용자 정보를 나타냅니다
@@ -45,150 +85,262 @@ type Top100Response struct {
 	Items []ProblemInfo `json:"items"`
 }
 
+// ProblemSearchResponse 문제 검색 API의 한 페이지 응답을 나타냅니다
+type ProblemSearchResponse struct {
+	Count int           `json:"count"`
+	Items []ProblemInfo `json:"items"`
+}
+
+// SolvedProblemsResponse 사용자가 해결한 전체 문제 목록을 나타냅니다
+type SolvedProblemsResponse struct {
+	Count int           `json:"count"`
+	Items []ProblemInfo `json:"items"`
+}
+
 // NewSolvedACClient 새로운 SolvedACClient 인스턴스를 생성합니다
-func NewSolvedACClient() *SolvedACClient {
-	utils.Debug("Creating new SolvedAC API client")
+func NewSolvedACClient(opts ClientOptions) *SolvedACClient {
+	if opts.RequestsPerSecond <= 0 {
+		opts.RequestsPerSecond = constants.DefaultRequestsPerSec
+	}
+	if opts.Burst <= 0 {
+		opts.Burst = constants.DefaultRequestBurst
+	}
+	if opts.BaseURL == "" {
+		opts.BaseURL = constants.SolvedACBaseURL
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = constants.RetryDelay
+	}
+
+	utils.Debug("Creating new SolvedAC API client (%.1f req/s, burst %d)", opts.RequestsPerSecond, opts.Burst)
 	return &SolvedACClient{
 		client: &http.Client{
 			Timeout: constants.APITimeout,
 		},
-		baseURL: constants.SolvedACBaseURL,
+		baseURL:    strings.TrimSuffix(opts.BaseURL, "/"),
+		retryDelay: opts.RetryDelay,
+		limiter:    newRateLimiter(opts.RequestsPerSecond, opts.Burst),
+		breaker:    newCircuitBreaker(constants.BreakerFailureLimit, constants.BreakerCooldown),
+		etags:      make(map[string]etagEntry),
 	}
 }
 
+// NotModifiedCount ETag 재검증에서 변경이 없다는 응답(304)을 받은 횟수를 반환합니다
+func (c *SolvedACClient) NotModifiedCount() uint64 {
+	return c.notModified.Load()
+}
+
+func (c *SolvedACClient) cachedETag(url string) (etagEntry, bool) {
+	c.etagMu.Lock()
+	defer c.etagMu.Unlock()
+	entry, ok := c.etags[url]
+	return entry, ok
+}
+
+// storeETag ETag가 있는 응답을 저장합니다. 저장 공간이 가득 차면 임의의 항목 하나를 지웁니다.
+func (c *SolvedACClient) storeETag(url string, entry etagEntry) {
+	c.etagMu.Lock()
+	defer c.etagMu.Unlock()
+
+	if _, ok := c.etags[url]; !ok && len(c.etags) >= constants.MaxETagEntries {
+		for k := range c.etags {
+			delete(c.etags, k)
+			break
+		}
+	}
+	c.etags[url] = entry
+}
+
 // GetUserInfo 지정된 핸들의 사용자 정보를 가져옵니다
-func (c *SolvedACClient) GetUserInfo(handle string) (*UserInfo, error) {
+func (c *SolvedACClient) GetUserInfo(ctx context.Context, handle string) (*UserInfo, error) {
 	if !utils.IsValidBaekjoonID(handle) {
 		return nil, fmt.Errorf("잘못된 핸들 형식: %s", handle)
 	}
 
 	url := fmt.Sprintf("%s/user/show?handle=%s", c.baseURL, handle)
-	return c.getUserInfoWithRetry(url, handle)
+
+	var userInfo UserInfo
+	if err := c.getWithRetry(ctx, url, "user info", handle, &userInfo); err != nil {
+		return nil, fmt.Errorf("사용자 정보 조회 실패: %w", err)
+	}
+
+	utils.Debug("Successfully fetched user info for %s (tier: %d, rating: %d)",
+		handle, userInfo.Tier, userInfo.Rating)
+	return &userInfo, nil
 }
 
-// 재시도 로직을 포함한 사용자 정보 조회
-func (c *SolvedACClient) getUserInfoWithRetry(url, handle string) (*UserInfo, error) {
-	var lastErr error
+// GetUserTop100 지정된 사용자의 TOP 100 문제를 가져옵니다
+func (c *SolvedACClient) GetUserTop100(ctx context.Context, handle string) (*Top100Response, error) {
+	if !utils.IsValidBaekjoonID(handle) {
+		return nil, fmt.Errorf("잘못된 핸들 형식: %s", handle)
+	}
 
-	for attempt := 0; attempt < constants.MaxRetries; attempt++ {
-		if attempt > 0 {
-			utils.Debug("Retrying user info fetch for %s (attempt %d/%d)", handle, attempt+1, constants.MaxRetries)
-			time.Sleep(constants.RetryDelay * time.Duration(attempt))
-		}
+	url := fmt.Sprintf("%s/user/top_100?handle=%s", c.baseURL, handle)
 
-		utils.Debug("Fetching user info from: %s", url)
+	var top100 Top100Response
+	if err := c.getWithRetry(ctx, url, "top 100", handle, &top100); err != nil {
+		return nil, fmt.Errorf("TOP 100 조회 실패: %w", err)
+	}
 
-		resp, err := c.client.Get(url)
-		if err != nil {
-			lastErr = fmt.Errorf("사용자 정보 조회 실패: %w", err)
-			utils.Warn("Attempt %d failed for user %s: %v", attempt+1, handle, err)
-			continue
-		}
-		defer resp.Body.Close()
+	utils.Debug("Successfully fetched %d top problems for %s", top100.Count, handle)
+	return &top100, nil
+}
 
-		if resp.StatusCode == http.StatusTooManyRequests {
-			lastErr = fmt.Errorf("요청 한도 초과")
-			utils.Warn("Rate limited for user %s, attempt %d", handle, attempt+1)
-			time.Sleep(constants.RetryDelay * constants.APIRetryMultiplier)
-			continue
-		}
+// GetUserSolvedProblems 지정된 사용자가 해결한 모든 문제를 페이지 단위로 조회하여 반환합니다
+func (c *SolvedACClient) GetUserSolvedProblems(ctx context.Context, handle string) (*SolvedProblemsResponse, error) {
+	if !utils.IsValidBaekjoonID(handle) {
+		return nil, fmt.Errorf("잘못된 핸들 형식: %s", handle)
+	}
 
-		if resp.StatusCode != http.StatusOK {
-			lastErr = fmt.Errorf("API가 상태 코드 %d를 반환했습니다", resp.StatusCode)
-			utils.Warn("API returned non-200 status for user %s: %d", handle, resp.StatusCode)
-			if resp.StatusCode >= 500 {
-				continue // 서버 에러는 재시도
-			}
-			break // 클라이언트 에러는 즉시 반환
-		}
+	solved := &SolvedProblemsResponse{Items: []ProblemInfo{}}
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			lastErr = fmt.Errorf("응답 읽기 실패: %w", err)
-			utils.Error("Failed to read response body for user %s: %v", handle, err)
-			continue
-		}
+	for page := 1; page <= constants.MaxSolvedProblemPages; page++ {
+		url := fmt.Sprintf("%s/search/problem?query=s@%s&sort=id&direction=asc&page=%d",
+			c.baseURL, handle, page)
 
-		var userInfo UserInfo
-		if err := json.Unmarshal(body, &userInfo); err != nil {
-			lastErr = fmt.Errorf("사용자 정보 파싱 실패: %w", err)
-			utils.Error("Failed to parse user info for %s: %v", handle, err)
-			continue
+		var result ProblemSearchResponse
+		if err := c.getWithRetry(ctx, url, "solved problems", handle, &result); err != nil {
+			return nil, fmt.Errorf("해결한 문제 목록 조회 실패 (page %d): %w", page, err)
 		}
 
-		utils.Debug("Successfully fetched user info for %s (tier: %d, rating: %d)",
-			handle, userInfo.Tier, userInfo.Rating)
-		return &userInfo, nil
-	}
+		solved.Count = result.Count
+		solved.Items = append(solved.Items, result.Items...)
 
-	utils.Error("Failed to fetch user info for %s after %d attempts: %v", handle, constants.MaxRetries, lastErr)
-	return nil, lastErr
-}
+		// 마지막 페이지 도달 여부 확인
+		if len(result.Items) < constants.SolvedACPageSize || len(solved.Items) >= result.Count {
+			break
+		}
+	}
 
-// GetUserTop100 지정된 사용자의 TOP 100 문제를 가져옵니다
-func (c *SolvedACClient) GetUserTop100(handle string) (*Top100Response, error) {
-	if !utils.IsValidBaekjoonID(handle) {
-		return nil, fmt.Errorf("잘못된 핸들 형식: %s", handle)
+	if len(solved.Items) < solved.Count {
+		utils.Warn("Fetched only %d of %d solved problems for %s", len(solved.Items), solved.Count, handle)
 	}
 
-	url := fmt.Sprintf("%s/user/top_100?handle=%s", c.baseURL, handle)
-	return c.getUserTop100WithRetry(url, handle)
+	utils.Debug("Successfully fetched %d solved problems for %s", len(solved.Items), handle)
+	return solved, nil
 }
 
-// 재시도 로직을 포함한 TOP 100 조회
-func (c *SolvedACClient) getUserTop100WithRetry(url, handle string) (*Top100Response, error) {
+// getWithRetry 재시도 로직을 포함하여 GET 요청을 보내고 응답을 v에 디코딩합니다.
+// 재시도 간격은 지수적으로 늘어나며, 요청 한도 초과 시에는 Retry-After 동안 클라이언트의 모든 요청을 멈춥니다.
+// ctx가 취소되면 대기 중이든 요청 중이든 바로 ctx의 오류를 반환합니다.
+func (c *SolvedACClient) getWithRetry(ctx context.Context, url, what, handle string, v interface{}) error {
 	var lastErr error
 
 	for attempt := 0; attempt < constants.MaxRetries; attempt++ {
 		if attempt > 0 {
-			utils.Debug("Retrying top 100 fetch for %s (attempt %d/%d)", handle, attempt+1, constants.MaxRetries)
-			time.Sleep(constants.RetryDelay * time.Duration(attempt))
+			utils.Debug("Retrying %s fetch for %s (attempt %d/%d)", what, handle, attempt+1, constants.MaxRetries)
 		}
 
-		utils.Debug("Fetching top 100 problems from: %s", url)
-
-		resp, err := c.client.Get(url)
-		if err != nil {
-			lastErr = fmt.Errorf("TOP 100 조회 실패: %w", err)
-			utils.Warn("Attempt %d failed for top 100 %s: %v", attempt+1, handle, err)
-			continue
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+		if err := c.breaker.Allow(); err != nil {
+			utils.Warn("Skipping %s fetch for %s: %v", what, handle, err)
+			return err
 		}
-		defer resp.Body.Close()
 
-		if resp.StatusCode == http.StatusTooManyRequests {
-			lastErr = fmt.Errorf("요청 한도 초과")
-			utils.Warn("Rate limited for top 100 %s, attempt %d", handle, attempt+1)
-			time.Sleep(constants.RetryDelay * constants.APIRetryMultiplier)
-			continue
+		utils.Debug("Fetching %s from: %s", what, url)
+
+		retry, err := c.fetchJSON(ctx, url, v)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			utils.Debug("Canceled %s fetch for %s: %v", what, handle, ctx.Err())
+			return ctx.Err()
 		}
 
-		if resp.StatusCode != http.StatusOK {
-			lastErr = fmt.Errorf("API가 상태 코드 %d를 반환했습니다", resp.StatusCode)
-			utils.Warn("API returned non-200 status for top 100 %s: %d", handle, resp.StatusCode)
-			if resp.StatusCode >= 500 {
-				continue // 서버 에러는 재시도
-			}
+		lastErr = err
+		utils.Warn("Attempt %d failed for %s %s: %v", attempt+1, what, handle, err)
+		if !retry {
 			break // 클라이언트 에러는 즉시 반환
 		}
+		if attempt+1 == constants.MaxRetries {
+			break
+		}
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			lastErr = fmt.Errorf("응답 읽기 실패: %w", err)
-			utils.Error("Failed to read top 100 response body for %s: %v", handle, err)
+		delay := backoffDelay(attempt+1, c.retryDelay, constants.MaxRetryBackoff)
+		var limitErr *rateLimitError
+		if errors.As(err, &limitErr) {
+			// 다른 요청도 함께 멈추도록 요청 제한기에 대기 시간을 걸어 두고 다음 시도는 제한기에서 기다립니다
+			if limitErr.hasRetryAfter {
+				delay = limitErr.retryAfter
+			}
+			c.limiter.PauseFor(delay)
 			continue
 		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
 
-		var top100 Top100Response
-		if err := json.Unmarshal(body, &top100); err != nil {
-			lastErr = fmt.Errorf("TOP 100 파싱 실패: %w", err)
-			utils.Error("Failed to parse top 100 for %s: %v", handle, err)
-			continue
+	utils.Error("Failed to fetch %s for %s after %d attempts: %v", what, handle, constants.MaxRetries, lastErr)
+	return lastErr
+}
+
+// fetchJSON 단일 GET 요청을 수행하고 재시도 가능 여부와 오류를 반환합니다.
+// 이전 응답의 ETag가 있으면 If-None-Match로 보내고, 변경이 없으면(304) 저장해 둔 본문을 사용합니다.
+// 연결 실패와 서버 에러는 서킷 브레이커에 실패로 기록합니다.
+func (c *SolvedACClient) fetchJSON(ctx context.Context, url string, v interface{}) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+	cached, hasETag := c.cachedETag(url)
+	if hasETag {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			// 취소는 solved.ac 장애가 아니므로 실패로 세지 않습니다
+			c.breaker.Cancel()
+			return false, err
+		}
+		c.breaker.Failure()
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		c.breaker.Failure()
+		return true, fmt.Errorf("API가 상태 코드 %d를 반환했습니다", resp.StatusCode)
+	}
+	c.breaker.Success()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if retryAfter > constants.MaxRetryAfter {
+			retryAfter = constants.MaxRetryAfter
+		}
+		return true, &rateLimitError{retryAfter: retryAfter, hasRetryAfter: ok}
+	}
+
+	if resp.StatusCode == http.StatusNotModified && hasETag {
+		c.notModified.Add(1)
+		if err := json.Unmarshal(cached.body, v); err != nil {
+			return true, fmt.Errorf("응답 파싱 실패: %w", err)
 		}
+		return false, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		// 서버 에러만 재시도
+		return false, fmt.Errorf("API가 상태 코드 %d를 반환했습니다", resp.StatusCode)
+	}
 
-		utils.Debug("Successfully fetched %d top problems for %s", top100.Count, handle)
-		return &top100, nil
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return true, fmt.Errorf("응답 읽기 실패: %w", err)
 	}
 
-	utils.Error("Failed to fetch top 100 for %s after %d attempts: %v", handle, constants.MaxRetries, lastErr)
-	return nil, lastErr
+	if err := json.Unmarshal(body, v); err != nil {
+		return true, fmt.Errorf("응답 파싱 실패: %w", err)
+	}
+
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		c.storeETag(url, etagEntry{etag: etag, body: body})
+	}
+	return false, nil
 }
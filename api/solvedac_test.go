@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"discord-bot/api/solvedactest"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// newFakeClient 가짜 solved.ac 서버와 재시도 대기가 짧은 클라이언트를 만듭니다
+func newFakeClient(t *testing.T) (*SolvedACClient, *solvedactest.Server) {
+	t.Helper()
+	server := solvedactest.NewServer()
+	t.Cleanup(server.Close)
+
+	client := NewSolvedACClient(ClientOptions{
+		BaseURL:           server.BaseURL(),
+		RequestsPerSecond: 1000,
+		Burst:             100,
+		RetryDelay:        time.Millisecond,
+	})
+	return client, server
+}
+
+func TestSolvedACClientReadsFixtures(t *testing.T) {
+	client, _ := newFakeClient(t)
+	ctx := context.Background()
+
+	info, err := client.GetUserInfo(ctx, solvedactest.GoldUser)
+	if err != nil {
+		t.Fatalf("GetUserInfo: %v", err)
+	}
+	if info.Tier != 11 || info.Rating != 1487 || info.SolvedCount != 60 {
+		t.Errorf("unexpected user info: %+v", info)
+	}
+
+	top100, err := client.GetUserTop100(ctx, solvedactest.GoldUser)
+	if err != nil {
+		t.Fatalf("GetUserTop100: %v", err)
+	}
+	for i := 1; i < len(top100.Items); i++ {
+		if top100.Items[i].Level > top100.Items[i-1].Level {
+			t.Fatalf("expected TOP 100 to be sorted by level, got %+v", top100.Items)
+		}
+	}
+
+	if _, err := client.GetUserInfo(ctx, "nobody"); err == nil {
+		t.Fatal("expected an error for an unknown handle")
+	}
+}
+
+func TestSolvedACClientPaginatesSolvedProblems(t *testing.T) {
+	client, server := newFakeClient(t)
+
+	solved, err := client.GetUserSolvedProblems(context.Background(), solvedactest.GoldUser)
+	if err != nil {
+		t.Fatalf("GetUserSolvedProblems: %v", err)
+	}
+	if solved.Count != 60 || len(solved.Items) != 60 {
+		t.Fatalf("expected all 60 solved problems, got count %d with %d items", solved.Count, len(solved.Items))
+	}
+	if n := server.Requests("/search/problem"); n != 2 {
+		t.Errorf("expected two search pages, got %d requests", n)
+	}
+}
+
+func TestSolvedACClientRevalidatesWithETag(t *testing.T) {
+	client, server := newFakeClient(t)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetUserInfo(context.Background(), solvedactest.NewbieUser); err != nil {
+			t.Fatalf("GetUserInfo: %v", err)
+		}
+	}
+	if client.NotModifiedCount() != 1 {
+		t.Errorf("expected the second response to be a 304, got %d", client.NotModifiedCount())
+	}
+
+	// 내용이 바뀌면 새 본문을 받습니다
+	server.Solve(solvedactest.NewbieUser, solvedactest.Problem{ProblemID: 31001, Level: 2})
+	info, err := client.GetUserInfo(context.Background(), solvedactest.NewbieUser)
+	if err != nil {
+		t.Fatalf("GetUserInfo: %v", err)
+	}
+	if info.SolvedCount != 6 || client.NotModifiedCount() != 1 {
+		t.Errorf("expected a fresh body after the change, got %+v (304s: %d)", info, client.NotModifiedCount())
+	}
+}
+
+func TestSolvedACClientHonorsRetryAfter(t *testing.T) {
+	client, server := newFakeClient(t)
+	server.RateLimitNext(1, time.Second)
+
+	start := time.Now()
+	if _, err := client.GetUserInfo(context.Background(), solvedactest.NewbieUser); err != nil {
+		t.Fatalf("expected the request to succeed after the pause, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("expected to wait for Retry-After, returned after %v", elapsed)
+	}
+	if n := server.Requests("/user/show"); n != 2 {
+		t.Errorf("expected one retry, got %d requests", n)
+	}
+}
+
+func TestSolvedACClientRetriesServerErrors(t *testing.T) {
+	client, server := newFakeClient(t)
+	server.FailNext(2, http.StatusBadGateway)
+
+	if _, err := client.GetUserInfo(context.Background(), solvedactest.NewbieUser); err != nil {
+		t.Fatalf("expected the third attempt to succeed, got %v", err)
+	}
+	if n := server.Requests("/user/show"); n != 3 {
+		t.Errorf("expected three attempts, got %d", n)
+	}
+}
+
+func TestSolvedACClientDoesNotRetryClientErrors(t *testing.T) {
+	client, server := newFakeClient(t)
+	server.FailNext(1, http.StatusNotFound)
+
+	if _, err := client.GetUserInfo(context.Background(), solvedactest.NewbieUser); err == nil {
+		t.Fatal("expected the 404 to be returned")
+	}
+	if n := server.Requests("/user/show"); n != 1 {
+		t.Errorf("expected no retry for a 404, got %d requests", n)
+	}
+}
+
+func TestSolvedACClientOpensCircuit(t *testing.T) {
+	client, server := newFakeClient(t)
+	server.FailNext(100, http.StatusInternalServerError)
+
+	// 요청 하나가 3번씩 시도하므로 두 번째 요청 도중 연속 실패 기준(5)에 닿습니다
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetUserInfo(context.Background(), solvedactest.NewbieUser); err == nil {
+			t.Fatal("expected the server error")
+		}
+	}
+	sent := server.Requests("")
+
+	if _, err := client.GetUserInfo(context.Background(), solvedactest.NewbieUser); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if n := server.Requests(""); n != sent {
+		t.Errorf("expected no request while the circuit is open, got %d more", n-sent)
+	}
+}
+
+func TestSolvedACClientStopsOnCancel(t *testing.T) {
+	client, server := newFakeClient(t)
+	server.SetLatency(time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetUserInfo(ctx, solvedactest.NewbieUser); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline error, got %v", err)
+	}
+
+	// 취소된 요청은 solved.ac 장애로 세지 않습니다
+	if client.breaker.failures != 0 {
+		t.Errorf("expected a canceled request not to count as a failure, got %d", client.breaker.failures)
+	}
+}
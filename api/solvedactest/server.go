@@ -0,0 +1,356 @@
+// Package solvedactest 네트워크 없이 solved.ac API를 흉내 내는 테스트용 서버를 제공합니다.
+// solved.ac 응답 형식에 맞춰 직접 작성한 fixtures의 가상 사용자로 시작하며, 문제 해결, 오류/429 응답, 응답 지연을 테스트에서 만들어 낼 수 있습니다.
+package solvedactest
+
+import (
+	"crypto/sha1"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fixtures에 들어 있는 가상 사용자
+const (
+	GoldUser   = "fixture_gold"   // Gold V, 해결한 문제 60개 (문제 검색 응답이 두 페이지)
+	NewbieUser = "fixture_newbie" // Bronze III, 해결한 문제 5개
+)
+
+// 문제 검색 API의 페이지당 항목 수 (solved.ac와 같음)
+const pageSize = 50
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// Problem 가짜 서버가 돌려주는 문제 정보입니다
+type Problem struct {
+	ProblemID         int     `json:"problemId"`
+	TitleKo           string  `json:"titleKo"`
+	Level             int     `json:"level"`
+	AcceptedUserCount int     `json:"acceptedUserCount"`
+	AverageTries      float64 `json:"averageTries"`
+}
+
+// solvedProblem 해결한 문제와 응답에 그대로 내보낼 JSON입니다
+type solvedProblem struct {
+	Problem
+	raw json.RawMessage
+}
+
+type user struct {
+	info   map[string]interface{} // user/show 응답
+	solved []solvedProblem        // 문제 번호 순
+	top100 []json.RawMessage      // fixtures의 TOP 100 응답 (해결한 문제가 바뀌면 nil로 두고 다시 계산)
+}
+
+// Server solved.ac API v3의 사용자 조회, TOP 100, 문제 검색을 흉내 내는 httptest 서버입니다
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	users      map[string]*user
+	failures   int // 남은 강제 오류 응답 수
+	failStatus int
+	limited    int // 남은 429 응답 수
+	retryAfter time.Duration
+	latency    time.Duration
+	requests   map[string]int // 경로별 요청 수
+}
+
+// NewServer fixtures의 사용자를 불러와 가짜 solved.ac 서버를 시작합니다. 테스트가 끝나면 Close를 호출하세요.
+func NewServer() *Server {
+	s := &Server{
+		users:    make(map[string]*user),
+		requests: make(map[string]int),
+	}
+	for _, handle := range []string{GoldUser, NewbieUser} {
+		if err := s.loadFixture(handle); err != nil {
+			panic(fmt.Sprintf("solvedactest: %s fixture를 읽을 수 없습니다: %v", handle, err))
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/user/show", s.handleUserShow)
+	mux.HandleFunc("/api/v3/user/top_100", s.handleTop100)
+	mux.HandleFunc("/api/v3/search/problem", s.handleSearch)
+	s.Server = httptest.NewServer(s.intercept(mux))
+	return s
+}
+
+// BaseURL api.ClientOptions.BaseURL에 넣을 주소를 반환합니다
+func (s *Server) BaseURL() string {
+	return s.URL + "/api/v3"
+}
+
+func (s *Server) loadFixture(handle string) error {
+	var info map[string]interface{}
+	if err := readFixture("user_show", handle, &info); err != nil {
+		return err
+	}
+	var top100, search struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := readFixture("user_top_100", handle, &top100); err != nil {
+		return err
+	}
+	if err := readFixture("search_problem", handle, &search); err != nil {
+		return err
+	}
+
+	u := &user{info: info, top100: top100.Items}
+	for _, raw := range search.Items {
+		var p Problem
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return err
+		}
+		u.solved = append(u.solved, solvedProblem{Problem: p, raw: raw})
+	}
+	s.users[handle] = u
+	return nil
+}
+
+func readFixture(endpoint, handle string, v interface{}) error {
+	data, err := fixtures.ReadFile(path.Join("fixtures", endpoint, handle+".json"))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// AddUser 해결한 문제가 없는 사용자를 추가합니다
+func (s *Server) AddUser(handle string, tier, rating int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users[handle] = &user{info: map[string]interface{}{
+		"handle":      handle,
+		"bio":         "",
+		"tier":        tier,
+		"rating":      rating,
+		"solvedCount": 0,
+		"class":       0,
+		"rank":        0,
+	}}
+}
+
+// Solve 사용자가 문제를 해결한 것으로 기록합니다. 이미 해결한 문제는 무시합니다.
+func (s *Server) Solve(handle string, problems ...Problem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := s.mustUser(handle)
+	for _, p := range problems {
+		if u.hasSolved(p.ProblemID) {
+			continue
+		}
+		raw, _ := json.Marshal(p)
+		u.solved = append(u.solved, solvedProblem{Problem: p, raw: raw})
+	}
+	sort.Slice(u.solved, func(i, j int) bool { return u.solved[i].ProblemID < u.solved[j].ProblemID })
+	u.info["solvedCount"] = len(u.solved)
+	u.top100 = nil
+}
+
+// SetBio 사용자의 자기소개를 바꿉니다 (계정 인증 테스트용)
+func (s *Server) SetBio(handle, bio string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mustUser(handle).info["bio"] = bio
+}
+
+// Solved 사용자가 해결한 문제를 문제 번호 순으로 반환합니다
+func (s *Server) Solved(handle string) []Problem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := s.mustUser(handle)
+	problems := make([]Problem, len(u.solved))
+	for i, p := range u.solved {
+		problems[i] = p.Problem
+	}
+	return problems
+}
+
+// FailNext 다음 n개의 요청에 status 상태 코드로 응답합니다
+func (s *Server) FailNext(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures, s.failStatus = n, status
+}
+
+// RateLimitNext 다음 n개의 요청에 429와 Retry-After(초 단위)로 응답합니다
+func (s *Server) RateLimitNext(n int, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limited, s.retryAfter = n, retryAfter
+}
+
+// SetLatency 모든 응답을 d만큼 늦춥니다. 클라이언트가 요청을 취소하면 바로 끝냅니다.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// Requests 경로(예: "/user/show")로 들어온 요청 수를 반환합니다. 빈 문자열이면 전체 요청 수입니다.
+func (s *Server) Requests(endpoint string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if endpoint != "" {
+		return s.requests["/api/v3"+endpoint]
+	}
+	total := 0
+	for _, n := range s.requests {
+		total += n
+	}
+	return total
+}
+
+// intercept 요청 수를 세고, 지연과 강제 오류/429 응답을 적용합니다
+func (s *Server) intercept(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[r.URL.Path]++
+		latency := s.latency
+		status := 0
+		var retryAfter time.Duration
+		switch {
+		case s.limited > 0:
+			s.limited--
+			status, retryAfter = http.StatusTooManyRequests, s.retryAfter
+		case s.failures > 0:
+			s.failures--
+			status = s.failStatus
+		}
+		s.mu.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter/time.Second)))
+		}
+		if status != 0 {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleUserShow(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	u, ok := s.users[r.URL.Query().Get("handle")]
+	var body []byte
+	if ok {
+		body, _ = json.Marshal(u.info)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, r, body)
+}
+
+func (s *Server) handleTop100(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	u, ok := s.users[r.URL.Query().Get("handle")]
+	var body []byte
+	if ok {
+		items := u.top100
+		if items == nil {
+			items = u.computeTop100()
+		}
+		body, _ = json.Marshal(map[string]interface{}{"count": len(items), "items": items})
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, r, body)
+}
+
+// handleSearch `s@<핸들>` 검색만 지원하며 해결한 문제를 문제 번호 순으로 페이지 단위로 돌려줍니다
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	s.mu.Lock()
+	items := []json.RawMessage{}
+	count := 0
+	if u, ok := s.users[strings.TrimPrefix(query.Get("query"), "s@")]; ok {
+		count = len(u.solved)
+		for i := (page - 1) * pageSize; i < count && i < page*pageSize; i++ {
+			items = append(items, u.solved[i].raw)
+		}
+	}
+	body, _ := json.Marshal(map[string]interface{}{"count": count, "items": items})
+	s.mu.Unlock()
+
+	writeJSON(w, r, body)
+}
+
+func (s *Server) mustUser(handle string) *user {
+	u, ok := s.users[handle]
+	if !ok {
+		panic("solvedactest: 알 수 없는 사용자 " + handle)
+	}
+	return u
+}
+
+func (u *user) hasSolved(problemID int) bool {
+	for _, p := range u.solved {
+		if p.ProblemID == problemID {
+			return true
+		}
+	}
+	return false
+}
+
+// computeTop100 해결한 문제 중 난이도가 높은 순으로 최대 100개를 고릅니다
+func (u *user) computeTop100() []json.RawMessage {
+	sorted := append([]solvedProblem(nil), u.solved...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Level > sorted[j].Level })
+	if len(sorted) > 100 {
+		sorted = sorted[:100]
+	}
+	items := make([]json.RawMessage, len(sorted))
+	for i, p := range sorted {
+		items[i] = p.raw
+	}
+	return items
+}
+
+// writeJSON 응답 본문의 해시를 ETag로 붙이고, If-None-Match가 같으면 304로 응답합니다
+func writeJSON(w http.ResponseWriter, r *http.Request, body []byte) {
+	sum := sha1.Sum(body)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"discord-bot/api"
 	"discord-bot/bot"
 	"discord-bot/config"
@@ -18,10 +19,13 @@ import (
 )
 
 type Application struct {
+	ctx               context.Context
+	cancel            context.CancelFunc
 	config            *config.Config
 	session           *discordgo.Session
-	storage           interfaces.StorageRepository
+	storages          interfaces.GuildStorageProvider
 	apiClient         interfaces.APIClient
+	apiCache          *api.CachingClient
 	commandHandler    *bot.CommandHandler
 	scoreboardManager *bot.ScoreboardManager
 	scheduler         *scheduler.Scheduler
@@ -29,6 +33,8 @@ type Application struct {
 
 func New() (*Application, error) {
 	app := &Application{}
+	// 종료 시 진행 중인 solved.ac 요청과 점수 계산을 취소하기 위한 최상위 context
+	app.ctx, app.cancel = context.WithCancel(context.Background())
 
 	if err := app.loadConfig(); err != nil {
 		return nil, err
@@ -57,11 +63,15 @@ func (app *Application) loadConfig() error {
 }
 
 func (app *Application) initializeDependencies() error {
-	// 단일 API 클라이언트 인스턴스 생성
-	app.apiClient = api.NewSolvedACClient()
+	// 단일 API 클라이언트 인스턴스 생성 (응답 캐시를 거쳐 solved.ac에 요청)
+	app.apiCache = api.NewCachingClient(api.NewSolvedACClient(api.ClientOptions{
+		RequestsPerSecond: app.config.API.RequestsPerSecond,
+		Burst:             app.config.API.Burst,
+	}))
+	app.apiClient = app.apiCache
 	
-	// API 클라이언트를 주입하여 Storage 생성
-	app.storage = storage.NewStorage(app.apiClient)
+	// API 클라이언트를 주입하여 길드별 Storage 레지스트리 생성
+	app.storages = storage.NewRegistry(app.apiClient, app.config.Storage.DataDir, app.config.Storage.Backend, app.config.Discord.LegacyGuildID)
 	
 	return nil
 }
@@ -80,15 +90,16 @@ func (app *Application) initializeDiscord() error {
 func (app *Application) setupHandlers() {
 	// 의존성 주입을 통한 컴포넌트 생성
 	calculator := scoring.NewScoreCalculator(app.apiClient)
-	app.scoreboardManager = bot.NewScoreboardManager(app.storage, calculator, app.apiClient)
-	app.commandHandler = bot.NewCommandHandler(app.storage, app.apiClient, app.scoreboardManager)
+	app.scoreboardManager = bot.NewScoreboardManager(app.storages, calculator, app.apiClient)
+	app.commandHandler = bot.NewCommandHandler(app.ctx, app.storages, app.apiClient, app.scoreboardManager)
 
 	app.session.AddHandler(app.commandHandler.HandleMessage)
+	app.session.AddHandler(app.commandHandler.HandleInteraction)
 	app.session.AddHandler(app.handleReady)
 }
 
 func (app *Application) initializeScheduler() {
-	app.scheduler = scheduler.NewScheduler(app.session, app.config, app.scoreboardManager)
+	app.scheduler = scheduler.NewScheduler(app.ctx, app.session, app.config, app.scoreboardManager, app.storages)
 }
 
 func (app *Application) Start() error {
@@ -101,12 +112,17 @@ func (app *Application) Start() error {
 			app.config.Schedule.ScoreboardHour,
 			app.config.Schedule.ScoreboardMinute,
 		)
-		log.Printf("매일 %02d:%02d에 자동으로 스코어보드가 띄워집니다.",
+		log.Printf("매일 %02d:%02d에 서버별 공지 채널로 스코어보드가 전송됩니다.",
 			app.config.Schedule.ScoreboardHour, app.config.Schedule.ScoreboardMinute)
 	} else {
-		log.Println("DISCORD_CHANNEL_ID가 설정되지 않았습니다. 스코어보드가 비활성화되었습니다.")
+		log.Println("ENABLE_AUTO_SCOREBOARD가 꺼져 있어 자동 스코어보드가 비활성화되었습니다.")
 	}
 
+	app.scheduler.StartScorePolling(app.config.Schedule.ScorePollInterval)
+	app.scheduler.StartSnapshotWatcher()
+	app.scheduler.StartResultsWatcher()
+	app.scheduler.StartBackups(app.config.Backup.Interval, app.config.Backup.Retention)
+
 	app.printStartupMessage()
 	return nil
 }
@@ -115,7 +131,7 @@ func (app *Application) printStartupMessage() {
 	fmt.Println("디스코드 봇이 실행되었습니다!")
 	fmt.Println("📋 사용 가능한 명령어: !help")
 	if app.config.Schedule.Enabled {
-		fmt.Printf("⏰ 매일 %02d:%02d에 자동으로 스코어보드가 전송됩니다.\n",
+		fmt.Printf("⏰ 매일 %02d:%02d에 서버별 공지 채널로 스코어보드가 전송됩니다.\n",
 			app.config.Schedule.ScoreboardHour, app.config.Schedule.ScoreboardMinute)
 	}
 }
@@ -135,11 +151,21 @@ func (app *Application) Run() error {
 
 func (app *Application) handleReady(s *discordgo.Session, event *discordgo.Ready) {
 	// TODO: Welcome message
+
+	if app.config.Features.EnableSlashCommands {
+		if err := app.commandHandler.RegisterSlashCommands(s); err != nil {
+			log.Printf("⚠️ %v", err)
+		}
+	}
 }
 
 func (app *Application) Stop() error {
 	fmt.Println("🔄 봇을 종료하는 중...")
 
+	if app.cancel != nil {
+		app.cancel()
+	}
+
 	if app.scheduler != nil {
 		app.scheduler.Stop()
 	}
@@ -148,6 +174,16 @@ func (app *Application) Stop() error {
 		app.session.Close()
 	}
 
+	if app.storages != nil {
+		if err := app.storages.Close(); err != nil {
+			log.Printf("⚠️ 저장소를 닫지 못했습니다: %v", err)
+		}
+	}
+
+	if app.apiCache != nil {
+		log.Printf("solved.ac 캐시 통계: %s", app.apiCache.Stats())
+	}
+
 	fmt.Println("봇이 정상적으로 종료되었습니다.")
 	return nil
 }
@@ -0,0 +1,232 @@
+package bot
+
+import (
+	"crypto/rand"
+	"discord-bot/constants"
+	"discord-bot/errors"
+	"discord-bot/interfaces"
+	"discord-bot/utils"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// verificationCodeAlphabet 인증 코드에 쓰는 문자입니다. 헷갈리기 쉬운 0/O, 1/I/L은 뺐습니다
+const verificationCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+// pendingVerification 아직 확인되지 않은 계정 연동 요청입니다
+type pendingVerification struct {
+	baekjoonID string
+	code       string
+	expiresAt  time.Time
+}
+
+type verificationKey struct {
+	guildID string
+	userID  string
+}
+
+// verificationStore 길드와 디스코드 사용자별로 진행 중인 인증 코드를 보관합니다.
+// 코드는 짧은 시간만 유효하므로 저장소에 남기지 않고 메모리에만 둡니다.
+type verificationStore struct {
+	mu      sync.Mutex
+	pending map[verificationKey]pendingVerification
+	now     func() time.Time
+}
+
+func newVerificationStore() *verificationStore {
+	return &verificationStore{
+		pending: make(map[verificationKey]pendingVerification),
+		now:     time.Now,
+	}
+}
+
+// issue 새 인증 코드를 만듭니다. 같은 사용자의 이전 요청은 대체됩니다
+func (vs *verificationStore) issue(key verificationKey, baekjoonID string) (pendingVerification, error) {
+	code, err := newVerificationCode()
+	if err != nil {
+		return pendingVerification{}, err
+	}
+
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+	vs.prune()
+
+	pending := pendingVerification{
+		baekjoonID: baekjoonID,
+		code:       code,
+		expiresAt:  vs.now().Add(constants.VerificationCodeTTL),
+	}
+	vs.pending[key] = pending
+	return pending, nil
+}
+
+// lookup 만료되지 않은 인증 요청을 반환합니다
+func (vs *verificationStore) lookup(key verificationKey) (pendingVerification, bool) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+	vs.prune()
+
+	pending, ok := vs.pending[key]
+	return pending, ok
+}
+
+// remove 확인이 끝난 인증 요청을 지웁니다
+func (vs *verificationStore) remove(key verificationKey) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+	delete(vs.pending, key)
+}
+
+// prune 호출자가 mu를 잡은 상태에서 만료된 요청을 지웁니다
+func (vs *verificationStore) prune() {
+	now := vs.now()
+	for key, pending := range vs.pending {
+		if !now.Before(pending.expiresAt) {
+			delete(vs.pending, key)
+		}
+	}
+}
+
+// newVerificationCode 예측할 수 없는 일회용 인증 코드를 만듭니다
+func newVerificationCode() (string, error) {
+	var sb strings.Builder
+	sb.WriteString(constants.VerificationCodePrefix)
+	max := big.NewInt(int64(len(verificationCodeAlphabet)))
+	for i := 0; i < constants.VerificationCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(verificationCodeAlphabet[n.Int64()])
+	}
+	return sb.String(), nil
+}
+
+// handleLinkStart 백준 ID 소유 인증을 시작하고 solved.ac 자기소개에 넣을 코드를 알려줍니다
+func (ch *CommandHandler) handleLinkStart(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	baekjoonID := args.String("baekjoon_id")
+	if link := g.storage.FindAccountLinkByBaekjoonID(baekjoonID); link != nil {
+		if link.DiscordUserID == m.Author.ID {
+			errors.SendDiscordInfo(s, m.ChannelID, fmt.Sprintf("이미 백준 ID %s와(과) 연동되어 있습니다.", link.BaekjoonID))
+			return
+		}
+		errorHandlers.Validation().HandleInvalidParams("ACCOUNT_LINKED_TO_OTHER",
+			fmt.Sprintf("Baekjoon ID %s is already linked to another user", baekjoonID),
+			fmt.Sprintf("백준 ID %s은(는) 이미 다른 디스코드 계정에 연동되어 있습니다. 본인 계정이라면 관리자에게 문의하세요.", baekjoonID))
+		return
+	}
+
+	// 존재하지 않는 ID로 코드를 발급하지 않도록 먼저 확인
+	if _, err := ch.client.GetUserInfo(s.Context(), baekjoonID); err != nil {
+		errorHandlers.API().HandleBaekjoonUserNotFound(baekjoonID, err)
+		return
+	}
+
+	pending, err := ch.verifications.issue(verificationKey{g.guildID, m.Author.ID}, baekjoonID)
+	if err != nil {
+		errorHandlers.System().HandleSystemError("VERIFICATION_CODE_FAILED",
+			"Failed to generate verification code", "인증 코드를 만들지 못했습니다. 잠시 후 다시 시도해주세요.", err)
+		return
+	}
+
+	message := fmt.Sprintf("🔑 **%s** 계정 인증 코드: `%s`\n"+
+		"1. %s 에서 프로필 편집 → 자기소개에 위 코드를 추가하세요.\n"+
+		"2. %d분 안에 `!연동 확인`을 입력하세요.\n"+
+		"인증이 끝나면 자기소개에서 코드를 지워도 됩니다.",
+		baekjoonID, pending.code, constants.SolvedACProfileURL+baekjoonID, int(constants.VerificationCodeTTL.Minutes()))
+	if _, err := s.ChannelMessageSend(m.ChannelID, message); err != nil {
+		utils.Error("인증 코드 메시지 전송 실패: %v", err)
+	}
+}
+
+// handleLinkCheck solved.ac 자기소개에 인증 코드가 있는지 확인하고 계정을 연동합니다
+func (ch *CommandHandler) handleLinkCheck(s *commandSession, m *discordgo.MessageCreate, _ *commandArgs) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	key := verificationKey{g.guildID, m.Author.ID}
+	pending, ok := ch.verifications.lookup(key)
+	if !ok {
+		errors.SendDiscordInfo(s, m.ChannelID, "진행 중인 인증이 없거나 코드가 만료되었습니다. `!연동 시작 <백준ID>`로 새 코드를 받으세요.")
+		return
+	}
+
+	// 방금 수정한 자기소개를 확인해야 하므로 캐시된 정보를 쓰지 않습니다
+	if cache, ok := ch.client.(interfaces.CacheInvalidator); ok {
+		cache.Invalidate(pending.baekjoonID)
+	}
+	userInfo, err := ch.client.GetUserInfo(s.Context(), pending.baekjoonID)
+	if err != nil {
+		errorHandlers.API().HandleBaekjoonUserNotFound(pending.baekjoonID, err)
+		return
+	}
+	if !strings.Contains(userInfo.Bio, pending.code) {
+		errors.SendDiscordWarning(s, m.ChannelID, fmt.Sprintf(
+			"%s의 solved.ac 자기소개에서 인증 코드 `%s`를 찾지 못했습니다. 자기소개를 저장했는지 확인하고 잠시 후 다시 시도하세요.",
+			pending.baekjoonID, pending.code))
+		return
+	}
+
+	if err := g.storage.LinkAccount(m.Author.ID, pending.baekjoonID); err != nil {
+		errorHandlers.Validation().HandleInvalidParams("ACCOUNT_LINK_FAILED",
+			fmt.Sprintf("Failed to link account: %v", err), "계정 연동에 실패했습니다: "+err.Error())
+		return
+	}
+	ch.verifications.remove(key)
+
+	errors.SendDiscordSuccess(s, m.ChannelID, fmt.Sprintf(
+		"백준 ID %s 인증이 완료되었습니다. 이제 `!등록 <이름> %s`로 대회에 등록할 수 있습니다.", pending.baekjoonID, pending.baekjoonID))
+}
+
+// handleLinkStatus 연동된 백준 계정과 진행 중인 인증을 보여줍니다
+func (ch *CommandHandler) handleLinkStatus(s *commandSession, m *discordgo.MessageCreate, _ *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	var sb strings.Builder
+	if link := g.storage.GetAccountLink(m.Author.ID); link != nil {
+		sb.WriteString(fmt.Sprintf("🔗 연동된 백준 ID: **%s** (%s 인증)", link.BaekjoonID, utils.FormatDateTime(link.VerifiedAt)))
+	} else {
+		sb.WriteString("🔗 연동된 백준 계정이 없습니다. `!연동 시작 <백준ID>`로 인증하세요.")
+	}
+	if pending, ok := ch.verifications.lookup(verificationKey{g.guildID, m.Author.ID}); ok {
+		sb.WriteString(fmt.Sprintf("\n진행 중인 인증: %s (코드 `%s`, %s까지)",
+			pending.baekjoonID, pending.code, pending.expiresAt.Format(constants.TimeFormat)))
+	}
+
+	if _, err := s.ChannelMessageSend(m.ChannelID, sb.String()); err != nil {
+		utils.Error("계정 연동 상태 메시지 전송 실패: %v", err)
+	}
+}
+
+// handleUnlink 계정 연동을 해제합니다. 이미 등록된 대회 참가 정보는 그대로 남습니다
+func (ch *CommandHandler) handleUnlink(s *commandSession, m *discordgo.MessageCreate, _ *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	if err := g.storage.UnlinkAccount(m.Author.ID); err != nil {
+		errors.SendDiscordInfo(s, m.ChannelID, err.Error())
+		return
+	}
+	errors.SendDiscordSuccess(s, m.ChannelID, "계정 연동이 해제되었습니다.")
+}
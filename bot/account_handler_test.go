@@ -0,0 +1,40 @@
+package bot
+
+import (
+	"discord-bot/constants"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerificationStore(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	vs := newVerificationStore()
+	vs.now = func() time.Time { return now }
+	key := verificationKey{guildID: "guild", userID: "user"}
+
+	first, err := vs.issue(key, "koosaga")
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	if !strings.HasPrefix(first.code, constants.VerificationCodePrefix) ||
+		len(first.code) != len(constants.VerificationCodePrefix)+constants.VerificationCodeLength {
+		t.Fatalf("code = %q", first.code)
+	}
+
+	second, err := vs.issue(key, "cubelover")
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	if got, ok := vs.lookup(key); !ok || got.baekjoonID != "cubelover" || got.code != second.code {
+		t.Fatalf("lookup = %+v, %v; want the latest request", got, ok)
+	}
+	if _, ok := vs.lookup(verificationKey{guildID: "other", userID: "user"}); ok {
+		t.Fatal("requests should be scoped to the guild")
+	}
+
+	now = now.Add(constants.VerificationCodeTTL)
+	if _, ok := vs.lookup(key); ok {
+		t.Fatal("expired request should not be returned")
+	}
+}
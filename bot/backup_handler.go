@@ -0,0 +1,84 @@
+package bot
+
+import (
+	"discord-bot/constants"
+	"discord-bot/errors"
+	"discord-bot/utils"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// handleBackupCreate 지금 상태를 백업합니다
+func (ch *CommandHandler) handleBackupCreate(s *commandSession, m *discordgo.MessageCreate, _ *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	backup, err := g.storage.CreateBackup()
+	if err != nil {
+		errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+		errorHandlers.System().HandleSystemError("BACKUP_CREATE_FAILED",
+			"Failed to create backup", "백업 생성에 실패했습니다.", err)
+		return
+	}
+	errors.SendDiscordSuccess(s, m.ChannelID, fmt.Sprintf("백업이 생성되었습니다: `%s`", backup.ID))
+}
+
+// handleBackupRestore 백업 시점으로 서버 데이터를 되돌립니다
+func (ch *CommandHandler) handleBackupRestore(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	backupID := args.String("backup_id")
+	if err := g.storage.RestoreBackup(backupID); err != nil {
+		errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+		errorHandlers.System().HandleSystemError("BACKUP_RESTORE_FAILED",
+			"Failed to restore backup "+backupID, "백업 복원에 실패했습니다: "+err.Error(), err)
+		return
+	}
+	ch.scoreboardManager.InvalidateGuildSnapshots(g.guildID)
+	errors.SendDiscordSuccess(s, m.ChannelID,
+		fmt.Sprintf("백업 `%s`(으)로 복원되었습니다. 복원 직전 상태도 새 백업으로 보관되었습니다.", backupID))
+}
+
+// handleBackupList 최근 백업 목록을 보여줍니다
+func (ch *CommandHandler) handleBackupList(s *commandSession, m *discordgo.MessageCreate, _ *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	backups, err := g.storage.ListBackups()
+	if err != nil {
+		errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+		errorHandlers.System().HandleSystemError("BACKUP_LIST_FAILED",
+			"Failed to list backups", "백업 목록을 불러오지 못했습니다.", err)
+		return
+	}
+
+	if len(backups) == 0 {
+		errors.SendDiscordInfo(s, m.ChannelID, "저장된 백업이 없습니다. `!백업 생성`으로 백업을 만들 수 있습니다.")
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("💾 **백업 목록** (%d개)\n```\n", len(backups)))
+	for i, backup := range backups {
+		if i >= constants.MaxBackupListEntries {
+			sb.WriteString(fmt.Sprintf("... 외 %d개\n", len(backups)-constants.MaxBackupListEntries))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("%-18s %s  %6.1f KB\n",
+			backup.ID, backup.CreatedAt.Format(constants.DateTimeFormat), float64(backup.Size)/1024))
+	}
+	sb.WriteString("```\n복원: `!백업 복원 <백업ID>`")
+
+	if _, err := s.ChannelMessageSend(m.ChannelID, sb.String()); err != nil {
+		utils.Error("백업 목록 메시지 전송 실패: %v", err)
+	}
+}
@@ -0,0 +1,342 @@
+package bot
+
+import (
+	"context"
+	"discord-bot/constants"
+	"discord-bot/utils"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// permissionLevel 명령을 실행할 수 있는 사용자 범위입니다
+type permissionLevel int
+
+const (
+	permissionEveryone     permissionLevel = iota
+	permissionAdmin                        // 서버 관리자만 (DM에서는 사용할 수 없음)
+	permissionAdminInGuild                 // 서버 채널에서는 관리자만, DM에서는 누구나
+)
+
+// channelScope 명령을 사용할 수 있는 위치입니다
+type channelScope int
+
+const (
+	scopeAnywhere  channelScope = iota
+	scopeGuildOnly              // 서버 채널에서만
+)
+
+// helpCategory 도움말에서 명령을 묶어 보여줄 분류입니다
+type helpCategory int
+
+const (
+	categoryParticipant helpCategory = iota
+	categoryAdmin
+	categoryMisc
+)
+
+// argKind 명령 인자의 형식입니다
+type argKind int
+
+const (
+	argText argKind = iota
+	argBaekjoonID
+	argInteger
+	argDate
+	argChoice
+)
+
+// argSpec 명령 인자 하나의 선언입니다
+type argSpec struct {
+	name         string // 영문 이름 (슬래시 명령 옵션 이름)
+	label        string // 사용법과 도움말에 표시할 이름
+	description  string
+	kind         argKind
+	optional     bool
+	rest         bool     // 남은 단어를 모두 이어 붙여 하나의 값으로 받음 (마지막 인자만)
+	choices      []string // argChoice에서 허용하는 값 (소문자)
+	min, max     int      // argInteger 허용 범위 (max가 0이면 상한 없음)
+	autocomplete bool     // 슬래시 명령에서 참가자 백준 ID 자동완성
+}
+
+// commandFunc 인자 검증을 마친 명령을 실행합니다
+type commandFunc func(ch *CommandHandler, s *commandSession, m *discordgo.MessageCreate, args *commandArgs)
+
+// commandSpec 명령 하나의 선언입니다. 라우팅, 인자 검증, 도움말, 슬래시 명령 정의가 모두 여기서 만들어집니다
+type commandSpec struct {
+	name        string   // 영문 이름 (별칭이자 슬래시 명령 이름)
+	koName      string   // 도움말에 표시하는 기본 이름 (없으면 name)
+	aliases     []string // 추가 별칭
+	summary     string   // 한 줄 설명 (슬래시 명령 설명으로도 사용, 100자 이하)
+	details     string   // `!도움말 <명령어>`에만 표시하는 추가 설명
+	args        []argSpec
+	competition bool // `#대회ID` 선택자 지원
+	permission  permissionLevel
+	scope       channelScope
+	category    helpCategory
+	run         commandFunc
+
+	subcommands       []*commandSpec
+	defaultSubcommand string // 하위 명령을 생략했을 때 실행할 하위 명령 이름
+}
+
+// displayName 도움말에 표시할 이름을 반환합니다
+func (c *commandSpec) displayName() string {
+	if c.koName != "" {
+		return c.koName
+	}
+	return c.name
+}
+
+// matches 이름이나 별칭이 일치하는지 확인합니다 (대소문자 무시)
+func (c *commandSpec) matches(name string) bool {
+	if strings.EqualFold(c.name, name) || strings.EqualFold(c.koName, name) {
+		return true
+	}
+	for _, alias := range c.aliases {
+		if strings.EqualFold(alias, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *commandSpec) findSubcommand(name string) *commandSpec {
+	for _, sub := range c.subcommands {
+		if sub.matches(name) {
+			return sub
+		}
+	}
+	return nil
+}
+
+// usage 명령의 사용법 문자열을 만듭니다 (예: `!기록 <백준ID> [일수] [#대회ID]`)
+func (c *commandSpec) usage(parent *commandSpec) string {
+	var parts []string
+	if parent != nil {
+		parts = append(parts, constants.CommandPrefix+parent.displayName())
+	}
+	if parent != nil {
+		parts = append(parts, c.displayName())
+	} else {
+		parts = append(parts, constants.CommandPrefix+c.displayName())
+	}
+
+	if len(c.subcommands) > 0 {
+		names := make([]string, len(c.subcommands))
+		for i, sub := range c.subcommands {
+			names[i] = sub.displayName()
+		}
+		parts = append(parts, "<"+strings.Join(names, "|")+">")
+	}
+
+	for _, arg := range c.args {
+		label := arg.label
+		if arg.kind == argChoice {
+			label = strings.Join(arg.choices, "|")
+		}
+		if arg.optional {
+			parts = append(parts, "["+label+"]")
+		} else {
+			parts = append(parts, "<"+label+">")
+		}
+	}
+	if c.competition {
+		parts = append(parts, "[#대회ID]")
+	}
+	return strings.Join(parts, " ")
+}
+
+// commandArgs 검증된 명령 인자입니다
+type commandArgs struct {
+	values   map[string]string
+	selector int // `#대회ID` 선택자 (없으면 0)
+}
+
+// String 인자 값을 반환합니다 (없으면 빈 문자열)
+func (a *commandArgs) String(name string) string {
+	return a.values[name]
+}
+
+// Has 인자가 입력되었는지 확인합니다
+func (a *commandArgs) Has(name string) bool {
+	_, exists := a.values[name]
+	return exists
+}
+
+// Int 정수 인자 값을 반환합니다. 입력되지 않았으면 defaultValue를 반환합니다
+func (a *commandArgs) Int(name string, defaultValue int) int {
+	value, exists := a.values[name]
+	if !exists {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue
+	}
+	return n
+}
+
+// commandRegistry 선언된 명령 목록입니다
+type commandRegistry struct {
+	commands []*commandSpec
+}
+
+func newCommandRegistry(commands []*commandSpec) *commandRegistry {
+	return &commandRegistry{commands: commands}
+}
+
+// find 이름이나 별칭으로 명령을 찾습니다
+func (r *commandRegistry) find(name string) *commandSpec {
+	for _, cmd := range r.commands {
+		if cmd.matches(name) {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// dispatch 명령의 사용 위치, 권한, 인자를 검증한 뒤 실행합니다
+func (ch *CommandHandler) dispatch(s *commandSession, m *discordgo.MessageCreate, cmd *commandSpec, params []string, selector int) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+
+	if cmd.scope == scopeGuildOnly && m.GuildID == "" {
+		errorHandlers.Validation().HandleInvalidParams(strings.ToUpper(cmd.name)+"_DM_NOT_ALLOWED",
+			fmt.Sprintf("Command %s used in DM", cmd.name),
+			"이 명령어는 서버 채널에서만 사용할 수 있습니다.")
+		return
+	}
+
+	if !ch.hasPermission(s, m, cmd.permission) {
+		errorHandlers.Validation().HandleInsufficientPermissions()
+		return
+	}
+
+	parent := (*commandSpec)(nil)
+	if len(cmd.subcommands) > 0 {
+		subName := cmd.defaultSubcommand
+		if len(params) > 0 {
+			subName = params[0]
+			params = params[1:]
+		}
+
+		sub := cmd.findSubcommand(subName)
+		if sub == nil {
+			reason := "하위 명령어를 입력하세요."
+			if subName != "" {
+				reason = fmt.Sprintf("알 수 없는 하위 명령어입니다: %s", subName)
+			}
+			ch.sendUsageError(s, m, cmd, nil, reason)
+			return
+		}
+		parent, cmd = cmd, sub
+	}
+
+	args, reason := parseCommandArgs(cmd.args, params)
+	if reason != "" {
+		ch.sendUsageError(s, m, cmd, parent, reason)
+		return
+	}
+	args.selector = selector
+
+	// 느린 solved.ac 응답이 핸들러를 오래 붙잡지 않도록 명령마다 처리 기한을 둡니다
+	ctx, cancel := context.WithTimeout(ch.baseContext(), constants.CommandTimeout)
+	defer cancel()
+	s.ctx = ctx
+
+	cmd.run(ch, s, m, args)
+
+	if ctx.Err() == context.DeadlineExceeded {
+		utils.Warn("명령 %s 처리 시간이 %v를 넘었습니다", cmd.name, constants.CommandTimeout)
+	}
+}
+
+// baseContext 명령 context의 부모를 반환합니다. 봇이 종료되면 취소됩니다
+func (ch *CommandHandler) baseContext() context.Context {
+	if ch.ctx == nil {
+		return context.Background()
+	}
+	return ch.ctx
+}
+
+// hasPermission 사용자가 권한 수준을 만족하는지 확인합니다
+func (ch *CommandHandler) hasPermission(s *commandSession, m *discordgo.MessageCreate, level permissionLevel) bool {
+	switch level {
+	case permissionAdmin:
+		return ch.isAdmin(s, m)
+	case permissionAdminInGuild:
+		return m.GuildID == "" || ch.isAdmin(s, m)
+	default:
+		return true
+	}
+}
+
+// sendUsageError 인자 오류와 함께 사용법을 안내합니다
+func (ch *CommandHandler) sendUsageError(s *commandSession, m *discordgo.MessageCreate, cmd, parent *commandSpec, reason string) {
+	code := strings.ToUpper(cmd.name)
+	if parent != nil {
+		code = strings.ToUpper(parent.name) + "_" + code
+	}
+
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+	errorHandlers.Validation().HandleInvalidParams(code+"_INVALID_PARAMS",
+		fmt.Sprintf("Invalid %s parameters: %s", cmd.name, reason),
+		fmt.Sprintf("%s\n사용법: `%s`", reason, cmd.usage(parent)))
+}
+
+// parseCommandArgs 위치 매개변수를 선언된 인자로 검증합니다. 실패하면 사용자에게 보여줄 이유를 반환합니다
+func parseCommandArgs(specs []argSpec, params []string) (*commandArgs, string) {
+	args := &commandArgs{values: make(map[string]string)}
+
+	for i, spec := range specs {
+		if i >= len(params) {
+			if spec.optional {
+				break
+			}
+			return nil, fmt.Sprintf("%s을(를) 입력하세요.", spec.label)
+		}
+
+		value := params[i]
+		if spec.rest {
+			value = strings.Join(params[i:], " ")
+		}
+
+		value, reason := validateArg(spec, value)
+		if reason != "" {
+			return nil, reason
+		}
+		args.values[spec.name] = value
+	}
+
+	return args, ""
+}
+
+// validateArg 인자 형식을 검사하고 정규화된 값을 반환합니다
+func validateArg(spec argSpec, value string) (string, string) {
+	switch spec.kind {
+	case argBaekjoonID:
+		if !utils.IsValidBaekjoonID(value) {
+			return "", "유효하지 않은 백준 ID 형식입니다."
+		}
+	case argInteger:
+		n, err := strconv.Atoi(value)
+		if spec.max == 0 && (err != nil || n < spec.min) {
+			return "", fmt.Sprintf("%s은(는) %d 이상의 숫자로 입력하세요.", spec.label, spec.min)
+		}
+		if spec.max != 0 && (err != nil || n < spec.min || n > spec.max) {
+			return "", fmt.Sprintf("%s은(는) %d에서 %d 사이의 숫자로 입력하세요.", spec.label, spec.min, spec.max)
+		}
+	case argDate:
+		if !utils.IsValidDateString(value) {
+			return "", fmt.Sprintf("%s 날짜 형식이 올바르지 않습니다. (YYYY-MM-DD)", spec.label)
+		}
+	case argChoice:
+		value = strings.ToLower(value)
+		if !utils.Contains(spec.choices, value) {
+			return "", fmt.Sprintf("%s은(는) %s 중 하나여야 합니다.", spec.label, strings.Join(spec.choices, ", "))
+		}
+	}
+	return value, ""
+}
@@ -0,0 +1,121 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCommandArgs(t *testing.T) {
+	specs := []argSpec{
+		baekjoonIDArg,
+		daysArg,
+	}
+
+	tests := []struct {
+		name       string
+		params     []string
+		wantDays   int
+		wantReason bool
+	}{
+		{name: "optional argument omitted", params: []string{"koosaga"}, wantDays: 7},
+		{name: "optional argument given", params: []string{"koosaga", "30"}, wantDays: 30},
+		{name: "missing required argument", params: nil, wantReason: true},
+		{name: "invalid baekjoon id", params: []string{"bad id!"}, wantReason: true},
+		{name: "integer out of range", params: []string{"koosaga", "0"}, wantReason: true},
+		{name: "integer not a number", params: []string{"koosaga", "abc"}, wantReason: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, reason := parseCommandArgs(specs, tt.params)
+			if tt.wantReason {
+				if reason == "" {
+					t.Fatalf("expected validation failure for %q", tt.params)
+				}
+				return
+			}
+			if reason != "" {
+				t.Fatalf("unexpected validation failure: %s", reason)
+			}
+			if got := args.Int("days", 7); got != tt.wantDays {
+				t.Fatalf("days = %d, want %d", got, tt.wantDays)
+			}
+		})
+	}
+}
+
+func TestParseCommandArgsRestAndChoice(t *testing.T) {
+	cmd := newCommandRegistry(defaultCommands()).find("대회").findSubcommand("update")
+
+	args, reason := parseCommandArgs(cmd.args, []string{"NAME", "새", "대회명"})
+	if reason != "" {
+		t.Fatalf("unexpected validation failure: %s", reason)
+	}
+	if args.String("field") != "name" || args.String("value") != "새 대회명" {
+		t.Fatalf("args = %v", args.values)
+	}
+
+	if _, reason := parseCommandArgs(cmd.args, []string{"owner", "x"}); reason == "" {
+		t.Fatal("expected unknown choice to be rejected")
+	}
+}
+
+func TestRegistryFind(t *testing.T) {
+	registry := newCommandRegistry(defaultCommands())
+
+	for _, name := range []string{"기록", "history", "HISTORY"} {
+		if cmd := registry.find(name); cmd == nil || cmd.name != "history" {
+			t.Errorf("find(%q) did not resolve to history", name)
+		}
+	}
+	if registry.find("unknown") != nil {
+		t.Error("find(unknown) should return nil")
+	}
+	if sub := registry.find("채널").findSubcommand("설정"); sub == nil || sub.name != "set" {
+		t.Error("channel subcommand alias 설정 did not resolve")
+	}
+}
+
+func TestHelpTextCoversEveryCommand(t *testing.T) {
+	registry := newCommandRegistry(defaultCommands())
+	help := registry.helpText()
+
+	for _, cmd := range registry.commands {
+		if cmd.run == nil && len(cmd.subcommands) == 0 {
+			t.Errorf("%s: command has nothing to run", cmd.name)
+		}
+		if !strings.Contains(help, "!"+cmd.displayName()) {
+			t.Errorf("help text does not mention %s", cmd.displayName())
+		}
+		if detail := commandHelpText(cmd); !strings.Contains(detail, cmd.usage(nil)) {
+			t.Errorf("%s: detailed help does not include usage", cmd.name)
+		}
+	}
+}
+
+func TestUsage(t *testing.T) {
+	registry := newCommandRegistry(defaultCommands())
+
+	if got, want := registry.find("history").usage(nil), "!기록 <백준ID> [일수] [#대회ID]"; got != want {
+		t.Errorf("usage = %q, want %q", got, want)
+	}
+	competition := registry.find("competition")
+	if got, want := competition.findSubcommand("blackout").usage(competition), "!대회 blackout <on|off> [#대회ID]"; got != want {
+		t.Errorf("usage = %q, want %q", got, want)
+	}
+}
+
+func TestIntegerArgWithoutUpperBound(t *testing.T) {
+	specs := []argSpec{changeRequestIDArg}
+
+	args, reason := parseCommandArgs(specs, []string{"12345"})
+	if reason != "" {
+		t.Fatalf("unexpected validation failure: %s", reason)
+	}
+	if got := args.Int("request_id", 0); got != 12345 {
+		t.Fatalf("request_id = %d, want 12345", got)
+	}
+	if _, reason := parseCommandArgs(specs, []string{"0"}); reason == "" {
+		t.Fatal("expected values below the minimum to be rejected")
+	}
+}
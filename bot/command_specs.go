@@ -0,0 +1,396 @@
+package bot
+
+import (
+	"discord-bot/constants"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// 자주 쓰는 인자 선언
+var (
+	baekjoonIDArg = argSpec{name: "baekjoon_id", label: "백준ID", description: "백준 ID", kind: argBaekjoonID}
+	daysArg       = argSpec{
+		name: "days", label: "일수", kind: argInteger, optional: true,
+		description: fmt.Sprintf("조회 기간 (기본 %d일)", constants.DefaultHistoryDays),
+		min:         1, max: constants.MaxHistoryDays,
+	}
+	changeRequestIDArg = argSpec{name: "request_id", label: "요청ID", description: "변경 요청 ID", kind: argInteger, min: 1}
+	applicationIDArg   = argSpec{name: "request_id", label: "신청ID", description: "등록 신청 ID", kind: argInteger, min: 1}
+)
+
+// withAutocomplete 슬래시 명령에서 참가자 백준 ID를 자동완성하도록 인자를 복사합니다
+func withAutocomplete(arg argSpec) argSpec {
+	arg.autocomplete = true
+	return arg
+}
+
+// defaultCommands 봇이 제공하는 모든 명령을 선언합니다.
+// 도움말과 슬래시 명령 정의가 이 목록에서 만들어지므로 명령을 추가할 때는 여기에만 선언하면 됩니다.
+func defaultCommands() []*commandSpec {
+	return []*commandSpec{
+		{
+			name: "register", koName: "등록",
+			summary:     "대회 등록 신청",
+			details:     "`!연동`으로 소유를 인증한 백준 ID로만 등록할 수 있습니다. 등록 기간이 정해진 대회는 기간 안에만, 승인 방식 대회는 관리자가 `!승인`한 뒤에 등록됩니다.",
+			args:        []argSpec{{name: "name", label: "이름", description: "대회에서 표시할 이름", kind: argText}, baekjoonIDArg},
+			competition: true,
+			run:         (*CommandHandler).handleRegister,
+		},
+		{
+			name: "link", koName: "연동",
+			summary:           "백준 계정 소유 인증 및 연동",
+			details:           "solved.ac 자기소개에 일회용 코드를 넣어 백준 ID가 본인 것임을 인증합니다. 인증한 ID로만 `!등록`할 수 있습니다.",
+			defaultSubcommand: "status",
+			subcommands: []*commandSpec{
+				{
+					name: "start", koName: "시작",
+					summary: "인증 코드 발급",
+					args:    []argSpec{baekjoonIDArg},
+					run:     (*CommandHandler).handleLinkStart,
+				},
+				{name: "check", koName: "확인", summary: "자기소개의 인증 코드를 확인하고 연동", run: (*CommandHandler).handleLinkCheck},
+				{name: "status", koName: "상태", summary: "연동된 백준 계정 확인", run: (*CommandHandler).handleLinkStatus},
+				{name: "unlink", koName: "해제", summary: "계정 연동 해제", run: (*CommandHandler).handleUnlink},
+			},
+		},
+		{
+			name: "scoreboard", koName: "스코어보드",
+			summary:     "현재 스코어보드 확인",
+			details:     "블랙아웃 기간에는 관리자만 점수를 볼 수 있습니다.",
+			competition: true,
+			scope:       scopeGuildOnly,
+			run:         (*CommandHandler).handleScoreboard,
+		},
+		{
+			name: "participants", koName: "참가자",
+			summary:     "참가자 목록 확인",
+			competition: true,
+			run:         (*CommandHandler).handleParticipants,
+		},
+		{
+			name: "history", koName: "기록",
+			summary:     fmt.Sprintf("최근 풀이 기록 확인 (기본 %d일)", constants.DefaultHistoryDays),
+			args:        []argSpec{withAutocomplete(baekjoonIDArg), daysArg},
+			competition: true,
+			run:         (*CommandHandler).handleHistory,
+		},
+		{
+			name: "ranking", koName: "랭킹",
+			summary:     fmt.Sprintf("최근 기간 동안의 풀이 랭킹 확인 (기본 %d일)", constants.DefaultHistoryDays),
+			args:        []argSpec{daysArg},
+			competition: true,
+			run:         (*CommandHandler).handleRanking,
+		},
+		{
+			name: "score", koName: "점수",
+			summary:     "참가자의 문제별 점수 계산 내역 확인",
+			details:     "문제마다 기본 점수, 티어 차이에 따른 가중치, 반영된 점수를 보여줍니다. 등록 시점에 이미 해결한 문제는 제외됩니다.",
+			args:        []argSpec{withAutocomplete(baekjoonIDArg)},
+			competition: true,
+			run:         (*CommandHandler).handleScoreBreakdown,
+		},
+		{
+			name: "profile", koName: "프로필",
+			summary: "참가자 프로필 카드 확인",
+			details: "등록일, 시작/현재 티어와 레이팅, 대회 중 해결한 문제 수, 현재 순위와 점수를 보여줍니다. 대상을 생략하면 자신의 프로필을 보여줍니다.",
+			args: []argSpec{
+				{name: "target", label: "대상", description: "백준 ID 또는 디스코드 멘션 (생략하면 자신)", kind: argText, optional: true},
+			},
+			competition: true,
+			run:         (*CommandHandler).handleProfile,
+		},
+		{
+			name: "withdraw", koName: "탈퇴",
+			summary:     "대회에서 탈퇴",
+			details:     "이 디스코드 계정으로 등록한 참가 정보를 삭제합니다.",
+			competition: true,
+			run:         (*CommandHandler).handleWithdraw,
+		},
+		{
+			name: "rename", koName: "이름변경",
+			summary:     "대회 표시 이름 변경",
+			args:        []argSpec{{name: "name", label: "새이름", description: "대회에서 표시할 새 이름", kind: argText}},
+			competition: true,
+			run:         (*CommandHandler).handleRename,
+		},
+		{
+			name: "handle", koName: "아이디변경",
+			summary:     "백준 ID 변경 요청 (관리자 승인 필요)",
+			details:     "백준 아이디를 바꿨을 때 사용합니다. 새 ID를 `!연동 시작`으로 먼저 인증해야 하며, 승인되면 시작 티어와 등록 전 해결 문제는 그대로 유지됩니다.",
+			args:        []argSpec{{name: "baekjoon_id", label: "새백준ID", description: "새 백준 ID", kind: argBaekjoonID}},
+			competition: true,
+			run:         (*CommandHandler).handleHandleChangeRequest,
+		},
+		{
+			name: "competition", koName: "대회",
+			summary:    "대회 관리",
+			permission: permissionAdminInGuild,
+			category:   categoryAdmin,
+			subcommands: []*commandSpec{
+				{
+					name:    "create",
+					summary: "대회 생성 (YYYY-MM-DD 형식)",
+					details: "예시: `!대회 create 2024알고리즘대회 2024-01-01 2024-01-21`",
+					args: []argSpec{
+						{name: "name", label: "대회명", description: "대회 이름", kind: argText},
+						{name: "start", label: "시작일", description: "시작일 (YYYY-MM-DD)", kind: argDate},
+						{name: "end", label: "종료일", description: "종료일 (YYYY-MM-DD)", kind: argDate},
+					},
+					run: runWithGuild((*CompetitionHandler).handleCompetitionCreate),
+				},
+				{
+					name:    "list",
+					summary: "전체 대회 목록 확인",
+					run:     runWithGuild((*CompetitionHandler).handleCompetitionList),
+				},
+				{
+					name:        "status",
+					summary:     "대회 상태 확인",
+					competition: true,
+					run:         runWithGuild((*CompetitionHandler).handleCompetitionStatus),
+				},
+				{
+					name:        "blackout",
+					summary:     "스코어보드 공개/비공개 설정",
+					args:        []argSpec{{name: "state", label: "상태", description: "on이면 비공개, off면 공개", kind: argChoice, choices: []string{"on", "off"}}},
+					competition: true,
+					run:         runWithGuild((*CompetitionHandler).handleCompetitionBlackout),
+				},
+				{
+					name:    "update",
+					summary: "대회 정보 수정 (name, start, end)",
+					details: "예시: `!대회 update name 새 대회명 #2`",
+					args: []argSpec{
+						{name: "field", label: "필드", description: "수정할 항목", kind: argChoice, choices: []string{"name", "start", "end"}},
+						{name: "value", label: "값", description: "새 값 (날짜는 YYYY-MM-DD)", kind: argText, rest: true},
+					},
+					competition: true,
+					run:         runWithGuild((*CompetitionHandler).handleCompetitionUpdate),
+				},
+			},
+		},
+		{
+			name: "remove", koName: "삭제",
+			summary:     "참가자 삭제",
+			args:        []argSpec{withAutocomplete(baekjoonIDArg)},
+			competition: true,
+			permission:  permissionAdmin,
+			scope:       scopeGuildOnly,
+			category:    categoryAdmin,
+			run:         (*CommandHandler).handleRemoveParticipant,
+		},
+		{
+			name: "applications", koName: "신청목록",
+			summary:     "처리되지 않은 등록 신청 확인",
+			competition: true,
+			permission:  permissionAdmin,
+			scope:       scopeGuildOnly,
+			category:    categoryAdmin,
+			run:         (*CommandHandler).handleApplicationList,
+		},
+		{
+			name: "approve", koName: "승인",
+			summary:    "등록 신청 승인",
+			args:       []argSpec{applicationIDArg},
+			permission: permissionAdmin,
+			scope:      scopeGuildOnly,
+			category:   categoryAdmin,
+			run:        (*CommandHandler).handleApplicationApprove,
+		},
+		{
+			name: "reject", koName: "거절",
+			summary:    "등록 신청 거절",
+			args:       []argSpec{applicationIDArg},
+			permission: permissionAdmin,
+			scope:      scopeGuildOnly,
+			category:   categoryAdmin,
+			run:        (*CommandHandler).handleApplicationReject,
+		},
+		{
+			name: "requests", koName: "변경요청",
+			summary:           "참가자의 백준 ID 변경 요청 처리",
+			permission:        permissionAdmin,
+			scope:             scopeGuildOnly,
+			category:          categoryAdmin,
+			defaultSubcommand: "list",
+			subcommands: []*commandSpec{
+				{name: "list", koName: "목록", summary: "처리되지 않은 요청 확인", competition: true, run: (*CommandHandler).handleChangeRequestList},
+				{
+					name: "approve", koName: "승인",
+					summary: "요청 승인",
+					args:    []argSpec{changeRequestIDArg},
+					run:     (*CommandHandler).handleChangeRequestApprove,
+				},
+				{
+					name: "reject", koName: "거절",
+					summary: "요청 거절",
+					args:    []argSpec{changeRequestIDArg},
+					run:     (*CommandHandler).handleChangeRequestReject,
+				},
+			},
+		},
+		{
+			name: "audit", koName: "변경기록",
+			summary:     "참가자 탈퇴, 이름/ID 변경 기록 확인",
+			competition: true,
+			permission:  permissionAdmin,
+			scope:       scopeGuildOnly,
+			category:    categoryAdmin,
+			run:         (*CommandHandler).handleAuditLog,
+		},
+		{
+			name: "scoring", koName: "점수규칙",
+			summary:           "대회 점수 계산 규칙 확인 및 변경",
+			permission:        permissionAdmin,
+			scope:             scopeGuildOnly,
+			category:          categoryAdmin,
+			defaultSubcommand: "show",
+			subcommands: []*commandSpec{
+				{name: "show", koName: "확인", summary: "점수표, 배율, 상한 확인", competition: true, run: (*CommandHandler).handleScoringShow},
+				{
+					name: "set", koName: "설정",
+					summary: "점수 규칙 한 항목 변경",
+					details: "bands: 티어 차이(문제 티어 - 시작 티어)별 가중치 구간 `최소..최대=가중치` 목록, 끝을 비우면 제한 없음 " +
+						"(예: `!점수규칙 설정 bands ..-3=0.5 -2..2=1 3..=1.4`)\n" +
+						"problem_cap/total_cap: 문제당/총점 상한 (0이면 제한 없음)\n" +
+						"points: `<티어> <점수>`로 티어별 기본 점수 변경 (예: `!점수규칙 설정 points 11 20`)",
+					args: []argSpec{
+						{name: "field", label: "항목", description: "변경할 항목", kind: argChoice, choices: scoringFields},
+						{name: "value", label: "값", description: "새 값 (bands는 `최소..최대=가중치` 목록, points는 `<티어> <점수>`)", kind: argText, rest: true},
+					},
+					competition: true,
+					run:         (*CommandHandler).handleScoringSet,
+				},
+				{name: "reset", koName: "초기화", summary: "기본 점수 규칙으로 되돌리기", competition: true, run: (*CommandHandler).handleScoringReset},
+			},
+		},
+		{
+			name: "registration", koName: "등록설정",
+			summary:           "대회 등록 기간과 승인 방식 확인 및 변경",
+			permission:        permissionAdmin,
+			scope:             scopeGuildOnly,
+			category:          categoryAdmin,
+			defaultSubcommand: "show",
+			subcommands: []*commandSpec{
+				{name: "show", koName: "확인", summary: "등록 기간, 승인 방식, 시작 기록 시점 확인", competition: true, run: (*CommandHandler).handleRegistrationShow},
+				{
+					name: "set", koName: "설정",
+					summary: "등록 설정 한 항목 변경",
+					details: "open/close: 등록 시작일/마감일 (YYYY-MM-DD, 마감일 당일까지 등록 가능, `none`이면 제한 없음)\n" +
+						"approval: on이면 `!등록`이 신청으로 접수되고 관리자가 `!승인`/`!거절`로 처리\n" +
+						"snapshot: 시작 시점 기록(해결 문제, 티어, 레이팅)을 남길 때. registration이면 등록(승인) 시, start면 대회 시작 시 (이미 등록한 참가자도 시작 시각에 다시 기록)",
+					args: []argSpec{
+						{name: "field", label: "항목", description: "변경할 항목", kind: argChoice, choices: registrationFields},
+						{name: "value", label: "값", description: "새 값 (날짜는 YYYY-MM-DD, approval은 on/off, snapshot은 registration/start)", kind: argText},
+					},
+					competition: true,
+					run:         (*CommandHandler).handleRegistrationSet,
+				},
+			},
+		},
+		{
+			name: "snapshot", koName: "시작기록",
+			summary:           "참가자 시작 기록(시작 티어, 레이팅, 해결 문제) 확인 및 재설정",
+			details:           "시작 기록 시점은 `!등록설정 설정 snapshot`으로 정하며, 대회 시작 시점으로 정하면 시작 시각에 전원의 기록이 자동으로 남습니다.",
+			permission:        permissionAdmin,
+			scope:             scopeGuildOnly,
+			category:          categoryAdmin,
+			defaultSubcommand: "status",
+			subcommands: []*commandSpec{
+				{name: "status", koName: "확인", summary: "참가자별 시작 기록 확인", competition: true, run: (*CommandHandler).handleSnapshotStatus},
+				{
+					name: "retake", koName: "재기록",
+					summary: "시작 기록을 지금 상태로 다시 남기기",
+					details: "백준 ID를 생략하면 참가자 전원의 기록을 다시 남깁니다. 변경 내역은 `!변경기록`에 남습니다.",
+					args: []argSpec{
+						{name: "baekjoon_id", label: "백준ID", description: "다시 기록할 참가자 (생략하면 전원)", kind: argBaekjoonID, optional: true, autocomplete: true},
+					},
+					competition: true,
+					run:         (*CommandHandler).handleSnapshotRetake,
+				},
+			},
+		},
+		{
+			name: "reveal", koName: "발표설정",
+			summary:           "대회 종료 시 최종 결과 발표 방식 확인 및 변경",
+			details:           "순차 발표를 켜면 대회 종료 시 공지 채널에 아래 순위부터 차례로 공개하며, 발표가 끝날 때까지 스코어보드는 관리자만 볼 수 있습니다.",
+			permission:        permissionAdmin,
+			scope:             scopeGuildOnly,
+			category:          categoryAdmin,
+			defaultSubcommand: "show",
+			subcommands: []*commandSpec{
+				{name: "show", koName: "확인", summary: "발표 방식, 메시지 간격, 한 번에 공개할 인원 확인", competition: true, run: (*CommandHandler).handleRevealShow},
+				{
+					name: "set", koName: "설정",
+					summary: "발표 설정 한 항목 변경",
+					details: "enabled: on이면 아래 순위부터 차례로 발표, off면 한 번에 발표\n" +
+						"delay: 발표 메시지 사이 간격 (초)\n" +
+						"group: 입상자 밖의 순위를 한 메시지에 공개할 인원 (입상자는 한 명씩)",
+					args: []argSpec{
+						{name: "field", label: "항목", description: "변경할 항목", kind: argChoice, choices: revealFields},
+						{name: "value", label: "값", description: "새 값 (enabled는 on/off, delay는 초, group은 인원)", kind: argText},
+					},
+					competition: true,
+					run:         (*CommandHandler).handleRevealSet,
+				},
+			},
+		},
+		{
+			name: "channel", koName: "채널",
+			summary:           "자동 스코어보드/공지 채널 설정",
+			permission:        permissionAdmin,
+			scope:             scopeGuildOnly,
+			category:          categoryAdmin,
+			defaultSubcommand: "status",
+			subcommands: []*commandSpec{
+				{name: "set", koName: "설정", summary: "현재 채널을 공지 채널로 설정", run: (*CommandHandler).handleChannelSet},
+				{name: "clear", koName: "해제", summary: "공지 채널 설정 해제", run: (*CommandHandler).handleChannelClear},
+				{name: "status", koName: "확인", summary: "현재 공지 채널 확인", run: (*CommandHandler).handleChannelStatus},
+			},
+		},
+		{
+			name: "backup", koName: "백업",
+			summary:           "데이터 백업 확인 및 복원",
+			permission:        permissionAdmin,
+			scope:             scopeGuildOnly,
+			category:          categoryAdmin,
+			defaultSubcommand: "list",
+			subcommands: []*commandSpec{
+				{name: "list", koName: "목록", summary: "최근 백업 목록 확인", run: (*CommandHandler).handleBackupList},
+				{name: "create", koName: "생성", summary: "지금 상태를 백업", run: (*CommandHandler).handleBackupCreate},
+				{
+					name: "restore", koName: "복원",
+					summary: "백업 시점으로 되돌리기",
+					details: "복원 직전 상태도 새 백업으로 보관되므로 다시 되돌릴 수 있습니다.",
+					args:    []argSpec{{name: "backup_id", label: "백업ID", description: "복원할 백업 ID", kind: argText}},
+					run:     (*CommandHandler).handleBackupRestore,
+				},
+			},
+		},
+		{
+			name:     "ping",
+			summary:  "봇 응답 확인",
+			category: categoryMisc,
+			run:      (*CommandHandler).handlePing,
+		},
+		{
+			name: "help", koName: "도움말",
+			summary:  "도움말 표시",
+			category: categoryMisc,
+			args:     []argSpec{{name: "command", label: "명령어", description: "자세히 볼 명령어", kind: argText, optional: true}},
+			run:      (*CommandHandler).handleHelp,
+		},
+	}
+}
+
+// runWithGuild 대회 관리 하위 명령이 대상 길드를 먼저 찾은 뒤 실행되도록 감쌉니다
+func runWithGuild(run func(*CompetitionHandler, *commandSession, *discordgo.MessageCreate, *guildScope, *commandArgs)) commandFunc {
+	return func(ch *CommandHandler, s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+		g, ok := ch.resolveGuild(s, m)
+		if !ok {
+			return
+		}
+		run(ch.competitionHandler, s, m, g, args)
+	}
+}
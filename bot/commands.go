@@ -1,6 +1,7 @@
 package bot
 
 import (
+	"context"
 	"discord-bot/constants"
 	"discord-bot/errors"
 	"discord-bot/interfaces"
@@ -9,24 +10,31 @@ import (
 	"discord-bot/utils"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 )
 
 type CommandHandler struct {
-	storage            interfaces.StorageRepository
+	ctx                context.Context // 봇이 종료되면 취소되어 진행 중인 명령을 멈춥니다
+	storages           interfaces.GuildStorageProvider
 	scoreboardManager  *ScoreboardManager
 	client             interfaces.APIClient
 	competitionHandler *CompetitionHandler
+	commands           *commandRegistry
+	verifications      *verificationStore
 }
 
-func NewCommandHandler(storage interfaces.StorageRepository, apiClient interfaces.APIClient, scoreboardManager *ScoreboardManager) *CommandHandler {
+func NewCommandHandler(ctx context.Context, storages interfaces.GuildStorageProvider, apiClient interfaces.APIClient, scoreboardManager *ScoreboardManager) *CommandHandler {
 	ch := &CommandHandler{
-		storage:           storage,
+		ctx:               ctx,
+		storages:          storages,
 		scoreboardManager: scoreboardManager,
 		client:            apiClient,
+		verifications:     newVerificationStore(),
 	}
 	ch.competitionHandler = NewCompetitionHandler(ch)
+	ch.commands = newCommandRegistry(defaultCommands())
 	return ch
 }
 
@@ -35,12 +43,12 @@ func (ch *CommandHandler) HandleMessage(s *discordgo.Session, m *discordgo.Messa
 		return
 	}
 
-	command, params, isDM := ch.parseMessage(m)
+	command, params := ch.parseMessage(m)
 	if command == "" {
 		return
 	}
 
-	ch.routeCommand(s, m, command, params, isDM)
+	ch.routeCommand(&commandSession{Messenger: newDiscordMessenger(s)}, m, command, params)
 }
 
 // shouldIgnoreMessage 메시지를 무시해야 하는지 확인합니다
@@ -59,128 +67,118 @@ func (ch *CommandHandler) shouldIgnoreMessage(s *discordgo.Session, m *discordgo
 }
 
 // parseMessage 메시지를 파싱하여 명령어와 매개변수를 추출합니다
-func (ch *CommandHandler) parseMessage(m *discordgo.MessageCreate) (command string, params []string, isDM bool) {
+func (ch *CommandHandler) parseMessage(m *discordgo.MessageCreate) (command string, params []string) {
 	content := strings.TrimSpace(m.Content)
 	if !strings.HasPrefix(content, constants.CommandPrefix) {
-		return "", nil, false
+		return "", nil
 	}
 
 	args := strings.Fields(content)
 	if len(args) == 0 {
-		return "", nil, false
+		return "", nil
 	}
 
 	command = args[0][constants.CommandPrefixLength:]
 	params = args[1:]
-	isDM = m.GuildID == ""
 
-	return command, params, isDM
+	return command, params
 }
 
-// routeCommand 명령어를 해당 핸들러로 라우팅합니다
-func (ch *CommandHandler) routeCommand(s *discordgo.Session, m *discordgo.MessageCreate, command string, params []string, isDM bool) {
-	switch command {
-	case "help", "도움말":
-		ch.handleHelp(s, m)
-	case "register", "등록":
-		ch.handleRegister(s, m, params)
-	case "scoreboard", "스코어보드":
-		ch.handleScoreboardCommand(s, m, isDM)
-	case "competition", "대회":
-		ch.competitionHandler.HandleCompetition(s, m, params)
-	case "participants", "참가자":
-		ch.handleParticipants(s, m)
-	case "remove", "삭제":
-		ch.handleRemoveParticipant(s, m, params)
-	case "ping":
-		ch.handlePing(s, m)
-	}
-}
-
-// handleScoreboardCommand 스코어보드 명령어를 처리합니다 (DM 체크 포함)
-func (ch *CommandHandler) handleScoreboardCommand(s *discordgo.Session, m *discordgo.MessageCreate, isDM bool) {
-	if isDM {
-		if _, err := s.ChannelMessageSend(m.ChannelID, "❌ 스코어보드는 서버에서만 확인할 수 있습니다."); err != nil {
-			utils.Error("DM 응답 전송 실패: %v", err)
-		}
+// routeCommand 명령어를 등록된 명령으로 라우팅합니다. 등록되지 않은 명령어는 무시합니다
+func (ch *CommandHandler) routeCommand(s *commandSession, m *discordgo.MessageCreate, command string, params []string) {
+	cmd := ch.commands.find(command)
+	if cmd == nil {
 		return
 	}
-	ch.handleScoreboard(s, m)
+
+	// `#<대회ID>` 선택자는 어느 위치에 있어도 분리하여 각 핸들러에 전달
+	selector, params := extractCompetitionSelector(params)
+	ch.dispatch(s, m, cmd, params, selector)
 }
 
 // handlePing ping 명령어를 처리합니다
-func (ch *CommandHandler) handlePing(s *discordgo.Session, m *discordgo.MessageCreate) {
+func (ch *CommandHandler) handlePing(s *commandSession, m *discordgo.MessageCreate, _ *commandArgs) {
 	if _, err := s.ChannelMessageSend(m.ChannelID, "Pong! 🏓"); err != nil {
 		utils.Error("Ping 응답 전송 실패: %v", err)
 	}
 }
 
-func (ch *CommandHandler) handleHelp(s *discordgo.Session, m *discordgo.MessageCreate) {
-	helpText := `🤖 **알고리즘 경진대회 봇 명령어**
-
-**참가자 명령어:**
-• ` + "`!등록 <이름> <백준ID>`" + ` - 대회 등록 신청
-• ` + "`!스코어보드`" + ` - 현재 스코어보드 확인
-• ` + "`!참가자`" + ` - 참가자 목록 확인
+func (ch *CommandHandler) handleRegister(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
 
-**관리자 명령어:**
-• ` + "`!대회 create <대회명> <시작일> <종료일>`" + ` - 대회 생성 (YYYY-MM-DD 형식)
-• ` + "`!대회 status`" + ` - 대회 상태 확인
-• ` + "`!대회 blackout <on/off>`" + ` - 스코어보드 공개/비공개 설정
-• ` + "`!대회 update <필드> <값>`" + ` - 대회 정보 수정 (name, start, end)
-• ` + "`!삭제 <백준ID>`" + ` - 참가자 삭제
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
 
-**기타:**
-• ` + "`!ping`" + ` - 봇 응답 확인
-• ` + "`!도움말`" + ` - 도움말 표시`
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
 
-	if _, err := s.ChannelMessageSend(m.ChannelID, helpText); err != nil {
-		utils.Error("도움말 메시지 전송 실패: %v", err)
+	if err := competition.CheckRegistrationOpen(time.Now()); err != nil {
+		errors.SendDiscordInfo(s, m.ChannelID, fmt.Sprintf("[%s] 대회에 지금은 등록할 수 없습니다: %v", competition.Name, err))
+		return
 	}
-}
 
-func (ch *CommandHandler) handleRegister(s *discordgo.Session, m *discordgo.MessageCreate, params []string) {
-	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+	name := args.String("name")
+	baekjoonID := args.String("baekjoon_id")
 
-	if len(params) < 2 {
-		errorHandlers.Validation().HandleInvalidParams("REGISTER_INVALID_PARAMS",
-			"Invalid register parameters",
-			"사용법: `!등록 <이름> <백준ID>`")
+	// 다른 사람의 백준 ID로 등록하지 못하도록 인증된 계정만 허용
+	link := g.storage.GetAccountLink(m.Author.ID)
+	if link == nil || !strings.EqualFold(link.BaekjoonID, baekjoonID) {
+		errorHandlers.Validation().HandleInvalidParams("ACCOUNT_NOT_VERIFIED",
+			fmt.Sprintf("User %s has not verified baekjoon ID %s", m.Author.ID, baekjoonID),
+			fmt.Sprintf("백준 ID %s의 소유 인증이 필요합니다. `!연동 시작 %s`로 먼저 계정을 인증해주세요.", baekjoonID, baekjoonID))
 		return
 	}
+	baekjoonID = link.BaekjoonID
 
-	name := params[0]
-	baekjoonID := params[1]
+	if competition.Registration.RequireApproval {
+		ch.requestRegistration(s, m, g, competition, name, baekjoonID)
+		return
+	}
 
-	userInfo, err := ch.client.GetUserInfo(baekjoonID)
+	userInfo, err := ch.client.GetUserInfo(s.Context(), baekjoonID)
 	if err != nil {
 		errorHandlers.API().HandleBaekjoonUserNotFound(baekjoonID, err)
 		return
 	}
 
-	err = ch.storage.AddParticipant(name, baekjoonID, userInfo.Tier, userInfo.Rating)
+	err = g.storage.AddParticipant(s.Context(), competition.ID, name, baekjoonID, m.Author.ID, userInfo.Tier, userInfo.Rating)
 	if err != nil {
 		errorHandlers.Data().HandleParticipantAlreadyExists(baekjoonID)
 		return
 	}
+	ch.scoreboardManager.InvalidateSnapshot(g.guildID, competition.ID)
 
 	tierName := getTierName(userInfo.Tier)
 	tm := models.NewTierManager()
 	colorCode := tm.GetTierANSIColor(userInfo.Tier)
 
-	response := fmt.Sprintf("```ansi\n%s%s(%s)%s님 성공적으로 등록되었습니다!\n```",
-		colorCode, name, tierName, tm.GetANSIReset())
+	response := fmt.Sprintf("```ansi\n%s%s(%s)%s님 [%s] 대회에 성공적으로 등록되었습니다!\n```",
+		colorCode, name, tierName, tm.GetANSIReset(), competition.Name)
 
 	if _, err := s.ChannelMessageSend(m.ChannelID, response); err != nil {
 		utils.Error("등록 응답 메시지 전송 실패: %v", err)
 	}
 }
 
-func (ch *CommandHandler) handleScoreboard(s *discordgo.Session, m *discordgo.MessageCreate) {
+func (ch *CommandHandler) handleScoreboard(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
 	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
 
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
 	isAdmin := ch.isAdmin(s, m)
-	embed, err := ch.scoreboardManager.GenerateScoreboard(isAdmin)
+	embed, err := ch.scoreboardManager.GenerateScoreboard(s.Context(), g.guildID, competition.ID, isAdmin)
 	if err != nil {
 		errorHandlers.System().HandleScoreboardGenerationFailed(err)
 		return
@@ -191,15 +189,25 @@ func (ch *CommandHandler) handleScoreboard(s *discordgo.Session, m *discordgo.Me
 	}
 }
 
-func (ch *CommandHandler) handleParticipants(s *discordgo.Session, m *discordgo.MessageCreate) {
-	participants := ch.storage.GetParticipants()
+func (ch *CommandHandler) handleParticipants(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	participants := g.storage.GetParticipants(competition.ID)
 	if len(participants) == 0 {
-		errors.SendDiscordInfo(s, m.ChannelID, "참가자가 없습니다.")
+		errors.SendDiscordInfo(s, m.ChannelID, fmt.Sprintf("[%s] 대회에 참가자가 없습니다.", competition.Name))
 		return
 	}
 
 	var sb strings.Builder
-	sb.WriteString("```ansi\n")
+	sb.WriteString(fmt.Sprintf("👥 **%s** 참가자 목록\n```ansi\n", competition.Name))
 
 	tm := models.NewTierManager()
 	for i, p := range participants {
@@ -215,39 +223,40 @@ func (ch *CommandHandler) handleParticipants(s *discordgo.Session, m *discordgo.
 	}
 }
 
-func (ch *CommandHandler) handleRemoveParticipant(s *discordgo.Session, m *discordgo.MessageCreate, params []string) {
+func (ch *CommandHandler) handleRemoveParticipant(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
 	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+	baekjoonID := args.String("baekjoon_id")
 
-	// 관리자 권한 확인
-	if !ch.isAdmin(s, m) {
-		errorHandlers.Validation().HandleInsufficientPermissions()
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
 		return
 	}
 
-	// 파라미터 확인
-	if len(params) < 1 {
-		errorHandlers.Validation().HandleInvalidParams("REMOVE_INVALID_PARAMS",
-			"Invalid remove parameters",
-			"사용법: `!삭제 <백준ID>`")
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
 		return
 	}
 
-	baekjoonID := params[0]
-
-	// 백준 ID 유효성 검사
-	if !utils.IsValidBaekjoonID(baekjoonID) {
-		errorHandlers.Validation().HandleInvalidParams("REMOVE_INVALID_BAEKJOON_ID",
-			"Invalid Baekjoon ID format",
-			"유효하지 않은 백준 ID 형식입니다.")
+	participant := findParticipant(g.storage, competition.ID, baekjoonID)
+	if participant == nil {
+		errorHandlers.Data().HandleParticipantNotFound(baekjoonID)
 		return
 	}
 
 	// 참가자 삭제
-	err := ch.storage.RemoveParticipant(baekjoonID)
+	err := g.storage.RemoveParticipant(competition.ID, participant.BaekjoonID)
 	if err != nil {
 		errorHandlers.Data().HandleParticipantNotFound(baekjoonID)
 		return
 	}
+	ch.scoreboardManager.InvalidateSnapshot(g.guildID, competition.ID)
+	ch.recordAudit(g, models.AuditEntry{
+		CompetitionID: competition.ID,
+		ParticipantID: participant.ID,
+		Action:        models.AuditRemove,
+		ActorID:       m.Author.ID,
+		OldValue:      fmt.Sprintf("%s (%s)", participant.Name, participant.BaekjoonID),
+	})
 
 	response := fmt.Sprintf("✅ **참가자 삭제 완료**\n🎯 백준ID: %s", baekjoonID)
 	if _, err := s.ChannelMessageSend(m.ChannelID, response); err != nil {
@@ -256,14 +265,14 @@ func (ch *CommandHandler) handleRemoveParticipant(s *discordgo.Session, m *disco
 }
 
 // isAdmin는 사용자가 서버 관리자 권한을 가지고 있는지 확인합니다
-func (ch *CommandHandler) isAdmin(s *discordgo.Session, m *discordgo.MessageCreate) bool {
+func (ch *CommandHandler) isAdmin(s *commandSession, m *discordgo.MessageCreate) bool {
 	// DM에서는 관리자 권한 없음
 	if m.GuildID == "" {
 		return false
 	}
 
 	// 길드 정보 가져오기
-	guild, err := s.State.Guild(m.GuildID)
+	guild, err := s.Guild(m.GuildID)
 	if err != nil || guild == nil {
 		utils.Warn("길드 정보를 가져올 수 없습니다: %v", err)
 		return false
@@ -283,7 +292,7 @@ func (ch *CommandHandler) isAdmin(s *discordgo.Session, m *discordgo.MessageCrea
 
 	// 멤버의 역할들을 확인
 	for _, roleID := range member.Roles {
-		role, err := s.State.Role(m.GuildID, roleID)
+		role, err := s.GuildRole(m.GuildID, roleID)
 		if err != nil {
 			continue
 		}
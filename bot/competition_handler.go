@@ -24,55 +24,12 @@ func NewCompetitionHandler(ch *CommandHandler) *CompetitionHandler {
 	}
 }
 
-// HandleCompetition은 대회 관련 명령어를 처리합니다
-func (ch *CompetitionHandler) HandleCompetition(s *discordgo.Session, m *discordgo.MessageCreate, params []string) {
+func (ch *CompetitionHandler) handleCompetitionCreate(s *commandSession, m *discordgo.MessageCreate, g *guildScope, args *commandArgs) {
 	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
 	
-	// DM이 아닌 경우에만 관리자 권한 확인
-	if m.GuildID != "" && !ch.commandHandler.isAdmin(s, m) {
-		errorHandlers.Validation().HandleInsufficientPermissions()
-		return
-	}
-
-	if len(params) == 0 {
-		errorHandlers.Validation().HandleInvalidParams("COMPETITION_INVALID_PARAMS",
-			"Invalid competition parameters",
-			"사용법: `!대회 <create|status|blackout|update>`")
-		return
-	}
-
-	subCommand := params[0]
-	switch subCommand {
-	case "create":
-		ch.handleCompetitionCreate(s, m, params[1:])
-	case "status":
-		ch.handleCompetitionStatus(s, m)
-	case "blackout":
-		ch.handleCompetitionBlackout(s, m, params[1:])
-	case "update":
-		ch.handleCompetitionUpdate(s, m, params[1:])
-	default:
-		err := errors.NewValidationError("COMPETITION_UNKNOWN_COMMAND",
-			fmt.Sprintf("Unknown competition command: %s", subCommand),
-			"알 수 없는 명령어입니다.")
-		errors.HandleDiscordError(s, m.ChannelID, err)
-	}
-}
-
-func (ch *CompetitionHandler) handleCompetitionCreate(s *discordgo.Session, m *discordgo.MessageCreate, params []string) {
-	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
-	
-	if len(params) < 3 {
-		err := errors.NewValidationError("COMPETITION_CREATE_INVALID_PARAMS",
-			"Invalid competition create parameters",
-			"사용법: `!대회 create <대회명> <시작일> <종료일>`\n예시: `!대회 create 2024알고리즘대회 2024-01-01 2024-01-21`")
-		errors.HandleDiscordError(s, m.ChannelID, err)
-		return
-	}
-
-	name := params[0]
-	startDateStr := params[1]
-	endDateStr := params[2]
+	name := args.String("name")
+	startDateStr := args.String("start")
+	endDateStr := args.String("end")
 
 	startDate, endDate, err := utils.ValidateAndParseCompetitionDates(name, startDateStr, endDateStr)
 	if err != nil {
@@ -82,7 +39,7 @@ func (ch *CompetitionHandler) handleCompetitionCreate(s *discordgo.Session, m *d
 		return
 	}
 
-	err = ch.commandHandler.storage.CreateCompetition(name, startDate, endDate)
+	competition, err := g.storage.CreateCompetition(name, startDate, endDate)
 	if err != nil {
 		errorHandlers.System().HandleCompetitionCreateFailed(err)
 		return
@@ -90,10 +47,12 @@ func (ch *CompetitionHandler) handleCompetitionCreate(s *discordgo.Session, m *d
 
 	blackoutStart := endDate.AddDate(0, 0, -constants.BlackoutDays)
 	response := fmt.Sprintf("🏆 **대회가 생성되었습니다!**\n"+
+		"🆔 대회 ID: #%d\n"+
 		"📝 대회명: %s\n"+
 		"📅 기간: %s ~ %s\n"+
 		"🔒 블랙아웃: %s ~ %s\n"+
 		"✅ 상태: active",
+		competition.ID,
 		name,
 		utils.FormatDate(startDate),
 		utils.FormatDate(endDate),
@@ -103,71 +62,69 @@ func (ch *CompetitionHandler) handleCompetitionCreate(s *discordgo.Session, m *d
 	errors.SendDiscordSuccess(s, m.ChannelID, response)
 }
 
-func (ch *CompetitionHandler) handleCompetitionStatus(s *discordgo.Session, m *discordgo.MessageCreate) {
-	competition := ch.commandHandler.storage.GetCompetition()
-	if competition == nil {
-		err := errors.NewNotFoundError("NO_ACTIVE_COMPETITION",
-			"No active competition found",
-			"활성화된 대회가 없습니다.")
-		errors.HandleDiscordError(s, m.ChannelID, err)
+// handleCompetitionList 전체 대회 목록을 보여줍니다
+func (ch *CompetitionHandler) handleCompetitionList(s *commandSession, m *discordgo.MessageCreate, g *guildScope, _ *commandArgs) {
+	competitions := g.storage.GetCompetitions()
+	if len(competitions) == 0 {
+		errors.SendDiscordInfo(s, m.ChannelID, "생성된 대회가 없습니다.")
 		return
 	}
 
-	now := time.Now()
-	status := "진행 중"
-	if now.Before(competition.StartDate) {
-		status = "시작 전"
-	} else if now.After(competition.EndDate) {
-		status = "종료됨"
+	var sb strings.Builder
+	sb.WriteString("🏆 **대회 목록**\n")
+	for _, c := range competitions {
+		sb.WriteString(fmt.Sprintf("• `#%d` **%s** (%s) - %s, 참가자 %d명\n",
+			c.ID, c.Name,
+			utils.FormatDateRange(c.StartDate, c.EndDate),
+			competitionStatusText(c, time.Now()),
+			len(c.Participants)))
+	}
+
+	if _, err := s.ChannelMessageSend(m.ChannelID, sb.String()); err != nil {
+		utils.Error("대회 목록 메시지 전송 실패: %v", err)
+	}
+}
+
+func (ch *CompetitionHandler) handleCompetitionStatus(s *commandSession, m *discordgo.MessageCreate, g *guildScope, args *commandArgs) {
+	competition, ok := ch.commandHandler.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
 	}
 
+	status := competitionStatusText(competition, time.Now())
+
 	blackoutStatus := "공개"
-	if ch.commandHandler.storage.IsBlackoutPeriod() {
+	if g.storage.IsBlackoutPeriod(competition.ID) {
 		blackoutStatus = "비공개 (블랙아웃)"
 	}
 
-	response := fmt.Sprintf("🏆 **%s** 대회가 진행 중입니다!\n"+
+	response := fmt.Sprintf("🏆 **%s** (`#%d`) 대회가 진행 중입니다!\n"+
 		"📅 **기간:** %s\n"+
 		"📊 **상태:** %s\n"+
 		"🔒 **스코어보드:** %s\n"+
 		"👥 **참가자 수:** %d명",
 		competition.Name,
+		competition.ID,
 		utils.FormatDateRange(competition.StartDate, competition.EndDate),
 		status,
 		blackoutStatus,
-		len(ch.commandHandler.storage.GetParticipants()))
+		len(g.storage.GetParticipants(competition.ID)))
 
 	if _, err := s.ChannelMessageSend(m.ChannelID, response); err != nil {
 		utils.Error("대회 상태 메시지 전송 실패: %v", err)
 	}
 }
 
-func (ch *CompetitionHandler) handleCompetitionBlackout(s *discordgo.Session, m *discordgo.MessageCreate, params []string) {
-	if len(params) == 0 {
-		err := errors.NewValidationError("BLACKOUT_INVALID_PARAMS",
-			"Invalid blackout parameters",
-			"사용법: `!대회 blackout <on|off>`")
-		errors.HandleDiscordError(s, m.ChannelID, err)
-		return
-	}
-
-	setting := strings.ToLower(params[0])
-	var visible bool
+func (ch *CompetitionHandler) handleCompetitionBlackout(s *commandSession, m *discordgo.MessageCreate, g *guildScope, args *commandArgs) {
+	// 인자는 on/off 중 하나로 검증되어 있음
+	visible := args.String("state") == "off"
 
-	switch setting {
-	case "on":
-		visible = false
-	case "off":
-		visible = true
-	default:
-		err := errors.NewValidationError("BLACKOUT_INVALID_SETTING",
-			fmt.Sprintf("Invalid blackout setting: %s", setting),
-			"올바른 설정값을 입력하세요: `on` 또는 `off`")
-		errors.HandleDiscordError(s, m.ChannelID, err)
+	competition, ok := ch.commandHandler.resolveCompetition(s, m, g, args.selector)
+	if !ok {
 		return
 	}
 
-	err := ch.commandHandler.storage.SetScoreboardVisibility(visible)
+	err := g.storage.SetScoreboardVisibility(competition.ID, visible)
 	if err != nil {
 		botErr := errors.NewSystemError("BLACKOUT_SETTING_FAILED",
 			"Failed to set scoreboard visibility", err)
@@ -181,38 +138,35 @@ func (ch *CompetitionHandler) handleCompetitionBlackout(s *discordgo.Session, m
 		status = "비공개"
 	}
 
-	message := fmt.Sprintf("스코어보드가 **%s**로 설정되었습니다.", status)
+	message := fmt.Sprintf("[%s] 스코어보드가 **%s**로 설정되었습니다.", competition.Name, status)
 	errors.SendDiscordSuccess(s, m.ChannelID, message)
 }
 
-func (ch *CompetitionHandler) handleCompetitionUpdate(s *discordgo.Session, m *discordgo.MessageCreate, params []string) {
-	if len(params) < 2 {
-		err := errors.NewValidationError("COMPETITION_UPDATE_INVALID_PARAMS",
-			"Invalid competition update parameters",
-			"사용법: `!대회 update <필드> <값>`\n필드: name, start, end\n예시: `!대회 update name 대회명`")
-		errors.HandleDiscordError(s, m.ChannelID, err)
+func (ch *CompetitionHandler) handleCompetitionUpdate(s *commandSession, m *discordgo.MessageCreate, g *guildScope, args *commandArgs) {
+	field := args.String("field")
+	value := args.String("value")
+
+	competition, ok := ch.commandHandler.resolveCompetition(s, m, g, args.selector)
+	if !ok {
 		return
 	}
 
-	field := strings.ToLower(params[0])
-	value := strings.Join(params[1:], " ")
-
-	competition := ch.commandHandler.storage.GetCompetition()
-	if competition == nil {
-		err := errors.NewNotFoundError("NO_ACTIVE_COMPETITION",
-			"No active competition found",
-			"수정할 대회가 없습니다.")
+	// 확정된 최종 결과는 바뀌지 않으므로 기간을 바꾸면 결과와 어긋납니다
+	if competition.IsFinalized() && field != "name" {
+		err := errors.NewValidationError("COMPETITION_FINALIZED",
+			fmt.Sprintf("Competition %d is finalized", competition.ID),
+			"최종 결과가 확정된 대회의 기간은 바꿀 수 없습니다.")
 		errors.HandleDiscordError(s, m.ChannelID, err)
 		return
 	}
 
 	switch field {
 	case "name":
-		ch.handleUpdateName(s, m, value, competition.Name)
+		ch.handleUpdateName(s, m, g, value, competition)
 	case "start":
-		ch.handleUpdateStartDate(s, m, value, competition)
+		ch.handleUpdateStartDate(s, m, g, value, competition)
 	case "end":
-		ch.handleUpdateEndDate(s, m, value, competition)
+		ch.handleUpdateEndDate(s, m, g, value, competition)
 	default:
 		err := errors.NewValidationError("INVALID_UPDATE_FIELD",
 			fmt.Sprintf("Invalid field: %s", field),
@@ -221,7 +175,7 @@ func (ch *CompetitionHandler) handleCompetitionUpdate(s *discordgo.Session, m *d
 	}
 }
 
-func (ch *CompetitionHandler) handleUpdateName(s *discordgo.Session, m *discordgo.MessageCreate, newName, oldName string) {
+func (ch *CompetitionHandler) handleUpdateName(s *commandSession, m *discordgo.MessageCreate, g *guildScope, newName string, competition *models.Competition) {
 	if newName == "" {
 		err := errors.NewValidationError("EMPTY_COMPETITION_NAME",
 			"Competition name cannot be empty",
@@ -230,7 +184,8 @@ func (ch *CompetitionHandler) handleUpdateName(s *discordgo.Session, m *discordg
 		return
 	}
 
-	err := ch.commandHandler.storage.UpdateCompetitionName(newName)
+	oldName := competition.Name
+	err := g.storage.UpdateCompetitionName(competition.ID, newName)
 	if err != nil {
 		botErr := errors.NewSystemError("COMPETITION_UPDATE_FAILED",
 			"Failed to update competition name", err)
@@ -243,7 +198,7 @@ func (ch *CompetitionHandler) handleUpdateName(s *discordgo.Session, m *discordg
 	errors.SendDiscordSuccess(s, m.ChannelID, message)
 }
 
-func (ch *CompetitionHandler) handleUpdateStartDate(s *discordgo.Session, m *discordgo.MessageCreate, dateStr string, competition *models.Competition) {
+func (ch *CompetitionHandler) handleUpdateStartDate(s *commandSession, m *discordgo.MessageCreate, g *guildScope, dateStr string, competition *models.Competition) {
 	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
 	
 	startDate, err := utils.ParseDateWithValidation(dateStr, "start")
@@ -258,7 +213,7 @@ func (ch *CompetitionHandler) handleUpdateStartDate(s *discordgo.Session, m *dis
 	}
 
 	oldDate := competition.StartDate
-	err = ch.commandHandler.storage.UpdateCompetitionStartDate(startDate)
+	err = g.storage.UpdateCompetitionStartDate(competition.ID, startDate)
 	if err != nil {
 		botErr := errors.NewSystemError("COMPETITION_UPDATE_FAILED",
 			"Failed to update competition start date", err)
@@ -272,7 +227,7 @@ func (ch *CompetitionHandler) handleUpdateStartDate(s *discordgo.Session, m *dis
 	errors.SendDiscordSuccess(s, m.ChannelID, message)
 }
 
-func (ch *CompetitionHandler) handleUpdateEndDate(s *discordgo.Session, m *discordgo.MessageCreate, dateStr string, competition *models.Competition) {
+func (ch *CompetitionHandler) handleUpdateEndDate(s *commandSession, m *discordgo.MessageCreate, g *guildScope, dateStr string, competition *models.Competition) {
 	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
 	
 	endDate, err := utils.ParseDateWithValidation(dateStr, "end")
@@ -287,7 +242,7 @@ func (ch *CompetitionHandler) handleUpdateEndDate(s *discordgo.Session, m *disco
 	}
 
 	oldDate := competition.EndDate
-	err = ch.commandHandler.storage.UpdateCompetitionEndDate(endDate)
+	err = g.storage.UpdateCompetitionEndDate(competition.ID, endDate)
 	if err != nil {
 		botErr := errors.NewSystemError("COMPETITION_UPDATE_FAILED",
 			"Failed to update competition end date", err)
@@ -300,3 +255,19 @@ func (ch *CompetitionHandler) handleUpdateEndDate(s *discordgo.Session, m *disco
 		utils.FormatDate(oldDate), utils.FormatDate(endDate))
 	errors.SendDiscordSuccess(s, m.ChannelID, message)
 }
+
+// competitionStatusText 대회의 진행 상태를 사람이 읽을 수 있는 문자열로 반환합니다
+func competitionStatusText(competition *models.Competition, now time.Time) string {
+	switch {
+	case competition.IsFinalized():
+		return "종료됨 (최종 결과 확정)"
+	case !competition.IsActive:
+		return "비활성"
+	case now.Before(competition.StartDate):
+		return "시작 전"
+	case now.After(competition.EndDate):
+		return "종료됨"
+	default:
+		return "진행 중"
+	}
+}
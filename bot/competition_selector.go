@@ -0,0 +1,104 @@
+package bot
+
+import (
+	"discord-bot/errors"
+	"discord-bot/models"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// competitionSelectorPrefix 명령어에서 대회를 지정할 때 사용하는 접두사입니다 (예: `#2`)
+const competitionSelectorPrefix = "#"
+
+// extractCompetitionSelector 매개변수에서 `#<대회ID>` 형식의 대회 선택자를 분리합니다.
+// 선택자가 없으면 0을 반환합니다.
+func extractCompetitionSelector(params []string) (int, []string) {
+	selector := 0
+	rest := make([]string, 0, len(params))
+
+	for _, param := range params {
+		if strings.HasPrefix(param, competitionSelectorPrefix) {
+			if id, err := strconv.Atoi(strings.TrimPrefix(param, competitionSelectorPrefix)); err == nil && id > 0 {
+				selector = id
+				continue
+			}
+		}
+		rest = append(rest, param)
+	}
+
+	return selector, rest
+}
+
+// defaultCompetition 선택자가 없을 때 사용할 대회를 고릅니다.
+// 진행 중인 대회가 하나면 그 대회를, 없으면 가장 최근의 활성 대회를, 활성 대회도 없으면
+// 가장 최근에 최종 결과가 확정된 대회를 반환하고, 진행 중인 대회가 여러 개면 nil과 후보 목록을 반환합니다.
+func defaultCompetition(competitions []*models.Competition, now time.Time) (*models.Competition, []*models.Competition) {
+	var ongoing []*models.Competition
+	var latestActive, latestFinalized *models.Competition
+
+	for _, c := range competitions {
+		if c.IsOngoing(now) {
+			ongoing = append(ongoing, c)
+		}
+		if c.IsActive && (latestActive == nil || c.ID > latestActive.ID) {
+			latestActive = c
+		}
+		if c.IsFinalized() && (latestFinalized == nil || c.FinalizedAt.After(latestFinalized.FinalizedAt)) {
+			latestFinalized = c
+		}
+	}
+
+	switch len(ongoing) {
+	case 0:
+		if latestActive == nil {
+			return latestFinalized, nil
+		}
+		return latestActive, nil
+	case 1:
+		return ongoing[0], nil
+	default:
+		return nil, ongoing
+	}
+}
+
+// resolveCompetition 명령 대상 대회를 결정하고, 실패하면 채널에 오류를 전송합니다
+func (ch *CommandHandler) resolveCompetition(s *commandSession, m *discordgo.MessageCreate, g *guildScope, selector int) (*models.Competition, bool) {
+	if selector != 0 {
+		competition := g.storage.GetCompetition(selector)
+		if competition == nil {
+			err := errors.NewNotFoundError("COMPETITION_NOT_FOUND",
+				fmt.Sprintf("Competition %d not found", selector),
+				fmt.Sprintf("ID %d인 대회가 없습니다. `!대회 list`로 대회 목록을 확인하세요.", selector))
+			errors.HandleDiscordError(s, m.ChannelID, err)
+			return nil, false
+		}
+		return competition, true
+	}
+
+	competition, candidates := defaultCompetition(g.storage.GetCompetitions(), time.Now())
+	if competition != nil {
+		return competition, true
+	}
+
+	if len(candidates) > 0 {
+		var sb strings.Builder
+		sb.WriteString("여러 대회가 진행 중입니다. `#대회ID`로 대회를 지정하세요.")
+		for _, c := range candidates {
+			sb.WriteString(fmt.Sprintf("\n• `#%d` %s", c.ID, c.Name))
+		}
+		err := errors.NewValidationError("COMPETITION_AMBIGUOUS",
+			"Multiple ongoing competitions, selector required", sb.String())
+		errors.HandleDiscordError(s, m.ChannelID, err)
+		return nil, false
+	}
+
+	err := errors.NewNotFoundError("NO_ACTIVE_COMPETITION",
+		"No active competition found",
+		"활성화된 대회가 없습니다.")
+	errors.HandleDiscordError(s, m.ChannelID, err)
+	return nil, false
+}
@@ -0,0 +1,190 @@
+// Package discordtest 디스코드 연결 없이 명령 핸들러를 테스트할 수 있도록 interfaces.Messenger의 가짜 구현을 제공합니다.
+// 보낸 메시지를 순서대로 기록하고, 길드/멤버/역할은 테스트에서 미리 등록한 값으로 응답합니다.
+package discordtest
+
+import (
+	"discord-bot/interfaces"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Message 가짜 메신저로 보낸 메시지입니다. 일반 메시지면 Embed가 nil입니다
+type Message struct {
+	ChannelID string
+	Content   string
+	Embed     *discordgo.MessageEmbed
+}
+
+// Text 메시지 내용과 embed의 제목, 설명, 필드를 이어 붙인 문자열을 반환합니다
+func (m Message) Text() string {
+	if m.Embed == nil {
+		return m.Content
+	}
+	parts := []string{m.Embed.Title, m.Embed.Description}
+	for _, field := range m.Embed.Fields {
+		parts = append(parts, field.Name, field.Value)
+	}
+	if m.Embed.Footer != nil {
+		parts = append(parts, m.Embed.Footer.Text)
+	}
+	return strings.Join(parts, "\n")
+}
+
+var _ interfaces.Messenger = (*Messenger)(nil)
+
+// Messenger 보낸 메시지를 기록하는 interfaces.Messenger 구현입니다
+type Messenger struct {
+	mu       sync.Mutex
+	sent     []Message
+	sendErr  error
+	guildIDs []string
+	guilds   map[string]*discordgo.Guild
+	members  map[string]*discordgo.Member // 길드ID/사용자ID
+	roles    map[string]*discordgo.Role   // 길드ID/역할ID
+}
+
+// NewMessenger 길드가 없는 가짜 메신저를 만듭니다
+func NewMessenger() *Messenger {
+	return &Messenger{
+		guilds:  make(map[string]*discordgo.Guild),
+		members: make(map[string]*discordgo.Member),
+		roles:   make(map[string]*discordgo.Role),
+	}
+}
+
+// AddGuild 봇이 속한 길드를 등록합니다
+func (f *Messenger) AddGuild(guildID, ownerID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, exists := f.guilds[guildID]; !exists {
+		f.guildIDs = append(f.guildIDs, guildID)
+	}
+	f.guilds[guildID] = &discordgo.Guild{ID: guildID, OwnerID: ownerID}
+}
+
+// AddRole 길드에 역할을 등록합니다
+func (f *Messenger) AddRole(guildID, roleID string, permissions int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.roles[guildID+"/"+roleID] = &discordgo.Role{ID: roleID, Permissions: permissions}
+}
+
+// AddMember 길드에 멤버를 등록합니다
+func (f *Messenger) AddMember(guildID, userID string, roleIDs ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.members[guildID+"/"+userID] = &discordgo.Member{
+		GuildID: guildID,
+		User:    &discordgo.User{ID: userID},
+		Roles:   roleIDs,
+	}
+}
+
+// FailSends 이후 메시지 전송이 err를 반환하게 합니다. nil이면 다시 성공합니다
+func (f *Messenger) FailSends(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sendErr = err
+}
+
+// Messages 지금까지 보낸 메시지를 순서대로 반환합니다
+func (f *Messenger) Messages() []Message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Message(nil), f.sent...)
+}
+
+// Last 마지막으로 보낸 메시지를 반환합니다. 보낸 메시지가 없으면 false를 반환합니다
+func (f *Messenger) Last() (Message, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.sent) == 0 {
+		return Message{}, false
+	}
+	return f.sent[len(f.sent)-1], true
+}
+
+// Reset 기록된 메시지를 지웁니다
+func (f *Messenger) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = nil
+}
+
+func (f *Messenger) record(msg Message) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.sendErr != nil {
+		return nil, f.sendErr
+	}
+	f.sent = append(f.sent, msg)
+
+	sent := &discordgo.Message{
+		ID:        fmt.Sprintf("message-%d", len(f.sent)),
+		ChannelID: msg.ChannelID,
+		Content:   msg.Content,
+	}
+	if msg.Embed != nil {
+		sent.Embeds = []*discordgo.MessageEmbed{msg.Embed}
+	}
+	return sent, nil
+}
+
+// ChannelMessageSend 메시지를 기록합니다
+func (f *Messenger) ChannelMessageSend(channelID, content string, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return f.record(Message{ChannelID: channelID, Content: content})
+}
+
+// ChannelMessageSendEmbed embed 메시지를 기록합니다
+func (f *Messenger) ChannelMessageSendEmbed(channelID string, embed *discordgo.MessageEmbed, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return f.record(Message{ChannelID: channelID, Embed: embed})
+}
+
+// Guild 등록된 길드를 반환합니다
+func (f *Messenger) Guild(guildID string) (*discordgo.Guild, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	guild, ok := f.guilds[guildID]
+	if !ok {
+		return nil, discordgo.ErrStateNotFound
+	}
+	return guild, nil
+}
+
+// GuildMember 등록된 멤버를 반환합니다
+func (f *Messenger) GuildMember(guildID, userID string) (*discordgo.Member, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	member, ok := f.members[guildID+"/"+userID]
+	if !ok {
+		return nil, discordgo.ErrStateNotFound
+	}
+	return member, nil
+}
+
+// GuildRole 등록된 역할을 반환합니다
+func (f *Messenger) GuildRole(guildID, roleID string) (*discordgo.Role, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	role, ok := f.roles[guildID+"/"+roleID]
+	if !ok {
+		return nil, discordgo.ErrStateNotFound
+	}
+	return role, nil
+}
+
+// GuildIDs 등록된 길드 ID를 등록 순서대로 반환합니다
+func (f *Messenger) GuildIDs() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.guildIDs...)
+}
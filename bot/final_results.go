@@ -0,0 +1,227 @@
+package bot
+
+import (
+	"context"
+	"discord-bot/api"
+	"discord-bot/constants"
+	"discord-bot/interfaces"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// podiumMedals 최종 결과 발표에서 입상자 앞에 붙이는 메달입니다
+var podiumMedals = []string{"🥇", "🥈", "🥉"}
+
+// FinalizedCompetition 최종 결과가 확정된 대회와 그 순위입니다
+type FinalizedCompetition struct {
+	Competition *models.Competition
+	Standings   *models.FinalStandings
+}
+
+// FinalizeDueCompetitions 종료 시각이 지난 활성 대회의 최종 순위를 계산해 확정하고 확정된 대회들을 반환합니다.
+// 아직 끝나지 않은 활성 대회 중 가장 이른 종료 시각도 반환합니다 (없으면 zero).
+// 확정에 실패하거나 ctx가 취소된 대회는 활성 상태로 남아 다음 확인 때 다시 시도합니다.
+func (sm *ScoreboardManager) FinalizeDueCompetitions(ctx context.Context, guildID string, now time.Time) ([]FinalizedCompetition, time.Time) {
+	storage, err := sm.storages.ForGuild(guildID)
+	if err != nil {
+		utils.Warn("길드 %s 저장소를 열 수 없습니다: %v", guildID, err)
+		return nil, time.Time{}
+	}
+
+	var finalized []FinalizedCompetition
+	var next time.Time
+	for _, competition := range sm.ActiveCompetitions(guildID) {
+		if ctx.Err() != nil {
+			break
+		}
+		if !competition.IsDueForFinalization(now) {
+			if next.IsZero() || competition.EndDate.Before(next) {
+				next = competition.EndDate
+			}
+			continue
+		}
+
+		standings, err := sm.finalizeCompetition(ctx, guildID, storage, competition, now)
+		if err != nil {
+			utils.Warn("길드 %s 대회 %d 최종 결과 확정 실패: %v", guildID, competition.ID, err)
+			continue
+		}
+		competition.IsActive = false
+		competition.FinalizedAt = standings.FinalizedAt
+		finalized = append(finalized, FinalizedCompetition{Competition: competition, Standings: standings})
+	}
+	return finalized, next
+}
+
+// finalizeCompetition 대회 참가자 전원의 점수를 계산해 최종 순위로 저장합니다.
+// 점수는 종료 시각까지 풀이 기록에 남은 풀이로만 계산합니다. 지금 조회해 새로 확인한 풀이는 종료 시각에 확인한 것으로 기록되어
+// 마지막 점수 갱신 뒤에 푼 문제도 반영됩니다.
+func (sm *ScoreboardManager) finalizeCompetition(ctx context.Context, guildID string, storage interfaces.StorageRepository, competition *models.Competition, now time.Time) (*models.FinalStandings, error) {
+	defer sm.lockRefresh(guildID, competition.ID)()
+
+	participants := sm.captureDueSnapshots(ctx, storage, competition)
+	scores, err := sm.collectScoreData(ctx, guildID, storage, competition, participants)
+	if err != nil {
+		return nil, err
+	}
+	scores, err = fillMissingScores(participants, scores, sm.LatestSnapshot(guildID, competition.ID))
+	if err != nil {
+		return nil, err
+	}
+	sm.scoreUntilEnd(storage, competition, participants, scores)
+	sm.sortScores(scores)
+
+	standings := &models.FinalStandings{
+		CompetitionID: competition.ID,
+		FinalizedAt:   now,
+		Scores:        scores,
+	}
+	// 순차 발표가 시작되기 전에 스코어보드에 결과가 먼저 보이지 않도록 확정 전에 표시합니다
+	if competition.Reveal.Enabled {
+		sm.setRevealing(guildID, competition.ID, true)
+	}
+	if err := storage.FinalizeCompetition(*standings); err != nil {
+		sm.setRevealing(guildID, competition.ID, false)
+		return nil, err
+	}
+	sm.InvalidateSnapshot(guildID, competition.ID)
+
+	utils.Info("길드 %s 대회 %d의 최종 결과를 확정했습니다 (%d명)", guildID, competition.ID, len(scores))
+	return standings, nil
+}
+
+// fillMissingScores 지금 점수를 계산하지 못한 참가자는 최근 스냅샷의 점수로 채웁니다.
+// 스냅샷에도 없는 참가자가 있으면 최종 순위에서 빠지지 않도록 오류를 반환합니다.
+func fillMissingScores(participants []models.Participant, scores []models.ScoreData, snapshot *models.ScoreboardSnapshot) ([]models.ScoreData, error) {
+	scored := make(map[int]bool, len(scores))
+	for _, score := range scores {
+		scored[score.ParticipantID] = true
+	}
+
+	var missing []string
+	for _, p := range participants {
+		if scored[p.ID] {
+			continue
+		}
+		if score, ok := snapshotScore(snapshot, p.ID); ok {
+			utils.Warn("참가자 %s의 점수를 계산하지 못해 %s 기준 점수를 사용합니다", p.BaekjoonID, utils.FormatDateTime(snapshot.UpdatedAt))
+			scores = append(scores, score)
+			continue
+		}
+		missing = append(missing, p.BaekjoonID)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("참가자 %s의 점수를 계산하지 못했습니다", strings.Join(missing, ", "))
+	}
+	return scores, nil
+}
+
+// scoreUntilEnd 풀이 기록에서 종료 시각까지 처음 확인된 풀이만으로 최종 점수와 푼 문제 수를 다시 계산합니다.
+// 종료 시각 뒤에 확인한 풀이는 solveSeenAt이 종료 시각으로 기록하므로 확정할 때 조회한 풀이까지 포함됩니다.
+func (sm *ScoreboardManager) scoreUntilEnd(storage interfaces.StorageRepository, competition *models.Competition, participants []models.Participant, scores []models.ScoreData) {
+	solvedByHandle := make(map[string][]api.ProblemInfo)
+	for _, record := range storage.GetSolvesSince(competition.ID, time.Time{}) {
+		if record.FirstSeenAt.After(competition.EndDate) {
+			continue
+		}
+		solvedByHandle[record.BaekjoonID] = append(solvedByHandle[record.BaekjoonID],
+			api.ProblemInfo{ProblemID: record.ProblemID, Level: record.Level})
+	}
+
+	byID := make(map[int]models.Participant, len(participants))
+	for _, p := range participants {
+		byID[p.ID] = p
+	}
+
+	profile := competition.ScoringRules()
+	for i := range scores {
+		p, ok := byID[scores[i].ParticipantID]
+		if !ok {
+			continue
+		}
+		startProblems := startProblemSet(p)
+		problemCount := 0
+		for _, problem := range solvedByHandle[p.BaekjoonID] {
+			if !startProblems[problem.ProblemID] {
+				problemCount++
+			}
+		}
+		scores[i].Score = sm.calculator.CalculateScoreFromProblems(solvedByHandle[p.BaekjoonID], p.StartTier, p.StartProblemIDs, profile)
+		scores[i].ProblemCount = problemCount
+	}
+}
+
+func snapshotScore(snapshot *models.ScoreboardSnapshot, participantID int) (models.ScoreData, bool) {
+	if snapshot == nil {
+		return models.ScoreData{}, false
+	}
+	for _, score := range snapshot.Scores {
+		if score.ParticipantID == participantID {
+			return score, true
+		}
+	}
+	return models.ScoreData{}, false
+}
+
+// finalScoreboard 최종 결과가 확정된 대회의 스코어보드를 확정된 순위로 만듭니다.
+// 순차 발표 중에는 발표가 끝날 때까지 관리자에게만 보여줍니다.
+func (sm *ScoreboardManager) finalScoreboard(guildID string, storage interfaces.StorageRepository, competition *models.Competition, isAdmin bool) (*discordgo.MessageEmbed, error) {
+	if sm.IsRevealing(guildID, competition.ID) && !isAdmin {
+		return &discordgo.MessageEmbed{
+			Title:       fmt.Sprintf("🥁 %s 최종 결과 발표 중", competition.Name),
+			Description: "공지 채널에서 최종 순위를 차례로 공개하고 있습니다. 발표가 끝나면 스코어보드를 확인할 수 있습니다.",
+			Color:       constants.ColorTierGold,
+		}, nil
+	}
+
+	standings := storage.GetFinalStandings(competition.ID)
+	if standings == nil {
+		return nil, fmt.Errorf("대회 %d의 최종 결과를 찾을 수 없습니다", competition.ID)
+	}
+	return FinalResultsEmbed(competition, standings), nil
+}
+
+// FinalResultsEmbed 대회의 최종 결과를 입상자와 전체 순위로 보여주는 embed를 만듭니다
+func FinalResultsEmbed(competition *models.Competition, standings *models.FinalStandings) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("🏁 %s 최종 결과", competition.Name),
+		Description: fmt.Sprintf("%s ~ %s",
+			competition.StartDate.Format(constants.DateFormat),
+			competition.EndDate.Format(constants.DateFormat)),
+		Color: constants.ColorTierGold,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("%s 최종 결과 확정: %s", constants.EmojiLock, utils.FormatDateTime(standings.FinalizedAt)),
+		},
+	}
+
+	if len(standings.Scores) == 0 {
+		embed.Description += "\n\n참가자가 없습니다."
+		return embed
+	}
+
+	embed.Description += "\n\n" + formatPodium(standings.Podium(constants.FinalPodiumSize)) +
+		"\n\n" + formatScoreTable(standings.Scores)
+	return embed
+}
+
+// formatPodium 입상자를 메달과 함께 한 줄씩 나열합니다
+func formatPodium(podium []models.ScoreData) string {
+	lines := make([]string, len(podium))
+	for i, score := range podium {
+		lines[i] = fmt.Sprintf("%s **%s** (%s) - %.0f점", podiumMedal(i), score.Name, score.BaekjoonID, score.Score)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// podiumMedal rank번째(0부터) 입상자의 메달을 반환합니다
+func podiumMedal(rank int) string {
+	if rank < len(podiumMedals) {
+		return podiumMedals[rank]
+	}
+	return constants.EmojiMedal
+}
@@ -0,0 +1,70 @@
+package bot
+
+import (
+	"discord-bot/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFillMissingScores(t *testing.T) {
+	participants := []models.Participant{{ID: 1, BaekjoonID: "alice"}, {ID: 2, BaekjoonID: "bob"}}
+	scores := []models.ScoreData{{ParticipantID: 1, Score: 10}}
+
+	if _, err := fillMissingScores(participants, scores, nil); err == nil || !strings.Contains(err.Error(), "bob") {
+		t.Fatalf("expected an error naming the unscored participant, got %v", err)
+	}
+
+	snapshot := &models.ScoreboardSnapshot{Scores: []models.ScoreData{{ParticipantID: 2, Score: 7}}}
+	filled, err := fillMissingScores(participants, scores, snapshot)
+	if err != nil {
+		t.Fatalf("fillMissingScores: %v", err)
+	}
+	if len(filled) != 2 || filled[1].ParticipantID != 2 || filled[1].Score != 7 {
+		t.Fatalf("filled scores = %+v", filled)
+	}
+}
+
+func TestFinalResultsEmbed(t *testing.T) {
+	competition := &models.Competition{Name: "겨울 대회"}
+	standings := &models.FinalStandings{
+		FinalizedAt: time.Date(2024, 1, 21, 0, 0, 0, 0, time.Local),
+		Scores: []models.ScoreData{
+			{Name: "일등", BaekjoonID: "first", Score: 30},
+			{Name: "이등", BaekjoonID: "second", Score: 20},
+			{Name: "삼등", BaekjoonID: "third", Score: 10},
+			{Name: "사등", BaekjoonID: "fourth", Score: 5},
+		},
+	}
+
+	embed := FinalResultsEmbed(competition, standings)
+	if !strings.Contains(embed.Title, "최종 결과") {
+		t.Fatalf("title = %q", embed.Title)
+	}
+	if !strings.Contains(embed.Description, "🥇 **일등** (first) - 30점") || !strings.Contains(embed.Description, "🥉 **삼등**") {
+		t.Fatalf("description does not announce the podium: %q", embed.Description)
+	}
+	if strings.Contains(embed.Description, "(fourth)") || !strings.Contains(embed.Description, "사등") {
+		t.Fatalf("fourth place should only appear in the table: %q", embed.Description)
+	}
+
+	empty := FinalResultsEmbed(competition, &models.FinalStandings{})
+	if !strings.Contains(empty.Description, "참가자가 없습니다") {
+		t.Fatalf("empty results description = %q", empty.Description)
+	}
+}
+
+func TestDefaultCompetitionFallsBackToFinalized(t *testing.T) {
+	now := time.Now()
+	older := &models.Competition{ID: 1, FinalizedAt: now.AddDate(0, 0, -10)}
+	latest := &models.Competition{ID: 2, FinalizedAt: now.AddDate(0, 0, -1)}
+
+	if got, _ := defaultCompetition([]*models.Competition{older, latest}, now); got != latest {
+		t.Fatalf("defaultCompetition = %+v, want the latest finalized competition", got)
+	}
+
+	active := &models.Competition{ID: 3, IsActive: true, EndDate: now.AddDate(0, 0, 7)}
+	if got, _ := defaultCompetition([]*models.Competition{older, latest, active}, now); got != active {
+		t.Fatalf("defaultCompetition = %+v, want the active competition", got)
+	}
+}
@@ -0,0 +1,98 @@
+package bot
+
+import (
+	"discord-bot/errors"
+	"discord-bot/interfaces"
+	"discord-bot/utils"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// guildScope 명령이 실행되는 길드와 그 길드의 저장소입니다
+type guildScope struct {
+	guildID string
+	storage interfaces.StorageRepository
+}
+
+// resolveGuild 명령 대상 길드의 저장소를 찾고, 실패하면 채널에 오류를 전송합니다.
+// DM에서는 봇이 속한 길드가 하나뿐일 때만 그 길드를 대상으로 합니다.
+func (ch *CommandHandler) resolveGuild(s *commandSession, m *discordgo.MessageCreate) (*guildScope, bool) {
+	guildID := m.GuildID
+	if guildID == "" {
+		guildID = soleGuildID(s)
+	}
+
+	if guildID == "" {
+		err := errors.NewValidationError("GUILD_REQUIRED",
+			"Cannot determine guild for DM command",
+			"봇이 여러 서버에서 사용 중이므로 이 명령어는 서버 채널에서 사용해주세요.")
+		errors.HandleDiscordError(s, m.ChannelID, err)
+		return nil, false
+	}
+
+	storage, err := ch.storages.ForGuild(guildID)
+	if err != nil {
+		errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+		errorHandlers.System().HandleSystemError("GUILD_STORAGE_UNAVAILABLE",
+			"Failed to open guild storage", "서버 데이터를 불러오지 못했습니다.", err)
+		return nil, false
+	}
+
+	return &guildScope{guildID: guildID, storage: storage}, true
+}
+
+// soleGuildID 봇이 속한 길드가 하나뿐이면 그 ID를, 아니면 빈 문자열을 반환합니다
+func soleGuildID(messenger interfaces.Messenger) string {
+	guildIDs := messenger.GuildIDs()
+	if len(guildIDs) != 1 {
+		return ""
+	}
+	return guildIDs[0]
+}
+
+// handleChannelSet 현재 채널을 자동 스코어보드/공지 채널로 설정합니다
+func (ch *CommandHandler) handleChannelSet(s *commandSession, m *discordgo.MessageCreate, _ *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	if err := g.storage.SetAnnouncementChannel(m.ChannelID); err != nil {
+		errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+		errorHandlers.System().HandleSystemError("CHANNEL_SET_FAILED",
+			"Failed to set announcement channel", "공지 채널 설정에 실패했습니다.", err)
+		return
+	}
+	errors.SendDiscordSuccess(s, m.ChannelID, "이 채널이 자동 스코어보드/공지 채널로 설정되었습니다.")
+}
+
+// handleChannelClear 공지 채널 설정을 해제합니다
+func (ch *CommandHandler) handleChannelClear(s *commandSession, m *discordgo.MessageCreate, _ *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	if err := g.storage.SetAnnouncementChannel(""); err != nil {
+		errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+		errorHandlers.System().HandleSystemError("CHANNEL_CLEAR_FAILED",
+			"Failed to clear announcement channel", "공지 채널 해제에 실패했습니다.", err)
+		return
+	}
+	errors.SendDiscordSuccess(s, m.ChannelID, "공지 채널 설정이 해제되었습니다. 자동 스코어보드가 전송되지 않습니다.")
+}
+
+// handleChannelStatus 현재 공지 채널을 보여줍니다
+func (ch *CommandHandler) handleChannelStatus(s *commandSession, m *discordgo.MessageCreate, _ *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	channelID := g.storage.GetGuildSettings().AnnouncementChannelID
+	if channelID == "" {
+		errors.SendDiscordInfo(s, m.ChannelID, "공지 채널이 설정되지 않았습니다. `!채널 설정`으로 현재 채널을 지정하세요.")
+		return
+	}
+	errors.SendDiscordInfo(s, m.ChannelID, "현재 공지 채널: <#"+channelID+">")
+}
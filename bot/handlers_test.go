@@ -0,0 +1,369 @@
+package bot
+
+import (
+	"context"
+	"discord-bot/api"
+	"discord-bot/api/solvedactest"
+	"discord-bot/bot/discordtest"
+	"discord-bot/constants"
+	"discord-bot/interfaces"
+	"discord-bot/scoring"
+	"discord-bot/storage"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// 테스트 길드의 사용자
+const (
+	testGuildID   = "111111111111111111"
+	testChannelID = "channel"
+	ownerID       = "owner"  // 서버 소유자
+	moderatorID   = "mod"    // 관리자 역할이 있는 멤버
+	goldID        = "user-1" // fixture_gold 사용자
+	newbieID      = "user-2" // fixture_newbie 사용자
+	thirdID       = "user-3"
+)
+
+// handlerHarness 가짜 디스코드 메신저와 가짜 solved.ac 서버에 연결된 CommandHandler입니다
+type handlerHarness struct {
+	ch        *CommandHandler
+	messenger *discordtest.Messenger
+	server    *solvedactest.Server
+	storage   interfaces.StorageRepository
+}
+
+func newHandlerHarness(t *testing.T) *handlerHarness {
+	t.Helper()
+	server := solvedactest.NewServer()
+	t.Cleanup(server.Close)
+
+	client := api.NewSolvedACClient(api.ClientOptions{
+		BaseURL:           server.BaseURL(),
+		RequestsPerSecond: 1000,
+		Burst:             100,
+		RetryDelay:        time.Millisecond,
+	})
+	registry := storage.NewRegistry(client, t.TempDir(), constants.StorageBackendJSON, "")
+	repo, err := registry.ForGuild(testGuildID)
+	if err != nil {
+		t.Fatalf("ForGuild: %v", err)
+	}
+
+	messenger := discordtest.NewMessenger()
+	messenger.AddGuild(testGuildID, ownerID)
+	messenger.AddRole(testGuildID, "admin-role", discordgo.PermissionAdministrator)
+	messenger.AddRole(testGuildID, "member-role", discordgo.PermissionSendMessages)
+	messenger.AddMember(testGuildID, moderatorID, "member-role", "admin-role")
+	for _, userID := range []string{goldID, newbieID, thirdID} {
+		messenger.AddMember(testGuildID, userID, "member-role")
+	}
+
+	sm := NewScoreboardManager(registry, scoring.NewScoreCalculator(client), client)
+	return &handlerHarness{
+		ch:        NewCommandHandler(context.Background(), registry, client, sm),
+		messenger: messenger,
+		server:    server,
+		storage:   repo,
+	}
+}
+
+// send 길드 채널에서 보낸 메시지처럼 명령을 처리하고 그동안 보낸 응답을 반환합니다
+func (h *handlerHarness) send(authorID, guildID, content string) []discordtest.Message {
+	h.messenger.Reset()
+	m := &discordgo.MessageCreate{Message: &discordgo.Message{
+		ID:        "message",
+		ChannelID: testChannelID,
+		GuildID:   guildID,
+		Content:   content,
+		Author:    &discordgo.User{ID: authorID, Username: authorID},
+	}}
+
+	command, params := h.ch.parseMessage(m)
+	if command != "" {
+		h.ch.routeCommand(&commandSession{Messenger: h.messenger}, m, command, params)
+	}
+	return h.messenger.Messages()
+}
+
+// putCodeInBio 진행 중인 계정 인증 코드를 가짜 solved.ac 자기소개에 넣습니다
+func (h *handlerHarness) putCodeInBio(t *testing.T, userID string) {
+	t.Helper()
+	pending, ok := h.ch.verifications.lookup(verificationKey{testGuildID, userID})
+	if !ok {
+		t.Fatalf("no pending verification for %s", userID)
+	}
+	h.server.SetBio(pending.baekjoonID, "안녕하세요 "+pending.code)
+}
+
+// latestBackup 명령을 실행하기 직전에 가장 최근 백업 ID로 바뀝니다
+const latestBackup = "{latest_backup}"
+
+// commandStep 명령 하나와 기대하는 응답입니다
+type commandStep struct {
+	path    string // 실행하는 명령 경로 (명령 이름과 하위 명령 이름). 검증 경로만 확인하면 빈 문자열
+	author  string
+	guildID string // 비어 있으면 DM
+	content string
+	want    string // 응답에 들어 있어야 하는 문자열
+	wantErr bool   // 오류 응답을 기대하는지
+	before  func(t *testing.T, h *handlerHarness)
+}
+
+func guildStep(path, author, content, want string) commandStep {
+	return commandStep{path: path, author: author, guildID: testGuildID, content: content, want: want}
+}
+
+func errorStep(author, content, want string) commandStep {
+	return commandStep{author: author, guildID: testGuildID, content: content, want: want, wantErr: true}
+}
+
+func (st commandStep) withBefore(before func(t *testing.T, h *handlerHarness)) commandStep {
+	st.before = before
+	return st
+}
+
+func (st commandStep) inDM() commandStep {
+	st.guildID = ""
+	return st
+}
+
+// commandScript 대회 생성부터 탈퇴까지 모든 명령을 한 번 이상 실행하는 순서입니다. 앞 단계의 결과에 의존합니다
+func commandScript() []commandStep {
+	today := time.Now()
+	start := today.AddDate(0, 0, -1).Format(constants.DateFormat)
+	end := today.AddDate(0, 0, 10).Format(constants.DateFormat)
+	laterEnd := today.AddDate(0, 0, 12).Format(constants.DateFormat)
+	tomorrow := today.AddDate(0, 0, 1).Format(constants.DateFormat)
+	bio := func(userID string) func(t *testing.T, h *handlerHarness) {
+		return func(t *testing.T, h *handlerHarness) { h.putCodeInBio(t, userID) }
+	}
+
+	return []commandStep{
+		guildStep("ping", goldID, "!ping", "Pong!"),
+		guildStep("help", goldID, "!도움말", "!등록"),
+		guildStep("help", goldID, "!도움말 점수", "!점수"),
+
+		// 권한과 사용 위치 검증
+		errorStep(goldID, "!대회 create 봄대회 "+start+" "+end, "관리자만"),
+		errorStep(goldID, "!채널 설정", "관리자만"),
+		errorStep(moderatorID, "!삭제", "백준ID"),
+		errorStep(moderatorID, "!채널 없는명령", "알 수 없는 하위 명령어"),
+		commandStep{author: moderatorID, content: "!스코어보드", want: "서버 채널에서만", wantErr: true},
+		errorStep(goldID, "!참가자", "활성화된 대회가 없습니다"),
+
+		// 대회 관리 (서버 소유자와 관리자 역할 모두 관리자)
+		guildStep("competition create", ownerID, "!대회 create 봄대회 "+start+" "+end, "봄대회"),
+		guildStep("competition list", moderatorID, "!대회 list", "봄대회"),
+		guildStep("competition status", moderatorID, "!대회 status", "봄대회"),
+		guildStep("competition update", moderatorID, "!대회 update name 봄 알고리즘 대회", "봄 알고리즘 대회"),
+		guildStep("competition update", moderatorID, "!대회 update start "+start, start),
+		guildStep("competition update", moderatorID, "!대회 update end "+laterEnd, laterEnd),
+		errorStep(moderatorID, "!대회 update end 2020-01-01", "종료일"),
+		guildStep("competition blackout", moderatorID, "!대회 blackout on", "**비공개**"),
+		guildStep("competition blackout", moderatorID, "!대회 blackout off", "**공개**"),
+
+		// 계정 연동과 등록
+		errorStep(goldID, "!등록 골드 fixture_gold", "소유 인증"),
+		guildStep("link start", goldID, "!연동 시작 fixture_gold", "인증 코드"),
+		guildStep("link check", goldID, "!연동 확인", "찾지 못했습니다"),
+		guildStep("link check", goldID, "!연동 확인", "인증이 완료").withBefore(bio(goldID)),
+		guildStep("link status", goldID, "!연동", "fixture_gold"),
+		guildStep("register", goldID, "!등록 골드 fixture_gold", "성공적으로 등록"),
+		errorStep(goldID, "!등록 골드 fixture_gold", "이미 등록된"),
+
+		// 등록 승인 방식
+		guildStep("registration show", moderatorID, "!등록설정", "승인"),
+		guildStep("registration set", moderatorID, "!등록설정 설정 approval on", "등록 설정이 변경되었습니다"),
+		guildStep("link start", newbieID, "!연동 시작 fixture_newbie", "인증 코드"),
+		guildStep("link check", newbieID, "!연동 확인", "인증이 완료").withBefore(bio(newbieID)),
+		guildStep("register", newbieID, "!등록 뉴비 fixture_newbie", "신청"),
+		guildStep("link start", thirdID, "!연동 시작 third_user", "인증 코드").withBefore(func(t *testing.T, h *handlerHarness) {
+			h.server.AddUser("third_user", 5, 300)
+		}),
+		guildStep("link check", thirdID, "!연동 확인", "인증이 완료").withBefore(bio(thirdID)),
+		guildStep("register", thirdID, "!등록 셋째 third_user", "신청"),
+		guildStep("applications", moderatorID, "!신청목록", "fixture_newbie"),
+		guildStep("approve", moderatorID, "!승인 1", "승인했습니다"),
+		guildStep("reject", moderatorID, "!거절 2", "거절했습니다"),
+		guildStep("approve", moderatorID, "!승인 2", "이미 처리된"),
+		guildStep("registration set", moderatorID, "!등록설정 설정 approval off", "등록 설정이 변경되었습니다"),
+
+		// 점수와 기록 조회
+		guildStep("participants", goldID, "!참가자", "골드"),
+		guildStep("scoreboard", goldID, "!스코어보드", "골드").withBefore(func(t *testing.T, h *handlerHarness) {
+			h.server.Solve(solvedactest.GoldUser, solvedactest.Problem{ProblemID: 30000, TitleKo: "도전 문제", Level: 12})
+		}),
+		guildStep("score", goldID, "!점수 fixture_gold", "도전 문제"),
+		guildStep("history", goldID, "!기록 fixture_gold", "fixture_gold"),
+		guildStep("competition update", moderatorID, "!대회 update end "+tomorrow, tomorrow),
+		guildStep("history", goldID, "!기록 fixture_gold", "블랙아웃 기간"),
+		guildStep("history", moderatorID, "!기록 fixture_gold", "fixture_gold"),
+		guildStep("competition update", moderatorID, "!대회 update end "+laterEnd, laterEnd),
+		guildStep("ranking", goldID, "!랭킹 30", "골드"),
+		guildStep("profile", goldID, "!프로필", "fixture_gold"),
+		guildStep("profile", goldID, "!프로필 fixture_newbie", "fixture_newbie"),
+		guildStep("rename", goldID, "!이름변경 골드왕", "골드왕"),
+
+		// 백준 ID 변경 요청
+		guildStep("link start", goldID, "!연동 시작 gold_renamed", "인증 코드").withBefore(func(t *testing.T, h *handlerHarness) {
+			h.server.AddUser("gold_renamed", 11, 1487)
+		}),
+		guildStep("link check", goldID, "!연동 확인", "인증이 완료").withBefore(bio(goldID)),
+		guildStep("handle", goldID, "!아이디변경 gold_renamed", "변경을 요청했습니다"),
+		guildStep("link start", newbieID, "!연동 시작 newbie_renamed", "인증 코드").withBefore(func(t *testing.T, h *handlerHarness) {
+			h.server.AddUser("newbie_renamed", 3, 142)
+		}),
+		guildStep("link check", newbieID, "!연동 확인", "인증이 완료").withBefore(bio(newbieID)),
+		guildStep("handle", newbieID, "!아이디변경 newbie_renamed", "변경을 요청했습니다"),
+		guildStep("requests list", moderatorID, "!변경요청", "gold_renamed"),
+		guildStep("requests approve", moderatorID, "!변경요청 승인 1", "gold_renamed"),
+		guildStep("requests reject", moderatorID, "!변경요청 거절 2", "newbie_renamed"),
+		guildStep("audit", moderatorID, "!변경기록", "gold_renamed"),
+
+		// 점수 규칙, 시작 기록, 발표 설정
+		guildStep("scoring show", moderatorID, "!점수규칙", "가중치"),
+		guildStep("scoring set", moderatorID, "!점수규칙 설정 problem_cap 30", "문제당 상한: 30점"),
+		errorStep(moderatorID, "!점수규칙 설정 problem_cap 많이", "숫자로 입력"),
+		guildStep("scoring reset", moderatorID, "!점수규칙 초기화", "문제당 상한: 없음"),
+		guildStep("snapshot status", moderatorID, "!시작기록", "gold_renamed"),
+		guildStep("snapshot retake", moderatorID, "!시작기록 재기록 fixture_newbie", "1명"),
+		guildStep("snapshot retake", moderatorID, "!시작기록 재기록", "2명"),
+		guildStep("reveal show", moderatorID, "!발표설정", "메시지 간격: 10초"),
+		guildStep("reveal set", moderatorID, "!발표설정 설정 delay 5", "메시지 간격: 5초"),
+
+		// 공지 채널과 백업
+		guildStep("channel status", moderatorID, "!채널", "설정되지 않았습니다"),
+		guildStep("channel set", moderatorID, "!채널 설정", "공지 채널로 설정되었습니다"),
+		guildStep("channel status", moderatorID, "!채널 확인", "<#"+testChannelID+">"),
+		guildStep("channel clear", moderatorID, "!채널 해제", "해제되었습니다"),
+		guildStep("backup list", moderatorID, "!백업", "저장된 백업이 없습니다"),
+		guildStep("backup create", moderatorID, "!백업 생성", "백업이 생성되었습니다"),
+		guildStep("backup list", moderatorID, "!백업 목록", "백업 목록"),
+		errorStep(moderatorID, "!백업 복원 없는백업", "복원에 실패했습니다"),
+		guildStep("backup restore", moderatorID, "!백업 복원 "+latestBackup, "복원되었습니다"),
+
+		// 참가자 삭제, 탈퇴, 연동 해제
+		guildStep("remove", moderatorID, "!삭제 fixture_newbie", "참가자 삭제 완료"),
+		errorStep(moderatorID, "!삭제 fixture_newbie", "찾을 수 없습니다"),
+		guildStep("withdraw", goldID, "!탈퇴", "탈퇴했습니다"),
+		guildStep("link unlink", goldID, "!연동 해제", "연동이 해제되었습니다"),
+		guildStep("link status", goldID, "!연동 상태", "연동된 백준 계정이 없습니다"),
+
+		// 봇이 한 서버에만 있으면 DM 명령은 그 서버를 대상으로 합니다
+		guildStep("", ownerID, "!대회 list", "봄 알고리즘 대회").inDM(),
+	}
+}
+
+func TestCommandPaths(t *testing.T) {
+	h := newHandlerHarness(t)
+
+	for i, st := range commandScript() {
+		content := st.content
+		if strings.Contains(content, latestBackup) {
+			backups, err := h.storage.ListBackups()
+			if err != nil || len(backups) == 0 {
+				t.Fatalf("ListBackups: %v (%d backups)", err, len(backups))
+			}
+			content = strings.ReplaceAll(content, latestBackup, backups[0].ID)
+		}
+
+		name := content
+		if st.path != "" {
+			name = st.path + ": " + content
+		}
+		ok := t.Run(name, func(t *testing.T) {
+			if st.before != nil {
+				st.before(t, h)
+			}
+			sent := h.send(st.author, st.guildID, content)
+			if len(sent) == 0 {
+				t.Fatalf("step %d sent no reply", i)
+			}
+
+			texts := make([]string, len(sent))
+			for j, msg := range sent {
+				texts[j] = msg.Text()
+				if msg.ChannelID != testChannelID {
+					t.Errorf("reply sent to channel %q", msg.ChannelID)
+				}
+			}
+			reply := strings.Join(texts, "\n")
+
+			failed := strings.HasPrefix(texts[0], constants.EmojiError)
+			if failed != st.wantErr {
+				t.Fatalf("wantErr = %v, got reply:\n%s", st.wantErr, reply)
+			}
+			if !strings.Contains(reply, st.want) {
+				t.Fatalf("reply does not contain %q:\n%s", st.want, reply)
+			}
+		})
+		if !ok {
+			// 뒤 단계는 앞 단계의 결과에 의존하므로 멈춥니다
+			t.FailNow()
+		}
+	}
+}
+
+// TestCommandScriptCoversEveryCommand 명령을 추가하면 commandScript에도 추가하도록 모든 명령 경로가 실행되는지 확인합니다
+func TestCommandScriptCoversEveryCommand(t *testing.T) {
+	covered := make(map[string]bool)
+	for _, st := range commandScript() {
+		covered[st.path] = true
+	}
+
+	for _, cmd := range defaultCommands() {
+		if len(cmd.subcommands) == 0 {
+			if !covered[cmd.name] {
+				t.Errorf("command %q is not exercised by commandScript", cmd.name)
+			}
+			continue
+		}
+		for _, sub := range cmd.subcommands {
+			if path := cmd.name + " " + sub.name; !covered[path] {
+				t.Errorf("command %q is not exercised by commandScript", path)
+			}
+		}
+	}
+}
+
+func TestAdminRequiresAdministratorRole(t *testing.T) {
+	h := newHandlerHarness(t)
+	m := &discordgo.MessageCreate{Message: &discordgo.Message{GuildID: testGuildID, Author: &discordgo.User{}}}
+	s := &commandSession{Messenger: h.messenger}
+
+	for userID, want := range map[string]bool{ownerID: true, moderatorID: true, goldID: false, "stranger": false} {
+		m.Author.ID = userID
+		if got := h.ch.isAdmin(s, m); got != want {
+			t.Errorf("isAdmin(%s) = %v, want %v", userID, got, want)
+		}
+	}
+
+	m.GuildID = ""
+	m.Author.ID = ownerID
+	if h.ch.isAdmin(s, m) {
+		t.Error("expected no admin rights in DMs")
+	}
+}
+
+func TestDMRequiresGuildWhenBotIsInSeveralGuilds(t *testing.T) {
+	h := newHandlerHarness(t)
+	h.messenger.AddGuild("222222222222222222", ownerID)
+
+	sent := h.send(goldID, "", "!연동")
+	if len(sent) != 1 || !strings.Contains(sent[0].Text(), "서버 채널에서 사용해주세요") {
+		t.Fatalf("expected the guild to be required, got %+v", sent)
+	}
+}
+
+func TestUnknownCommandsAreIgnored(t *testing.T) {
+	h := newHandlerHarness(t)
+
+	for _, content := range []string{"!없는명령", "안녕하세요", "!"} {
+		if sent := h.send(goldID, testGuildID, content); len(sent) != 0 {
+			t.Errorf("%q: expected no reply, got %+v", content, sent)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package bot
+
+import (
+	"discord-bot/utils"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// helpSections 도움말에 표시할 분류 순서와 제목입니다
+var helpSections = []struct {
+	category helpCategory
+	title    string
+}{
+	{categoryParticipant, "참가자 명령어"},
+	{categoryAdmin, "관리자 명령어"},
+	{categoryMisc, "기타"},
+}
+
+// handleHelp 전체 도움말이나 특정 명령어의 자세한 도움말을 보여줍니다
+func (ch *CommandHandler) handleHelp(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	helpText := ch.commands.helpText()
+	if name := strings.TrimPrefix(args.String("command"), "!"); name != "" {
+		cmd := ch.commands.find(name)
+		if cmd == nil {
+			errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+			errorHandlers.Validation().HandleInvalidParams("HELP_UNKNOWN_COMMAND",
+				fmt.Sprintf("Unknown command for help: %s", name),
+				fmt.Sprintf("알 수 없는 명령어입니다: %s\n`!도움말`로 전체 명령어를 확인하세요.", name))
+			return
+		}
+		helpText = commandHelpText(cmd)
+	}
+
+	if _, err := s.ChannelMessageSend(m.ChannelID, helpText); err != nil {
+		utils.Error("도움말 메시지 전송 실패: %v", err)
+	}
+}
+
+// helpText 등록된 명령으로 전체 도움말을 만듭니다
+func (r *commandRegistry) helpText() string {
+	var sb strings.Builder
+	sb.WriteString("🤖 **알고리즘 경진대회 봇 명령어**\n")
+
+	for _, section := range helpSections {
+		sb.WriteString(fmt.Sprintf("\n**%s:**\n", section.title))
+		for _, cmd := range r.commands {
+			if cmd.category != section.category {
+				continue
+			}
+			// 기본 하위 명령이 없는 명령은 하위 명령마다 한 줄씩 보여줌
+			if len(cmd.subcommands) > 0 && cmd.defaultSubcommand == "" {
+				for _, sub := range cmd.subcommands {
+					sb.WriteString(fmt.Sprintf("• `%s` - %s\n", sub.usage(cmd), sub.summary))
+				}
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("• `%s` - %s\n", cmd.usage(nil), cmd.summary))
+		}
+	}
+
+	sb.WriteString("\n여러 대회가 동시에 진행 중이면 명령어 뒤에 `#대회ID`를 붙여 대회를 지정하세요. (예: `!스코어보드 #2`)\n")
+	sb.WriteString("`!도움말 <명령어>`로 명령어별 자세한 사용법을 볼 수 있습니다.")
+	return sb.String()
+}
+
+// commandHelpText 명령어 하나의 사용법, 별칭, 권한, 사용 위치를 보여주는 도움말을 만듭니다
+func commandHelpText(cmd *commandSpec) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📖 **%s** - %s\n", cmd.displayName(), cmd.summary))
+	sb.WriteString(fmt.Sprintf("사용법: `%s`\n", cmd.usage(nil)))
+	if cmd.details != "" {
+		sb.WriteString(cmd.details + "\n")
+	}
+
+	for _, sub := range cmd.subcommands {
+		sb.WriteString(fmt.Sprintf("• `%s` - %s", sub.usage(cmd), sub.summary))
+		if sub.name == cmd.defaultSubcommand {
+			sb.WriteString(" (기본)")
+		}
+		sb.WriteString("\n")
+		if sub.details != "" {
+			sb.WriteString("  " + sub.details + "\n")
+		}
+	}
+
+	names := []string{cmd.name}
+	if cmd.koName != "" {
+		names = []string{cmd.koName, cmd.name}
+	}
+	names = append(names, cmd.aliases...)
+	sb.WriteString(fmt.Sprintf("별칭: %s\n", strings.Join(names, ", ")))
+	sb.WriteString(fmt.Sprintf("권한: %s\n", cmd.permission.description()))
+	sb.WriteString(fmt.Sprintf("사용 위치: %s", cmd.scope.description()))
+	return sb.String()
+}
+
+// description 권한 수준의 설명을 반환합니다
+func (p permissionLevel) description() string {
+	switch p {
+	case permissionAdmin:
+		return "서버 관리자"
+	case permissionAdminInGuild:
+		return "서버 관리자 (DM에서는 누구나)"
+	default:
+		return "누구나"
+	}
+}
+
+// description 사용 위치의 설명을 반환합니다
+func (c channelScope) description() string {
+	if c == scopeGuildOnly {
+		return "서버 채널"
+	}
+	return "서버 채널, DM"
+}
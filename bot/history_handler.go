@@ -0,0 +1,213 @@
+package bot
+
+import (
+	"discord-bot/constants"
+	"discord-bot/errors"
+	"discord-bot/interfaces"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// periodRanking 기간 랭킹의 참가자별 집계 결과입니다
+type periodRanking struct {
+	Name         string
+	BaekjoonID   string
+	ProblemCount int
+	Points       int
+}
+
+// handleHistory 참가자가 최근 기간 동안 처음 해결한 문제 목록을 보여줍니다
+func (ch *CommandHandler) handleHistory(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+
+	baekjoonID := args.String("baekjoon_id")
+	days := args.Int("days", constants.DefaultHistoryDays)
+
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	// 새로 해결한 문제와 가중치로 점수를 추정할 수 있으므로 순위를 숨기는 동안에는 보여주지 않습니다
+	hidden := g.storage.IsBlackoutPeriod(competition.ID) || ch.scoreboardManager.IsRevealing(g.guildID, competition.ID)
+	if hidden && !ch.isAdmin(s, m) {
+		errors.SendDiscordInfo(s, m.ChannelID, "블랙아웃 기간이나 최종 결과 발표 중에는 풀이 기록을 확인할 수 없습니다.")
+		return
+	}
+
+	participant := findParticipant(g.storage, competition.ID, baekjoonID)
+	if participant == nil {
+		errorHandlers.Data().HandleParticipantNotFound(baekjoonID)
+		return
+	}
+
+	since := time.Now().AddDate(0, 0, -days)
+	history := periodSolves(*participant, g.storage.GetSolveHistory(competition.ID, participant.BaekjoonID, since))
+	if len(history) == 0 {
+		errors.SendDiscordInfo(s, m.ChannelID,
+			fmt.Sprintf("%s님이 최근 %d일 동안 새로 해결한 문제가 없습니다.", participant.Name, days))
+		return
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].FirstSeenAt.After(history[j].FirstSeenAt)
+	})
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📜 **%s (%s)** 최근 %d일 풀이 기록 (%d문제)\n```ansi\n",
+		participant.Name, participant.BaekjoonID, days, len(history)))
+
+	// 문제마다 시작 티어와의 차이에 따른 가중치를 함께 표시
+	profile := competition.ScoringRules()
+	tm := models.NewTierManager()
+	for i, record := range history {
+		if i >= constants.MaxHistoryEntries {
+			sb.WriteString(fmt.Sprintf("... 외 %d문제\n", len(history)-constants.MaxHistoryEntries))
+			break
+		}
+		weight, category := profile.WeightFor(record.Level, participant.StartTier)
+		sb.WriteString(fmt.Sprintf("%s%s  %6d  %-13s ×%-4g %s%s\n",
+			tm.GetTierANSIColor(record.Level),
+			record.FirstSeenAt.Format("01-02 15:04"),
+			record.ProblemID,
+			tm.GetTierName(record.Level),
+			weight, category,
+			tm.GetANSIReset()))
+	}
+	sb.WriteString("```")
+
+	if _, err := s.ChannelMessageSend(m.ChannelID, sb.String()); err != nil {
+		utils.Error("풀이 기록 메시지 전송 실패: %v", err)
+	}
+}
+
+// handleRanking 최근 기간 동안의 풀이 기록으로 참가자 랭킹을 보여줍니다
+func (ch *CommandHandler) handleRanking(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	if g.storage.IsBlackoutPeriod(competition.ID) && !ch.isAdmin(s, m) {
+		errors.SendDiscordInfo(s, m.ChannelID, "블랙아웃 기간에는 기간 랭킹을 확인할 수 없습니다.")
+		return
+	}
+
+	days := args.Int("days", constants.DefaultHistoryDays)
+
+	rankings := buildPeriodRankings(g.storage, competition, time.Now().AddDate(0, 0, -days))
+	if len(rankings) == 0 {
+		errors.SendDiscordInfo(s, m.ChannelID, fmt.Sprintf("최근 %d일 동안 새로 해결된 문제가 없습니다.", days))
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📈 **%s 최근 %d일 랭킹**\n```\n", competition.Name, days))
+	sb.WriteString(fmt.Sprintf("%-*s %-*s %5s %6s\n",
+		constants.ScoreboardRankWidth, "순위",
+		constants.ScoreboardNameWidth, "이름",
+		"문제", "점수"))
+	sb.WriteString(constants.ScoreboardSeparator + "\n")
+
+	for i, r := range rankings {
+		sb.WriteString(fmt.Sprintf("%-*d %-*s %5d %6d\n",
+			constants.ScoreboardRankWidth, i+1,
+			constants.ScoreboardNameWidth, utils.TruncateString(r.Name, constants.ScoreboardNameWidth),
+			r.ProblemCount, r.Points))
+	}
+	sb.WriteString("```")
+
+	if _, err := s.ChannelMessageSend(m.ChannelID, sb.String()); err != nil {
+		utils.Error("기간 랭킹 메시지 전송 실패: %v", err)
+	}
+}
+
+// buildPeriodRankings since 이후 풀이 기록을 대회 참가자별로 집계하여 정렬합니다.
+// 문제 점수는 대회 점수표의 기본 점수를 사용합니다 (배율 제외)
+func buildPeriodRankings(storage interfaces.StorageRepository, competition *models.Competition, since time.Time) []periodRanking {
+	profile := competition.ScoringRules()
+	startProblems := make(map[string]map[int]bool)
+	byHandle := make(map[string]*periodRanking)
+	for _, p := range storage.GetParticipants(competition.ID) {
+		startProblems[p.BaekjoonID] = startProblemSet(p)
+		byHandle[p.BaekjoonID] = &periodRanking{Name: p.Name, BaekjoonID: p.BaekjoonID}
+	}
+
+	tm := models.NewTierManager()
+	for _, record := range storage.GetSolvesSince(competition.ID, since) {
+		ranking, exists := byHandle[record.BaekjoonID]
+		if !exists {
+			continue // 대회에서 삭제된 참가자의 기록은 제외
+		}
+		if record.Backlog || startProblems[record.BaekjoonID][record.ProblemID] {
+			continue // 해결 시각을 알 수 없거나 시작 기록을 다시 남겨 대회 전에 해결한 것이 된 문제
+		}
+		ranking.ProblemCount++
+		ranking.Points += profile.PointsFor(tm, record.Level)
+	}
+
+	var rankings []periodRanking
+	for _, r := range byHandle {
+		if r.ProblemCount > 0 {
+			rankings = append(rankings, *r)
+		}
+	}
+
+	sort.Slice(rankings, func(i, j int) bool {
+		if rankings[i].Points != rankings[j].Points {
+			return rankings[i].Points > rankings[j].Points
+		}
+		return rankings[i].ProblemCount > rankings[j].ProblemCount
+	})
+	return rankings
+}
+
+// periodSolves 풀이 기록 중 기간 집계에 쓸 수 있는 기록만 남깁니다.
+// 해결 시각을 알 수 없는 Backlog 기록과, 시작 기록을 다시 남겨 시작 스냅샷에 포함된 문제는 제외합니다.
+func periodSolves(participant models.Participant, records []models.SolveRecord) []models.SolveRecord {
+	startProblems := startProblemSet(participant)
+
+	var filtered []models.SolveRecord
+	for _, record := range records {
+		if !record.Backlog && !startProblems[record.ProblemID] {
+			filtered = append(filtered, record)
+		}
+	}
+	return filtered
+}
+
+// startProblemSet 참가자의 시작 스냅샷 문제 ID 집합을 만듭니다
+func startProblemSet(participant models.Participant) map[int]bool {
+	set := make(map[int]bool, len(participant.StartProblemIDs))
+	for _, id := range participant.StartProblemIDs {
+		set[id] = true
+	}
+	return set
+}
+
+// findParticipant 대회에 백준 ID로 등록된 참가자를 찾습니다
+func findParticipant(storage interfaces.StorageRepository, competitionID int, baekjoonID string) *models.Participant {
+	for _, p := range storage.GetParticipants(competitionID) {
+		if strings.EqualFold(p.BaekjoonID, baekjoonID) {
+			participant := p
+			return &participant
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package bot
+
+import (
+	"discord-bot/interfaces"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// discordMessenger *discordgo.Session으로 interfaces.Messenger를 구현합니다.
+// 길드와 역할은 게이트웨이 상태(State)에서, 멤버는 REST API로 조회합니다.
+type discordMessenger struct {
+	session *discordgo.Session
+}
+
+func newDiscordMessenger(s *discordgo.Session) interfaces.Messenger {
+	return &discordMessenger{session: s}
+}
+
+func (d *discordMessenger) ChannelMessageSend(channelID, content string, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return d.session.ChannelMessageSend(channelID, content, options...)
+}
+
+func (d *discordMessenger) ChannelMessageSendEmbed(channelID string, embed *discordgo.MessageEmbed, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return d.session.ChannelMessageSendEmbed(channelID, embed, options...)
+}
+
+func (d *discordMessenger) Guild(guildID string) (*discordgo.Guild, error) {
+	if d.session.State == nil {
+		return nil, discordgo.ErrNilState
+	}
+	return d.session.State.Guild(guildID)
+}
+
+func (d *discordMessenger) GuildMember(guildID, userID string) (*discordgo.Member, error) {
+	return d.session.GuildMember(guildID, userID)
+}
+
+func (d *discordMessenger) GuildRole(guildID, roleID string) (*discordgo.Role, error) {
+	if d.session.State == nil {
+		return nil, discordgo.ErrNilState
+	}
+	return d.session.State.Role(guildID, roleID)
+}
+
+func (d *discordMessenger) GuildIDs() []string {
+	if d.session.State == nil {
+		return nil
+	}
+	d.session.State.RLock()
+	defer d.session.State.RUnlock()
+
+	ids := make([]string, len(d.session.State.Guilds))
+	for i, guild := range d.session.State.Guilds {
+		ids[i] = guild.ID
+	}
+	return ids
+}
+
+// interactionMessenger 슬래시 명령의 응답을 채널 메시지 대신 상호작용의 후속 메시지로 보냅니다
+type interactionMessenger struct {
+	discordMessenger
+	interaction *discordgo.Interaction
+}
+
+func newInteractionMessenger(s *discordgo.Session, interaction *discordgo.Interaction) interfaces.Messenger {
+	return &interactionMessenger{discordMessenger: discordMessenger{session: s}, interaction: interaction}
+}
+
+func (im *interactionMessenger) ChannelMessageSend(_, content string, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return im.session.FollowupMessageCreate(im.interaction, true, &discordgo.WebhookParams{
+		Content: content,
+	}, options...)
+}
+
+func (im *interactionMessenger) ChannelMessageSendEmbed(_ string, embed *discordgo.MessageEmbed, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return im.session.FollowupMessageCreate(im.interaction, true, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{embed},
+	}, options...)
+}
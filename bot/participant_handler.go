@@ -0,0 +1,269 @@
+package bot
+
+import (
+	"discord-bot/constants"
+	"discord-bot/errors"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// resolveSelf 명령을 보낸 사용자가 대회에 등록한 참가자를 찾습니다. 없으면 안내 메시지를 보냅니다
+func (ch *CommandHandler) resolveSelf(s *commandSession, m *discordgo.MessageCreate, g *guildScope, competition *models.Competition) (*models.Participant, bool) {
+	participant := findParticipantByDiscordUser(g.storage, competition.ID, m.Author.ID)
+	if participant == nil {
+		errors.SendDiscordInfo(s, m.ChannelID, fmt.Sprintf(
+			"[%s] 대회에 이 디스코드 계정으로 등록한 참가자가 없습니다. 다른 계정으로 등록했다면 관리자에게 문의하세요.", competition.Name))
+		return nil, false
+	}
+	return participant, true
+}
+
+// recordAudit 참가자 정보 변경 기록을 남깁니다. 기록에 실패해도 명령은 계속 진행합니다
+func (ch *CommandHandler) recordAudit(g *guildScope, entry models.AuditEntry) {
+	if err := g.storage.AppendAuditEntry(entry); err != nil {
+		utils.Error("길드 %s 변경 기록 저장 실패: %v", g.guildID, err)
+	}
+}
+
+// handleWithdraw 참가자가 스스로 대회에서 탈퇴합니다
+func (ch *CommandHandler) handleWithdraw(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	participant, ok := ch.resolveSelf(s, m, g, competition)
+	if !ok {
+		return
+	}
+
+	if err := g.storage.RemoveParticipant(competition.ID, participant.BaekjoonID); err != nil {
+		errorHandlers.Data().HandleParticipantNotFound(participant.BaekjoonID)
+		return
+	}
+	ch.scoreboardManager.InvalidateSnapshot(g.guildID, competition.ID)
+	ch.recordAudit(g, models.AuditEntry{
+		CompetitionID: competition.ID,
+		ParticipantID: participant.ID,
+		Action:        models.AuditWithdraw,
+		ActorID:       m.Author.ID,
+		OldValue:      fmt.Sprintf("%s (%s)", participant.Name, participant.BaekjoonID),
+	})
+
+	errors.SendDiscordSuccess(s, m.ChannelID, fmt.Sprintf("[%s] 대회에서 탈퇴했습니다.", competition.Name))
+}
+
+// handleRename 참가자가 자신의 표시 이름을 바꿉니다
+func (ch *CommandHandler) handleRename(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	participant, ok := ch.resolveSelf(s, m, g, competition)
+	if !ok {
+		return
+	}
+
+	name := args.String("name")
+	if err := g.storage.RenameParticipant(competition.ID, participant.ID, name); err != nil {
+		errorHandlers.Validation().HandleInvalidParams("RENAME_FAILED",
+			fmt.Sprintf("Failed to rename participant: %v", err), "이름 변경에 실패했습니다: "+err.Error())
+		return
+	}
+	ch.scoreboardManager.InvalidateSnapshot(g.guildID, competition.ID)
+	ch.recordAudit(g, models.AuditEntry{
+		CompetitionID: competition.ID,
+		ParticipantID: participant.ID,
+		Action:        models.AuditRename,
+		ActorID:       m.Author.ID,
+		OldValue:      participant.Name,
+		NewValue:      name,
+	})
+
+	errors.SendDiscordSuccess(s, m.ChannelID, fmt.Sprintf("표시 이름을 %s에서 %s(으)로 바꿨습니다.", participant.Name, name))
+}
+
+// handleHandleChangeRequest 참가자가 백준 ID 변경을 요청합니다. 관리자가 승인해야 반영됩니다
+func (ch *CommandHandler) handleHandleChangeRequest(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	participant, ok := ch.resolveSelf(s, m, g, competition)
+	if !ok {
+		return
+	}
+
+	// 등록과 마찬가지로 새 ID도 본인 소유임을 인증해야 함
+	newBaekjoonID := args.String("baekjoon_id")
+	link := g.storage.GetAccountLink(m.Author.ID)
+	if link == nil || !strings.EqualFold(link.BaekjoonID, newBaekjoonID) {
+		errorHandlers.Validation().HandleInvalidParams("ACCOUNT_NOT_VERIFIED",
+			fmt.Sprintf("User %s has not verified baekjoon ID %s", m.Author.ID, newBaekjoonID),
+			fmt.Sprintf("새 백준 ID %s의 소유 인증이 필요합니다. `!연동 시작 %s`로 먼저 인증해주세요.", newBaekjoonID, newBaekjoonID))
+		return
+	}
+
+	request, err := g.storage.CreateHandleChangeRequest(competition.ID, participant.ID, link.BaekjoonID, m.Author.ID)
+	if err != nil {
+		errorHandlers.Validation().HandleInvalidParams("HANDLE_CHANGE_REQUEST_FAILED",
+			fmt.Sprintf("Failed to create handle change request: %v", err), "백준 ID 변경 요청에 실패했습니다: "+err.Error())
+		return
+	}
+	ch.recordAudit(g, models.AuditEntry{
+		CompetitionID: competition.ID,
+		ParticipantID: participant.ID,
+		Action:        models.AuditHandleRequest,
+		ActorID:       m.Author.ID,
+		OldValue:      request.OldBaekjoonID,
+		NewValue:      request.NewBaekjoonID,
+	})
+
+	errors.SendDiscordSuccess(s, m.ChannelID, fmt.Sprintf(
+		"백준 ID 변경을 요청했습니다 (요청 %d: %s → %s). 관리자가 승인하면 시작 시점 기록을 유지한 채 반영됩니다.",
+		request.ID, request.OldBaekjoonID, request.NewBaekjoonID))
+}
+
+// handleChangeRequestList 처리되지 않은 백준 ID 변경 요청을 보여줍니다
+func (ch *CommandHandler) handleChangeRequestList(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	var sb strings.Builder
+	for _, r := range g.storage.GetHandleChangeRequests(competition.ID) {
+		if r.Status != models.RequestPending {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("• `%d` %s → %s (<@%s>, %s)\n",
+			r.ID, r.OldBaekjoonID, r.NewBaekjoonID, r.RequestedBy, utils.FormatDateTime(r.RequestedAt)))
+	}
+	if sb.Len() == 0 {
+		errors.SendDiscordInfo(s, m.ChannelID, fmt.Sprintf("[%s] 대회에 처리할 백준 ID 변경 요청이 없습니다.", competition.Name))
+		return
+	}
+
+	message := fmt.Sprintf("📝 **%s** 백준 ID 변경 요청\n%s`!변경요청 승인 <요청ID>` 또는 `!변경요청 거절 <요청ID>`로 처리하세요.",
+		competition.Name, sb.String())
+	if _, err := s.ChannelMessageSend(m.ChannelID, message); err != nil {
+		utils.Error("변경 요청 목록 메시지 전송 실패: %v", err)
+	}
+}
+
+// handleChangeRequestApprove 백준 ID 변경 요청을 승인합니다
+func (ch *CommandHandler) handleChangeRequestApprove(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	ch.resolveChangeRequest(s, m, args, true)
+}
+
+// handleChangeRequestReject 백준 ID 변경 요청을 거절합니다
+func (ch *CommandHandler) handleChangeRequestReject(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	ch.resolveChangeRequest(s, m, args, false)
+}
+
+func (ch *CommandHandler) resolveChangeRequest(s *commandSession, m *discordgo.MessageCreate, args *commandArgs, approve bool) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	request, err := g.storage.ResolveHandleChangeRequest(args.Int("request_id", 0), approve, m.Author.ID)
+	if err != nil {
+		errorHandlers.Validation().HandleInvalidParams("HANDLE_CHANGE_RESOLVE_FAILED",
+			fmt.Sprintf("Failed to resolve handle change request: %v", err), "변경 요청 처리에 실패했습니다: "+err.Error())
+		return
+	}
+
+	action, result := models.AuditHandleReject, "거절"
+	if approve {
+		action, result = models.AuditHandleApprove, "승인"
+		ch.scoreboardManager.InvalidateSnapshot(g.guildID, request.CompetitionID)
+	}
+	ch.recordAudit(g, models.AuditEntry{
+		CompetitionID: request.CompetitionID,
+		ParticipantID: request.ParticipantID,
+		Action:        action,
+		ActorID:       m.Author.ID,
+		OldValue:      request.OldBaekjoonID,
+		NewValue:      request.NewBaekjoonID,
+	})
+
+	errors.SendDiscordSuccess(s, m.ChannelID, fmt.Sprintf("변경 요청 %d(%s → %s)을(를) %s했습니다. <@%s>",
+		request.ID, request.OldBaekjoonID, request.NewBaekjoonID, result, request.RequestedBy))
+}
+
+// handleAuditLog 대회 참가자 정보 변경 기록을 최신순으로 보여줍니다
+func (ch *CommandHandler) handleAuditLog(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	entries := g.storage.GetAuditLog(competition.ID)
+	if len(entries) == 0 {
+		errors.SendDiscordInfo(s, m.ChannelID, fmt.Sprintf("[%s] 대회에 참가자 정보 변경 기록이 없습니다.", competition.Name))
+		return
+	}
+
+	if _, err := s.ChannelMessageSend(m.ChannelID, formatAuditLog(competition, entries)); err != nil {
+		utils.Error("변경 기록 메시지 전송 실패: %v", err)
+	}
+}
+
+// formatAuditLog 변경 기록을 최대 MaxAuditLogEntries개까지 나열합니다
+func formatAuditLog(competition *models.Competition, entries []models.AuditEntry) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📜 **%s** 참가자 정보 변경 기록\n", competition.Name))
+	for i, e := range entries {
+		if i >= constants.MaxAuditLogEntries {
+			sb.WriteString(fmt.Sprintf("... 외 %d건\n", len(entries)-constants.MaxAuditLogEntries))
+			break
+		}
+		change := e.OldValue
+		if e.NewValue != "" {
+			change = fmt.Sprintf("%s → %s", e.OldValue, e.NewValue)
+		}
+		sb.WriteString(fmt.Sprintf("• %s %s · 참가자 #%d · %s · <@%s>\n",
+			utils.FormatDateTime(e.CreatedAt), e.Action.Label(), e.ParticipantID, change, e.ActorID))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,309 @@
+package bot
+
+import (
+	"context"
+	"discord-bot/api"
+	"discord-bot/api/solvedactest"
+	"discord-bot/constants"
+	"discord-bot/interfaces"
+	"discord-bot/models"
+	"discord-bot/scoring"
+	"discord-bot/storage"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const pipelineGuildID = "123456789012345678"
+
+// newPipeline 가짜 solved.ac 서버에 연결된 저장소와 스코어보드 관리자를 만들고 진행 중인 대회에 두 사용자를 등록합니다
+func newPipeline(t *testing.T) (*ScoreboardManager, interfaces.StorageRepository, *models.Competition, *solvedactest.Server) {
+	t.Helper()
+	server := solvedactest.NewServer()
+	t.Cleanup(server.Close)
+
+	client := api.NewSolvedACClient(api.ClientOptions{
+		BaseURL:           server.BaseURL(),
+		RequestsPerSecond: 1000,
+		Burst:             100,
+		RetryDelay:        time.Millisecond,
+	})
+	registry := storage.NewRegistry(client, t.TempDir(), constants.StorageBackendJSON, "")
+	repo, err := registry.ForGuild(pipelineGuildID)
+	if err != nil {
+		t.Fatalf("ForGuild: %v", err)
+	}
+
+	now := time.Now()
+	competition, err := repo.CreateCompetition("파이프라인 대회", now.AddDate(0, 0, -1), now.AddDate(0, 0, 10))
+	if err != nil {
+		t.Fatalf("CreateCompetition: %v", err)
+	}
+
+	ctx := context.Background()
+	for handle, name := range map[string]string{solvedactest.GoldUser: "골드", solvedactest.NewbieUser: "뉴비"} {
+		info, err := client.GetUserInfo(ctx, handle)
+		if err != nil {
+			t.Fatalf("GetUserInfo(%s): %v", handle, err)
+		}
+		if err := repo.AddParticipant(ctx, competition.ID, name, handle, "", info.Tier, info.Rating); err != nil {
+			t.Fatalf("AddParticipant(%s): %v", handle, err)
+		}
+	}
+
+	sm := NewScoreboardManager(registry, scoring.NewScoreCalculator(client), client)
+	return sm, repo, competition, server
+}
+
+func TestScoringPipelineAgainstFakeServer(t *testing.T) {
+	sm, repo, competition, server := newPipeline(t)
+	ctx := context.Background()
+
+	for _, p := range repo.GetParticipants(competition.ID) {
+		if want := len(server.Solved(p.BaekjoonID)); p.StartProblemCount != want || len(p.StartProblemIDs) != want {
+			t.Fatalf("participant %s started with %d problems (%d ids), want %d", p.BaekjoonID, p.StartProblemCount, len(p.StartProblemIDs), want)
+		}
+	}
+
+	// 참가 전에 푼 문제는 점수에 들어가지 않습니다
+	snapshot, err := sm.RefreshScores(ctx, pipelineGuildID, competition.ID)
+	if err != nil {
+		t.Fatalf("RefreshScores: %v", err)
+	}
+	for _, score := range snapshot.Scores {
+		if score.Score != 0 || score.ProblemCount != 0 {
+			t.Fatalf("expected no points before solving anything, got %+v", score)
+		}
+	}
+
+	// Gold V 참가자: Gold IV 20×1.4 + Bronze I 5×0.5 = 30.5 → 31
+	server.Solve(solvedactest.GoldUser,
+		solvedactest.Problem{ProblemID: 30000, TitleKo: "도전 문제", Level: 12},
+		solvedactest.Problem{ProblemID: 30001, TitleKo: "쉬운 문제", Level: 5},
+	)
+	// Bronze III 참가자: Silver III 12×1.4 = 16.8 → 17
+	server.Solve(solvedactest.NewbieUser, solvedactest.Problem{ProblemID: 30002, TitleKo: "실버 문제", Level: 8})
+
+	snapshot, err = sm.RefreshScores(ctx, pipelineGuildID, competition.ID)
+	if err != nil {
+		t.Fatalf("RefreshScores: %v", err)
+	}
+	want := []struct {
+		handle   string
+		score    float64
+		problems int
+	}{
+		{solvedactest.GoldUser, 31, 2},
+		{solvedactest.NewbieUser, 17, 1},
+	}
+	if len(snapshot.Scores) != len(want) {
+		t.Fatalf("expected %d scores, got %+v", len(want), snapshot.Scores)
+	}
+	for i, w := range want {
+		got := snapshot.Scores[i]
+		if got.BaekjoonID != w.handle || got.Score != w.score || got.ProblemCount != w.problems {
+			t.Errorf("rank %d = %+v, want %s with %.0f points and %d problems", i+1, got, w.handle, w.score, w.problems)
+		}
+	}
+}
+
+func TestSolvesBeforeFirstPollAreBacklog(t *testing.T) {
+	// 참가자는 관리자가 만들어지기 전에 등록되었으므로 첫 확인 전의 풀이는 해결 시각을 알 수 없습니다
+	sm, repo, competition, server := newPipeline(t)
+	ctx := context.Background()
+
+	server.Solve(solvedactest.GoldUser, solvedactest.Problem{ProblemID: 30000, TitleKo: "밀린 문제", Level: 12})
+	if _, err := sm.RefreshScores(ctx, pipelineGuildID, competition.ID); err != nil {
+		t.Fatalf("RefreshScores: %v", err)
+	}
+	server.Solve(solvedactest.GoldUser, solvedactest.Problem{ProblemID: 30001, TitleKo: "새 문제", Level: 5})
+	if _, err := sm.RefreshScores(ctx, pipelineGuildID, competition.ID); err != nil {
+		t.Fatalf("RefreshScores: %v", err)
+	}
+
+	backlog := map[int]bool{}
+	for _, record := range repo.GetSolveHistory(competition.ID, solvedactest.GoldUser, time.Time{}) {
+		if record.CompetitionID != competition.ID {
+			t.Fatalf("record of another competition: %+v", record)
+		}
+		backlog[record.ProblemID] = record.Backlog
+	}
+	if len(backlog) != 2 || !backlog[30000] || backlog[30001] {
+		t.Fatalf("backlog flags = %v, want only the solve before the first poll marked", backlog)
+	}
+
+	rankings := buildPeriodRankings(repo, competition, time.Now().Add(-time.Hour))
+	if len(rankings) != 1 || rankings[0].ProblemCount != 1 {
+		t.Fatalf("period rankings = %+v, want the backlog solve excluded", rankings)
+	}
+}
+
+func TestFinalizeFallsBackToSnapshotWhenSolvedACFails(t *testing.T) {
+	sm, repo, competition, server := newPipeline(t)
+	ctx := context.Background()
+
+	server.Solve(solvedactest.NewbieUser, solvedactest.Problem{ProblemID: 30002, Level: 8})
+	if _, err := sm.RefreshScores(ctx, pipelineGuildID, competition.ID); err != nil {
+		t.Fatalf("RefreshScores: %v", err)
+	}
+
+	// 종료 시점에 solved.ac가 응답하지 않으면 마지막 스냅샷 점수로 확정합니다
+	server.FailNext(1000, http.StatusInternalServerError)
+	finalized, _ := sm.FinalizeDueCompetitions(ctx, pipelineGuildID, competition.EndDate.Add(time.Minute))
+	if len(finalized) != 1 {
+		t.Fatalf("expected the competition to be finalized, got %d", len(finalized))
+	}
+
+	standings := repo.GetFinalStandings(competition.ID)
+	if standings == nil || len(standings.Scores) != 2 {
+		t.Fatalf("expected stored final standings for both participants, got %+v", standings)
+	}
+	if top := standings.Scores[0]; top.BaekjoonID != solvedactest.NewbieUser || top.Score != 17 {
+		t.Errorf("expected the newbie to win with 17 points from the snapshot, got %+v", top)
+	}
+}
+
+func TestSolvesAfterFinalizationDoNotChangeFinalStandings(t *testing.T) {
+	sm, repo, competition, server := newPipeline(t)
+	ctx := context.Background()
+
+	server.Solve(solvedactest.NewbieUser, solvedactest.Problem{ProblemID: 30002, Level: 8})
+	if _, err := sm.RefreshScores(ctx, pipelineGuildID, competition.ID); err != nil {
+		t.Fatalf("RefreshScores: %v", err)
+	}
+
+	// 마지막 점수 갱신 뒤에 푼 문제는 확정할 때 조회해 종료 시각의 풀이로 반영합니다
+	server.Solve(solvedactest.GoldUser, solvedactest.Problem{ProblemID: 30000, Level: 12})
+	end := time.Now()
+	if err := repo.UpdateCompetitionEndDate(competition.ID, end); err != nil {
+		t.Fatalf("UpdateCompetitionEndDate: %v", err)
+	}
+
+	finalized, _ := sm.FinalizeDueCompetitions(ctx, pipelineGuildID, end.Add(time.Minute))
+	if len(finalized) != 1 {
+		t.Fatalf("expected the competition to be finalized, got %d", len(finalized))
+	}
+	standings := repo.GetFinalStandings(competition.ID)
+	if standings == nil || len(standings.Scores) != 2 {
+		t.Fatalf("expected stored final standings for both participants, got %+v", standings)
+	}
+	for _, score := range standings.Scores {
+		switch score.BaekjoonID {
+		case solvedactest.NewbieUser:
+			if score.Score != 17 || score.ProblemCount != 1 {
+				t.Errorf("newbie final score = %+v, want 17 points from one problem", score)
+			}
+		case solvedactest.GoldUser:
+			if score.Score <= 0 || score.ProblemCount != 1 {
+				t.Errorf("gold final score = %+v, want the solve since the last refresh counted", score)
+			}
+		}
+	}
+	history := repo.GetSolveHistory(competition.ID, solvedactest.GoldUser, time.Time{})
+	if len(history) != 1 || !history[0].FirstSeenAt.Equal(end) {
+		t.Fatalf("gold solve history = %+v, want the solve recorded at the end date", history)
+	}
+
+	// 확정된 뒤에 푼 문제는 최종 순위를 바꾸지 않습니다
+	server.Solve(solvedactest.NewbieUser, solvedactest.Problem{ProblemID: 30001, Level: 10})
+	if again, _ := sm.FinalizeDueCompetitions(ctx, pipelineGuildID, end.Add(time.Hour)); len(again) != 0 {
+		t.Fatalf("expected a finalized competition to stay frozen, got %d", len(again))
+	}
+	if after := repo.GetFinalStandings(competition.ID); len(after.Scores) != len(standings.Scores) || after.Scores[0] != standings.Scores[0] {
+		t.Fatalf("final standings changed after finalization: %+v", after)
+	}
+}
+
+func TestFinalizeCountsSolvesWithoutBackgroundPolling(t *testing.T) {
+	sm, repo, competition, server := newPipeline(t)
+	ctx := context.Background()
+
+	// 백그라운드 갱신이 꺼져 있어도 종료 전에 푼 문제는 확정할 때 조회해 반영합니다
+	server.Solve(solvedactest.NewbieUser, solvedactest.Problem{ProblemID: 30002, Level: 8})
+	end := time.Now()
+	if err := repo.UpdateCompetitionEndDate(competition.ID, end); err != nil {
+		t.Fatalf("UpdateCompetitionEndDate: %v", err)
+	}
+
+	finalized, _ := sm.FinalizeDueCompetitions(ctx, pipelineGuildID, end.Add(10*time.Minute))
+	if len(finalized) != 1 {
+		t.Fatalf("expected the competition to be finalized, got %d", len(finalized))
+	}
+	standings := repo.GetFinalStandings(competition.ID)
+	if standings == nil || len(standings.Scores) != 2 {
+		t.Fatalf("expected stored final standings for both participants, got %+v", standings)
+	}
+	for _, score := range standings.Scores {
+		if score.BaekjoonID == solvedactest.NewbieUser && (score.Score != 17 || score.ProblemCount != 1) {
+			t.Errorf("newbie final score = %+v, want 17 points from one problem", score)
+		}
+	}
+}
+
+func TestRefreshLockIsPerCompetition(t *testing.T) {
+	sm, repo, competition, _ := newPipeline(t)
+	ctx := context.Background()
+	other, err := repo.CreateCompetition("다른 대회", competition.StartDate, competition.EndDate)
+	if err != nil {
+		t.Fatalf("CreateCompetition: %v", err)
+	}
+
+	unlock := sm.lockRefresh(pipelineGuildID, competition.ID)
+	done := make(chan error, 1)
+	go func() {
+		_, err := sm.RefreshScores(ctx, pipelineGuildID, other.ID)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("RefreshScores(other): %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("refreshing another competition waited for the locked competition")
+	}
+
+	go func() {
+		_, err := sm.RefreshScores(ctx, pipelineGuildID, competition.ID)
+		done <- err
+	}()
+	select {
+	case <-done:
+		t.Fatal("refresh ran while the competition was locked")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	if err := <-done; err != nil {
+		t.Fatalf("RefreshScores: %v", err)
+	}
+}
+
+func TestRetakeStartSnapshotsStopsWhenContextEnds(t *testing.T) {
+	_, repo, competition, _ := newPipeline(t)
+	participants := repo.GetParticipants(competition.ID)
+
+	captured := 0
+	for result := range retakeStartSnapshots(context.Background(), repo, competition.ID, participants) {
+		if result.err != nil {
+			t.Fatalf("retake %s: %v", result.participant.BaekjoonID, result.err)
+		}
+		captured++
+	}
+	if captured != len(participants) {
+		t.Fatalf("captured %d of %d participants", captured, len(participants))
+	}
+
+	// 기한이 지난 뒤에는 남은 참가자를 기록하지 않고 실패로 돌려줍니다
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	failed := 0
+	for result := range retakeStartSnapshots(ctx, repo, competition.ID, participants) {
+		if result.err == nil {
+			t.Fatalf("retake %s succeeded after the context ended", result.participant.BaekjoonID)
+		}
+		failed++
+	}
+	if failed != len(participants) {
+		t.Fatalf("got %d results after the context ended, want %d", failed, len(participants))
+	}
+}
@@ -0,0 +1,194 @@
+package bot
+
+import (
+	"context"
+	"discord-bot/api"
+	"discord-bot/constants"
+	"discord-bot/interfaces"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// profileScore 프로필 카드에 표시할 스코어보드 정보입니다. 블랙아웃으로 가려졌으면 nil입니다
+type profileScore struct {
+	rank  int
+	total int
+	score models.ScoreData
+}
+
+// handleProfile 참가자의 등록 정보, 시작/현재 티어, 순위와 점수를 카드로 보여줍니다
+func (ch *CommandHandler) handleProfile(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	target := args.String("target")
+	participant := resolveProfileTarget(g.storage, competition.ID, target, m.Author.ID)
+	if participant == nil {
+		if target == "" {
+			target = m.Author.Username
+		}
+		errorHandlers.Data().HandleParticipantNotFound(target)
+		return
+	}
+
+	userInfo, err := ch.client.GetUserInfo(s.Context(), participant.BaekjoonID)
+	if err != nil {
+		errorHandlers.API().HandleBaekjoonUserNotFound(participant.BaekjoonID, err)
+		return
+	}
+
+	var score *profileScore
+	hidden := g.storage.IsBlackoutPeriod(competition.ID) || ch.scoreboardManager.IsRevealing(g.guildID, competition.ID)
+	if !hidden || ch.isAdmin(s, m) {
+		score = ch.participantScore(s.Context(), g.guildID, competition.ID, participant.ID)
+	}
+
+	embed := buildProfileEmbed(competition, *participant, userInfo, score)
+	if _, err := s.ChannelMessageSendEmbed(m.ChannelID, embed); err != nil {
+		utils.Error("프로필 embed 메시지 전송 실패: %v", err)
+	}
+}
+
+// participantScore 대회 순위(확정된 최종 순위 또는 최근 스냅샷)에서 참가자의 순위와 점수를 찾습니다
+func (ch *CommandHandler) participantScore(ctx context.Context, guildID string, competitionID, participantID int) *profileScore {
+	snapshot, err := ch.scoreboardManager.CurrentStandings(ctx, guildID, competitionID)
+	if err != nil {
+		utils.Warn("프로필용 점수 계산 실패 (길드 %s 대회 %d): %v", guildID, competitionID, err)
+		return nil
+	}
+
+	for i, score := range snapshot.Scores {
+		if score.ParticipantID == participantID {
+			return &profileScore{rank: i + 1, total: len(snapshot.Scores), score: score}
+		}
+	}
+	return nil
+}
+
+// resolveProfileTarget 백준 ID나 디스코드 멘션으로 참가자를 찾습니다. 대상이 비어 있으면 명령을 보낸 사용자를 찾습니다
+func resolveProfileTarget(storage interfaces.StorageRepository, competitionID int, target, authorID string) *models.Participant {
+	if target == "" {
+		return findParticipantByDiscordUser(storage, competitionID, authorID)
+	}
+	if userID, ok := parseUserMention(target); ok {
+		return findParticipantByDiscordUser(storage, competitionID, userID)
+	}
+	return findParticipant(storage, competitionID, target)
+}
+
+// findParticipantByDiscordUser 참가자를 등록한 디스코드 사용자 ID로 찾습니다
+func findParticipantByDiscordUser(storage interfaces.StorageRepository, competitionID int, userID string) *models.Participant {
+	if userID == "" {
+		return nil
+	}
+	for _, p := range storage.GetParticipants(competitionID) {
+		if p.DiscordUserID == userID {
+			participant := p
+			return &participant
+		}
+	}
+	return nil
+}
+
+// parseUserMention `<@123>` 또는 `<@!123>` 형식의 사용자 멘션에서 사용자 ID를 꺼냅니다
+func parseUserMention(text string) (string, bool) {
+	if !strings.HasPrefix(text, "<@") || !strings.HasSuffix(text, ">") {
+		return "", false
+	}
+	id := strings.TrimPrefix(strings.TrimSuffix(text[2:], ">"), "!")
+	if id == "" {
+		return "", false
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return "", false
+		}
+	}
+	return id, true
+}
+
+// buildProfileEmbed 참가자 프로필 카드를 만듭니다. 색상은 현재 티어를 따릅니다
+func buildProfileEmbed(competition *models.Competition, participant models.Participant, userInfo *api.UserInfo, score *profileScore) *discordgo.MessageEmbed {
+	tm := models.NewTierManager()
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("%s %s (%s)", constants.EmojiUser, participant.Name, participant.BaekjoonID),
+		URL:         constants.SolvedACProfileURL + participant.BaekjoonID,
+		Description: fmt.Sprintf("[%s] 대회 참가자 프로필", competition.Name),
+		Color:       tm.GetTierColor(userInfo.Tier),
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   constants.EmojiCalendar + " 등록일",
+				Value:  participant.CreatedAt.Format(constants.DateFormat),
+				Inline: true,
+			},
+			{
+				Name:   "시작 티어",
+				Value:  fmt.Sprintf("%s (%d)", tm.GetTierName(participant.StartTier), participant.StartRating),
+				Inline: true,
+			},
+			{
+				Name:   "현재 티어",
+				Value:  fmt.Sprintf("%s (%d)%s", tm.GetTierName(userInfo.Tier), userInfo.Rating, formatRatingChange(userInfo.Rating-participant.StartRating)),
+				Inline: true,
+			},
+		},
+	}
+	if participant.SnapshotPending {
+		embed.Fields[1].Value = "대회 시작 시 기록"
+	}
+	if userInfo.ProfileImageURL != "" {
+		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{URL: userInfo.ProfileImageURL}
+	}
+
+	if score == nil {
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: constants.EmojiLock + " 순위와 점수는 지금 확인할 수 없습니다 (블랙아웃 기간이거나 계산 전)",
+		}
+		return embed
+	}
+
+	embed.Fields = append(embed.Fields,
+		&discordgo.MessageEmbedField{
+			Name:   constants.EmojiTarget + " 대회 중 해결",
+			Value:  fmt.Sprintf("%d문제", score.score.ProblemCount),
+			Inline: true,
+		},
+		&discordgo.MessageEmbedField{
+			Name:   constants.EmojiTrophy + " 순위",
+			Value:  fmt.Sprintf("%d위 / %d명", score.rank, score.total),
+			Inline: true,
+		},
+		&discordgo.MessageEmbedField{
+			Name:   constants.EmojiStats + " 점수",
+			Value:  fmt.Sprintf("%.0f점", score.score.Score),
+			Inline: true,
+		},
+	)
+	return embed
+}
+
+// formatRatingChange 레이팅 변화량을 ` ▲12` / ` ▼3` 형식으로 반환합니다 (변화가 없으면 빈 문자열)
+func formatRatingChange(delta int) string {
+	switch {
+	case delta > 0:
+		return fmt.Sprintf(" ▲%d", delta)
+	case delta < 0:
+		return fmt.Sprintf(" ▼%d", -delta)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,66 @@
+package bot
+
+import (
+	"discord-bot/api"
+	"discord-bot/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseUserMention(t *testing.T) {
+	tests := []struct {
+		text   string
+		wantID string
+		wantOK bool
+	}{
+		{text: "<@123>", wantID: "123", wantOK: true},
+		{text: "<@!456>", wantID: "456", wantOK: true},
+		{text: "koosaga", wantOK: false},
+		{text: "<@&789>", wantOK: false}, // 역할 멘션
+		{text: "<@>", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		id, ok := parseUserMention(tt.text)
+		if ok != tt.wantOK || id != tt.wantID {
+			t.Errorf("parseUserMention(%q) = %q, %v; want %q, %v", tt.text, id, ok, tt.wantID, tt.wantOK)
+		}
+	}
+}
+
+func TestBuildProfileEmbed(t *testing.T) {
+	competition := &models.Competition{ID: 1, Name: "테스트 대회"}
+	participant := models.Participant{
+		ID: 1, Name: "참가자", BaekjoonID: "koosaga",
+		StartTier: 11, StartRating: 1500,
+		CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	userInfo := &api.UserInfo{Handle: "koosaga", Tier: 12, Rating: 1620}
+	tm := models.NewTierManager()
+
+	hidden := buildProfileEmbed(competition, participant, userInfo, nil)
+	if hidden.Color != tm.GetTierColor(12) {
+		t.Errorf("color = %#x, want current tier color", hidden.Color)
+	}
+	if len(hidden.Fields) != 3 || hidden.Footer == nil {
+		t.Fatalf("score fields should be hidden without a score: %+v", hidden.Fields)
+	}
+	if !strings.Contains(hidden.Fields[2].Value, "▲120") {
+		t.Errorf("current tier field = %q, want rating change", hidden.Fields[2].Value)
+	}
+
+	shown := buildProfileEmbed(competition, participant, userInfo, &profileScore{
+		rank: 2, total: 5, score: models.ScoreData{ParticipantID: 1, Score: 42, ProblemCount: 3},
+	})
+	var values []string
+	for _, field := range shown.Fields {
+		values = append(values, field.Value)
+	}
+	joined := strings.Join(values, "|")
+	for _, want := range []string{"2024-01-02", "3문제", "2위 / 5명", "42점"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("profile fields %q missing %q", joined, want)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package bot
+
+import (
+	"discord-bot/constants"
+	"discord-bot/errors"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// registrationFields `!등록설정 설정`으로 바꿀 수 있는 항목입니다
+var registrationFields = []string{"open", "close", "approval", "snapshot"}
+
+// requestRegistration 승인 방식 대회에 등록 신청을 남기고 관리자 승인을 기다리도록 안내합니다
+func (ch *CommandHandler) requestRegistration(s *commandSession, m *discordgo.MessageCreate, g *guildScope, competition *models.Competition, name, baekjoonID string) {
+	request, err := g.storage.CreateRegistrationRequest(competition.ID, name, baekjoonID, m.Author.ID)
+	if err != nil {
+		utils.NewErrorHandlerFactory(s, m.ChannelID).Validation().HandleInvalidParams("REGISTRATION_REQUEST_FAILED",
+			fmt.Sprintf("Failed to create registration request: %v", err), "등록 신청에 실패했습니다: "+err.Error())
+		return
+	}
+
+	errors.SendDiscordSuccess(s, m.ChannelID, fmt.Sprintf(
+		"[%s] 대회 등록을 신청했습니다 (신청 %d). 관리자가 승인하면 참가자로 등록됩니다.", competition.Name, request.ID))
+}
+
+// handleApplicationList 처리되지 않은 등록 신청을 보여줍니다
+func (ch *CommandHandler) handleApplicationList(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	var sb strings.Builder
+	for _, r := range g.storage.GetRegistrationRequests(competition.ID) {
+		if r.Status != models.RequestPending {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("• `%d` %s (%s, <@%s>, %s)\n",
+			r.ID, r.Name, r.BaekjoonID, r.DiscordUserID, utils.FormatDateTime(r.RequestedAt)))
+	}
+	if sb.Len() == 0 {
+		errors.SendDiscordInfo(s, m.ChannelID, fmt.Sprintf("[%s] 대회에 처리할 등록 신청이 없습니다.", competition.Name))
+		return
+	}
+
+	message := fmt.Sprintf("📝 **%s** 등록 신청\n%s`!승인 <신청ID>` 또는 `!거절 <신청ID>`로 처리하세요.",
+		competition.Name, sb.String())
+	if _, err := s.ChannelMessageSend(m.ChannelID, message); err != nil {
+		utils.Error("등록 신청 목록 메시지 전송 실패: %v", err)
+	}
+}
+
+// handleApplicationApprove 등록 신청을 승인하고 신청자를 참가자로 등록합니다
+func (ch *CommandHandler) handleApplicationApprove(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	request, ok := ch.pendingApplication(s, m, g, args.Int("request_id", 0))
+	if !ok {
+		return
+	}
+
+	userInfo, err := ch.client.GetUserInfo(s.Context(), request.BaekjoonID)
+	if err != nil {
+		errorHandlers.API().HandleBaekjoonUserNotFound(request.BaekjoonID, err)
+		return
+	}
+
+	// 참가자 추가와 승인 처리는 함께 저장되므로 실패하면 신청이 대기 상태로 남아 다시 처리할 수 있습니다
+	if _, err := g.storage.ApproveRegistrationRequest(s.Context(), request.ID, m.Author.ID, userInfo.Tier, userInfo.Rating); err != nil {
+		errorHandlers.Validation().HandleInvalidParams("REGISTRATION_APPROVE_FAILED",
+			fmt.Sprintf("Failed to approve registration request %d: %v", request.ID, err),
+			"참가자 등록에 실패했습니다: "+err.Error())
+		return
+	}
+	ch.scoreboardManager.InvalidateSnapshot(g.guildID, request.CompetitionID)
+
+	errors.SendDiscordSuccess(s, m.ChannelID, fmt.Sprintf("등록 신청 %d을(를) 승인했습니다. %s(%s)님이 대회에 등록되었습니다. <@%s>",
+		request.ID, request.Name, request.BaekjoonID, request.DiscordUserID))
+}
+
+// handleApplicationReject 등록 신청을 거절합니다
+func (ch *CommandHandler) handleApplicationReject(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	request, err := g.storage.RejectRegistrationRequest(args.Int("request_id", 0), m.Author.ID)
+	if err != nil {
+		errorHandlers.Validation().HandleInvalidParams("REGISTRATION_REJECT_FAILED",
+			fmt.Sprintf("Failed to reject registration request: %v", err), "등록 신청 처리에 실패했습니다: "+err.Error())
+		return
+	}
+
+	errors.SendDiscordSuccess(s, m.ChannelID, fmt.Sprintf("등록 신청 %d(%s, %s)을(를) 거절했습니다. <@%s>",
+		request.ID, request.Name, request.BaekjoonID, request.DiscordUserID))
+}
+
+// pendingApplication 처리되지 않은 등록 신청을 찾습니다. 없으면 안내 메시지를 보냅니다
+func (ch *CommandHandler) pendingApplication(s *commandSession, m *discordgo.MessageCreate, g *guildScope, requestID int) (*models.RegistrationRequest, bool) {
+	request := g.storage.GetRegistrationRequest(requestID)
+	if request == nil {
+		errors.SendDiscordInfo(s, m.ChannelID, fmt.Sprintf("등록 신청 %d을(를) 찾을 수 없습니다.", requestID))
+		return nil, false
+	}
+	if request.Status != models.RequestPending {
+		errors.SendDiscordInfo(s, m.ChannelID, fmt.Sprintf("이미 처리된 등록 신청입니다 (신청 %d).", requestID))
+		return nil, false
+	}
+	return request, true
+}
+
+// handleRegistrationShow 대회의 등록 기간과 승인 방식을 보여줍니다
+func (ch *CommandHandler) handleRegistrationShow(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	if _, err := s.ChannelMessageSend(m.ChannelID, formatRegistrationPolicy(competition)); err != nil {
+		utils.Error("등록 설정 메시지 전송 실패: %v", err)
+	}
+}
+
+// handleRegistrationSet 대회의 등록 기간과 승인 방식 중 한 항목을 변경합니다
+func (ch *CommandHandler) handleRegistrationSet(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	policy := competition.Registration
+	if err := applyRegistrationField(&policy, args.String("field"), args.String("value")); err != nil {
+		errorHandlers.Validation().HandleInvalidParams("REGISTRATION_INVALID_VALUE",
+			fmt.Sprintf("Invalid registration value: %v", err),
+			fmt.Sprintf("%v\n예시: `!등록설정 설정 close 2024-01-07`, `!등록설정 설정 approval on`, `!등록설정 설정 snapshot start`", err))
+		return
+	}
+
+	if err := g.storage.UpdateCompetitionRegistration(competition.ID, policy); err != nil {
+		botErr := errors.NewSystemError("REGISTRATION_UPDATE_FAILED",
+			"Failed to update competition registration policy", err)
+		botErr.UserMsg = "등록 설정 변경에 실패했습니다: " + err.Error()
+		errors.HandleDiscordError(s, m.ChannelID, botErr)
+		return
+	}
+
+	competition.Registration = policy
+	errors.SendDiscordSuccess(s, m.ChannelID, "등록 설정이 변경되었습니다.")
+	if _, err := s.ChannelMessageSend(m.ChannelID, formatRegistrationPolicy(competition)); err != nil {
+		utils.Error("등록 설정 메시지 전송 실패: %v", err)
+	}
+}
+
+// applyRegistrationField 입력된 항목과 값을 등록 방식에 반영하고 검증합니다
+func applyRegistrationField(policy *models.RegistrationPolicy, field, value string) error {
+	value = strings.TrimSpace(value)
+	switch field {
+	case "open", "close":
+		target := &policy.OpensAt
+		if field == "close" {
+			target = &policy.ClosesAt
+		}
+		if value == "none" || value == "없음" {
+			*target = time.Time{}
+			break
+		}
+		date, err := utils.ParseDateWithValidation(value, field)
+		if err != nil {
+			return err
+		}
+		*target = date
+	case "approval":
+		switch value {
+		case "on":
+			policy.RequireApproval = true
+		case "off":
+			policy.RequireApproval = false
+		default:
+			return fmt.Errorf("approval 값은 on 또는 off로 입력하세요")
+		}
+	case "snapshot":
+		policy.SnapshotTiming = models.SnapshotTiming(value)
+	default:
+		return fmt.Errorf("알 수 없는 항목입니다: %s", field)
+	}
+	return policy.Validate()
+}
+
+// formatRegistrationPolicy 등록 기간과 승인 방식을 메시지로 만듭니다
+func formatRegistrationPolicy(competition *models.Competition) string {
+	policy := competition.Registration
+
+	opens := "제한 없음"
+	if !policy.OpensAt.IsZero() {
+		opens = policy.OpensAt.Format(constants.DateFormat)
+	}
+	closes := "대회 종료 시"
+	if !policy.ClosesAt.IsZero() {
+		closes = policy.ClosesAt.Format(constants.DateFormat) + " (당일 포함)"
+	}
+	approval := "바로 등록"
+	if policy.RequireApproval {
+		approval = "관리자 승인 후 등록 (`!승인`/`!거절`)"
+	}
+	snapshot := "등록(승인) 시점"
+	if policy.Timing() == models.SnapshotAtStart {
+		snapshot = "대회 시작 시점 (시작 후 등록하면 등록 시점)"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📋 **%s** (`#%d`) 등록 설정\n", competition.Name, competition.ID))
+	sb.WriteString(fmt.Sprintf("• 등록 기간: %s ~ %s\n", opens, closes))
+	sb.WriteString("• 승인 방식: " + approval + "\n")
+	sb.WriteString("• 시작 기록 시점: " + snapshot)
+	return sb.String()
+}
@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"discord-bot/models"
+	"strings"
+	"testing"
+)
+
+func TestApplyRegistrationField(t *testing.T) {
+	var policy models.RegistrationPolicy
+	steps := []struct{ field, value string }{
+		{"open", "2024-01-01"},
+		{"close", "2024-01-07"},
+		{"approval", "on"},
+		{"snapshot", "start"},
+	}
+	for _, step := range steps {
+		if err := applyRegistrationField(&policy, step.field, step.value); err != nil {
+			t.Fatalf("applyRegistrationField(%s, %s): %v", step.field, step.value, err)
+		}
+	}
+	if policy.OpensAt.Day() != 1 || policy.ClosesAt.Day() != 7 || !policy.RequireApproval || policy.Timing() != models.SnapshotAtStart {
+		t.Fatalf("policy = %+v", policy)
+	}
+
+	if err := applyRegistrationField(&policy, "open", "none"); err != nil || !policy.OpensAt.IsZero() {
+		t.Fatalf("clearing open date: policy=%+v err=%v", policy, err)
+	}
+
+	invalid := []struct{ field, value string }{
+		{"open", "2024-02-01"}, // 마감일보다 늦은 시작일
+		{"close", "내일"},
+		{"approval", "yes"},
+		{"snapshot", "later"},
+		{"unknown", "1"},
+	}
+	for _, step := range invalid {
+		candidate := policy
+		if err := applyRegistrationField(&candidate, step.field, step.value); err == nil {
+			t.Errorf("applyRegistrationField(%s, %s) succeeded, want error", step.field, step.value)
+		}
+	}
+}
+
+func TestFormatRegistrationPolicy(t *testing.T) {
+	competition := &models.Competition{ID: 2, Name: "겨울 대회"}
+	if got := formatRegistrationPolicy(competition); !strings.Contains(got, "제한 없음 ~ 대회 종료 시") || !strings.Contains(got, "바로 등록") {
+		t.Fatalf("default policy message = %q", got)
+	}
+
+	competition.Registration = models.RegistrationPolicy{RequireApproval: true, SnapshotTiming: models.SnapshotAtStart}
+	got := formatRegistrationPolicy(competition)
+	if !strings.Contains(got, "관리자 승인") || !strings.Contains(got, "대회 시작 시점") {
+		t.Fatalf("approval policy message = %q", got)
+	}
+}
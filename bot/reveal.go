@@ -0,0 +1,282 @@
+package bot
+
+import (
+	"context"
+	"discord-bot/constants"
+	"discord-bot/errors"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// revealFields `!발표설정 설정`으로 바꿀 수 있는 항목입니다
+var revealFields = []string{"enabled", "delay", "group"}
+
+// IsRevealing 대회의 최종 결과를 순차 발표하는 중인지 확인합니다
+func (sm *ScoreboardManager) IsRevealing(guildID string, competitionID int) bool {
+	sm.snapshotMu.RLock()
+	defer sm.snapshotMu.RUnlock()
+	return sm.revealing[snapshotKey{guildID, competitionID}]
+}
+
+func (sm *ScoreboardManager) setRevealing(guildID string, competitionID int, revealing bool) {
+	sm.snapshotMu.Lock()
+	defer sm.snapshotMu.Unlock()
+	if revealing {
+		sm.revealing[snapshotKey{guildID, competitionID}] = true
+	} else {
+		delete(sm.revealing, snapshotKey{guildID, competitionID})
+	}
+}
+
+// CancelReveal 공지 채널이 없는 등으로 발표할 곳이 없을 때 발표 중 표시와 발표 대기 표시를 지웁니다
+func (sm *ScoreboardManager) CancelReveal(guildID string, competitionID int) {
+	sm.setRevealing(guildID, competitionID, false)
+	sm.markAnnounced(guildID, competitionID)
+}
+
+// RevealFinalResults 최종 순위를 아래 순위부터 차례로 채널에 공개하고 마지막에 전체 결과를 보냅니다.
+// 발표가 끝날 때까지 관리자가 아닌 사용자에게는 스코어보드를 보여주지 않습니다.
+// 중간 메시지를 보내지 못하거나 ctx가 취소되면 남은 단계를 건너뛰고 바로 전체 결과를 보냅니다.
+func (sm *ScoreboardManager) RevealFinalResults(ctx context.Context, session errors.MessageSender, guildID, channelID string, result FinalizedCompetition) error {
+	competition := result.Competition
+	sm.setRevealing(guildID, competition.ID, true)
+	defer sm.setRevealing(guildID, competition.ID, false)
+
+	delay := competition.Reveal.Delay()
+	if !sm.sendRevealStages(ctx, session, guildID, channelID, competition, result.Standings) {
+		delay = 0
+	}
+
+	select {
+	case <-time.After(delay):
+	case <-ctx.Done():
+	}
+	return sm.AnnounceFinalResults(ctx, session, guildID, channelID, result)
+}
+
+// sendRevealStages 순차 발표 메시지를 간격을 두고 보냅니다. 모든 단계를 보내면 true를 반환합니다
+func (sm *ScoreboardManager) sendRevealStages(ctx context.Context, session errors.MessageSender, guildID, channelID string, competition *models.Competition, standings *models.FinalStandings) bool {
+	delay := competition.Reveal.Delay()
+	for i, stage := range revealStages(competition, standings) {
+		if i > 0 {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				utils.Info("길드 %s 대회 %d 최종 결과 순차 발표가 중단되어 전체 결과를 바로 보냅니다", guildID, competition.ID)
+				return false
+			}
+		}
+		if _, err := session.ChannelMessageSend(channelID, stage); err != nil {
+			utils.Warn("길드 %s 대회 %d 순차 발표 메시지 전송 실패, 전체 결과를 바로 보냅니다: %v", guildID, competition.ID, err)
+			return false
+		}
+	}
+	return true
+}
+
+// AnnounceFinalResults 최종 결과 embed를 채널에 보내고 발표 대기 표시를 지웁니다.
+// 전송에 실패하면 간격을 두고 다시 시도하며, 끝내 보내지 못하면 대기 표시가 남아 봇이 다시 시작할 때 발표합니다.
+func (sm *ScoreboardManager) AnnounceFinalResults(ctx context.Context, session errors.MessageSender, guildID, channelID string, result FinalizedCompetition) error {
+	embed := FinalResultsEmbed(result.Competition, result.Standings)
+
+	var err error
+	for attempt := 1; attempt <= constants.AnnounceAttempts; attempt++ {
+		if _, err = session.ChannelMessageSendEmbed(channelID, embed); err == nil {
+			sm.markAnnounced(guildID, result.Competition.ID)
+			return nil
+		}
+		utils.Warn("길드 %s 대회 %d 최종 결과 전송 실패 (%d/%d): %v", guildID, result.Competition.ID, attempt, constants.AnnounceAttempts, err)
+		if attempt == constants.AnnounceAttempts {
+			break
+		}
+
+		// 종료 중이면 기다리지 않고 다시 시작할 때 발표하도록 남겨둡니다
+		select {
+		case <-time.After(constants.AnnounceRetryDelay):
+		case <-ctx.Done():
+			return err
+		}
+	}
+	return err
+}
+
+// PendingAnnouncements 최종 결과를 확정했지만 아직 발표하지 못한 대회와 그 순위를 반환합니다
+func (sm *ScoreboardManager) PendingAnnouncements(guildID string) []FinalizedCompetition {
+	storage, err := sm.storages.ForGuild(guildID)
+	if err != nil {
+		utils.Warn("길드 %s 저장소를 열 수 없습니다: %v", guildID, err)
+		return nil
+	}
+
+	var pending []FinalizedCompetition
+	for _, competition := range storage.GetCompetitions() {
+		if !competition.ResultsPending || sm.IsRevealing(guildID, competition.ID) {
+			continue
+		}
+		standings := storage.GetFinalStandings(competition.ID)
+		if standings == nil {
+			continue
+		}
+		pending = append(pending, FinalizedCompetition{Competition: competition, Standings: standings})
+	}
+	return pending
+}
+
+// markAnnounced 대회의 최종 결과 발표 대기 표시를 지웁니다
+func (sm *ScoreboardManager) markAnnounced(guildID string, competitionID int) {
+	storage, err := sm.storages.ForGuild(guildID)
+	if err != nil {
+		utils.Warn("길드 %s 저장소를 열 수 없습니다: %v", guildID, err)
+		return
+	}
+	if err := storage.MarkResultsAnnounced(competitionID); err != nil {
+		utils.Error("길드 %s 대회 %d 최종 결과 발표 완료 저장 실패: %v", guildID, competitionID, err)
+	}
+}
+
+// revealStages 순차 발표 메시지를 순서대로 만듭니다.
+// 입상자 밖의 순위는 정해진 인원씩 묶어 아래 순위부터, 입상자는 한 명씩 공개합니다.
+func revealStages(competition *models.Competition, standings *models.FinalStandings) []string {
+	scores := standings.Scores
+	if len(scores) == 0 {
+		return []string{fmt.Sprintf("🥁 **%s** 대회가 끝났습니다! 참가자가 없어 발표할 순위가 없습니다.", competition.Name)}
+	}
+
+	stages := []string{fmt.Sprintf("🥁 **%s** 대회가 끝났습니다! %d명의 최종 순위를 %d위부터 공개합니다.",
+		competition.Name, len(scores), len(scores))}
+
+	podium := len(standings.Podium(constants.FinalPodiumSize))
+	group := competition.Reveal.Group()
+	for end := len(scores); end > podium; end -= group {
+		start := end - group
+		if start < podium {
+			start = podium
+		}
+
+		var sb strings.Builder
+		if start+1 == end {
+			sb.WriteString(fmt.Sprintf("📢 **%d위**", end))
+		} else {
+			sb.WriteString(fmt.Sprintf("📢 **%d위 ~ %d위**", end, start+1))
+		}
+		for i := end - 1; i >= start; i-- {
+			sb.WriteString(fmt.Sprintf("\n`%d위` %s (%s) - %.0f점", i+1, scores[i].Name, scores[i].BaekjoonID, scores[i].Score))
+		}
+		stages = append(stages, sb.String())
+	}
+
+	for i := podium - 1; i >= 0; i-- {
+		stage := fmt.Sprintf("%s **%d위** %s (%s) - %.0f점", podiumMedal(i), i+1, scores[i].Name, scores[i].BaekjoonID, scores[i].Score)
+		if i == 0 {
+			stage = "🎉 우승을 축하합니다!\n" + stage
+		}
+		stages = append(stages, stage)
+	}
+	return stages
+}
+
+// handleRevealShow 대회의 최종 결과 발표 방식을 보여줍니다
+func (ch *CommandHandler) handleRevealShow(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	if _, err := s.ChannelMessageSend(m.ChannelID, formatRevealSettings(competition)); err != nil {
+		utils.Error("발표 설정 메시지 전송 실패: %v", err)
+	}
+}
+
+// handleRevealSet 대회의 최종 결과 발표 방식 중 한 항목을 변경합니다
+func (ch *CommandHandler) handleRevealSet(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	settings := competition.Reveal
+	if err := applyRevealField(&settings, args.String("field"), args.String("value")); err != nil {
+		errorHandlers.Validation().HandleInvalidParams("REVEAL_INVALID_VALUE",
+			fmt.Sprintf("Invalid reveal value: %v", err),
+			fmt.Sprintf("%v\n예시: `!발표설정 설정 enabled on`, `!발표설정 설정 delay 15`, `!발표설정 설정 group 3`", err))
+		return
+	}
+
+	if err := g.storage.UpdateCompetitionReveal(competition.ID, settings); err != nil {
+		botErr := errors.NewSystemError("REVEAL_UPDATE_FAILED",
+			"Failed to update competition reveal settings", err)
+		botErr.UserMsg = "발표 설정 변경에 실패했습니다: " + err.Error()
+		errors.HandleDiscordError(s, m.ChannelID, botErr)
+		return
+	}
+
+	competition.Reveal = settings
+	errors.SendDiscordSuccess(s, m.ChannelID, "발표 설정이 변경되었습니다.")
+	if _, err := s.ChannelMessageSend(m.ChannelID, formatRevealSettings(competition)); err != nil {
+		utils.Error("발표 설정 메시지 전송 실패: %v", err)
+	}
+}
+
+// applyRevealField 입력된 항목과 값을 발표 방식에 반영하고 검증합니다
+func applyRevealField(settings *models.RevealSettings, field, value string) error {
+	value = strings.TrimSpace(value)
+	switch field {
+	case "enabled":
+		switch value {
+		case "on":
+			settings.Enabled = true
+		case "off":
+			settings.Enabled = false
+		default:
+			return fmt.Errorf("enabled 값은 on 또는 off로 입력하세요")
+		}
+	case "delay", "group":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("%s 값은 1 이상의 정수로 입력하세요", field)
+		}
+		if field == "delay" {
+			settings.DelaySeconds = n
+		} else {
+			settings.GroupSize = n
+		}
+	default:
+		return fmt.Errorf("알 수 없는 항목입니다: %s", field)
+	}
+	return settings.Validate()
+}
+
+// formatRevealSettings 최종 결과 발표 방식을 메시지로 만듭니다
+func formatRevealSettings(competition *models.Competition) string {
+	settings := competition.Reveal
+
+	mode := "한 번에 발표"
+	if settings.Enabled {
+		mode = "아래 순위부터 차례로 발표"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🥁 **%s** (`#%d`) 최종 결과 발표 설정\n", competition.Name, competition.ID))
+	sb.WriteString("• 발표 방식: " + mode + "\n")
+	sb.WriteString(fmt.Sprintf("• 메시지 간격: %d초\n", int(settings.Delay()/time.Second)))
+	sb.WriteString(fmt.Sprintf("• 한 번에 공개할 인원: %d명 (%d위 이내는 한 명씩)", settings.Group(), constants.FinalPodiumSize))
+	return sb.String()
+}
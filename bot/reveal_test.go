@@ -0,0 +1,114 @@
+package bot
+
+import (
+	"context"
+	"discord-bot/bot/discordtest"
+	"discord-bot/models"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRevealStages(t *testing.T) {
+	standings := &models.FinalStandings{}
+	for i := 1; i <= 8; i++ {
+		standings.Scores = append(standings.Scores, models.ScoreData{
+			Name: fmt.Sprintf("참가자%d", i), BaekjoonID: fmt.Sprintf("user%d", i), Score: float64(100 - i),
+		})
+	}
+	competition := &models.Competition{Name: "겨울 대회", Reveal: models.RevealSettings{Enabled: true, GroupSize: 3}}
+
+	stages := revealStages(competition, standings)
+	// 시작 안내, 8~6위, 5~4위, 3위, 2위, 1위
+	if len(stages) != 6 {
+		t.Fatalf("got %d stages, want 6: %q", len(stages), stages)
+	}
+	if !strings.Contains(stages[1], "8위 ~ 6위") || strings.Index(stages[1], "user8") > strings.Index(stages[1], "user6") {
+		t.Fatalf("first group should reveal 8th to 6th place from the bottom: %q", stages[1])
+	}
+	if !strings.Contains(stages[2], "5위 ~ 4위") || strings.Contains(stages[2], "user3") {
+		t.Fatalf("second group should stop before the podium: %q", stages[2])
+	}
+	if !strings.Contains(stages[3], "🥉 **3위** 참가자3") || !strings.Contains(stages[5], "🥇 **1위** 참가자1") {
+		t.Fatalf("podium should be revealed one by one ending with the winner: %q", stages[3:])
+	}
+
+	small := &models.FinalStandings{Scores: standings.Scores[:2]}
+	if got := revealStages(competition, small); len(got) != 3 || !strings.Contains(got[1], "2위") {
+		t.Fatalf("two participants stages = %q", got)
+	}
+}
+
+func TestApplyRevealField(t *testing.T) {
+	var settings models.RevealSettings
+	steps := []struct{ field, value string }{
+		{"enabled", "on"},
+		{"delay", "15"},
+		{"group", "3"},
+	}
+	for _, step := range steps {
+		if err := applyRevealField(&settings, step.field, step.value); err != nil {
+			t.Fatalf("applyRevealField(%s, %s): %v", step.field, step.value, err)
+		}
+	}
+	if !settings.Enabled || settings.DelaySeconds != 15 || settings.GroupSize != 3 {
+		t.Fatalf("settings = %+v", settings)
+	}
+
+	invalid := []struct{ field, value string }{
+		{"enabled", "yes"},
+		{"delay", "0"},
+		{"delay", "9999"},
+		{"group", "many"},
+		{"group", "100"},
+		{"unknown", "1"},
+	}
+	for _, step := range invalid {
+		candidate := settings
+		if err := applyRevealField(&candidate, step.field, step.value); err == nil {
+			t.Errorf("applyRevealField(%s, %s) succeeded, want error", step.field, step.value)
+		}
+	}
+}
+
+func TestRevealPostsFinalResultsWhenInterrupted(t *testing.T) {
+	sm, repo, competition, _ := newPipeline(t)
+	finalized, _ := sm.FinalizeDueCompetitions(context.Background(), pipelineGuildID, competition.EndDate.Add(time.Minute))
+	if len(finalized) != 1 {
+		t.Fatalf("expected the competition to be finalized, got %d", len(finalized))
+	}
+	result := finalized[0]
+	result.Competition.Reveal = models.RevealSettings{Enabled: true, DelaySeconds: 60}
+	if pending := sm.PendingAnnouncements(pipelineGuildID); len(pending) != 1 {
+		t.Fatalf("pending announcements after finalizing = %d, want 1", len(pending))
+	}
+
+	// 종료 중이라 ctx가 취소되어도 남은 단계를 건너뛰고 전체 결과를 보냅니다
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	messenger := discordtest.NewMessenger()
+	messenger.FailSends(errors.New("discord unavailable"))
+	if err := sm.RevealFinalResults(ctx, messenger, pipelineGuildID, "announcements", result); err == nil {
+		t.Fatal("expected the reveal to fail while discord is unavailable")
+	}
+	if pending := sm.PendingAnnouncements(pipelineGuildID); len(pending) != 1 {
+		t.Fatalf("pending announcements after a failed reveal = %d, want 1 to announce on restart", len(pending))
+	}
+
+	messenger.FailSends(nil)
+	if err := sm.RevealFinalResults(ctx, messenger, pipelineGuildID, "announcements", result); err != nil {
+		t.Fatalf("RevealFinalResults: %v", err)
+	}
+	last, ok := messenger.Last()
+	if !ok || last.Embed == nil || !strings.Contains(last.Embed.Title, "최종 결과") {
+		t.Fatalf("last message = %+v, want the final results embed", last)
+	}
+	if pending := sm.PendingAnnouncements(pipelineGuildID); len(pending) != 0 {
+		t.Fatalf("pending announcements after the reveal = %d, want none", len(pending))
+	}
+	if repo.GetCompetition(competition.ID).ResultsPending {
+		t.Fatal("expected the announcement to be stored")
+	}
+}
@@ -0,0 +1,134 @@
+package bot
+
+import (
+	"discord-bot/constants"
+	"discord-bot/errors"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// handleScoreBreakdown 참가자의 점수가 어떤 문제들로 계산되었는지 보여줍니다
+func (ch *CommandHandler) handleScoreBreakdown(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	if g.storage.IsBlackoutPeriod(competition.ID) && !ch.isAdmin(s, m) {
+		errors.SendDiscordInfo(s, m.ChannelID, "블랙아웃 기간에는 점수 내역을 확인할 수 없습니다.")
+		return
+	}
+
+	baekjoonID := args.String("baekjoon_id")
+	participant := findParticipant(g.storage, competition.ID, baekjoonID)
+	if participant == nil {
+		errorHandlers.Data().HandleParticipantNotFound(baekjoonID)
+		return
+	}
+	// 확정된 대회는 종료 후의 풀이가 섞이지 않도록 현재 풀이로 다시 계산하지 않습니다
+	if competition.IsFinalized() {
+		if ch.scoreboardManager.IsRevealing(g.guildID, competition.ID) && !ch.isAdmin(s, m) {
+			errors.SendDiscordInfo(s, m.ChannelID, "공지 채널에서 최종 결과를 발표하는 중입니다. 발표가 끝나면 확인할 수 있습니다.")
+			return
+		}
+		message := fmt.Sprintf("[%s] 대회는 최종 결과가 확정되었습니다.", competition.Name)
+		if score := ch.participantScore(s.Context(), g.guildID, competition.ID, participant.ID); score != nil {
+			message += fmt.Sprintf(" %s의 최종 점수는 **%.0f점** (%d명 중 %d위)입니다.",
+				participant.BaekjoonID, score.score.Score, score.total, score.rank)
+		}
+		errors.SendDiscordInfo(s, m.ChannelID, message)
+		return
+	}
+	if participant.SnapshotPending {
+		errors.SendDiscordInfo(s, m.ChannelID, fmt.Sprintf(
+			"%s의 시작 시점 기록은 대회가 시작되면 남습니다. 그 전에는 점수가 계산되지 않습니다.", participant.BaekjoonID))
+		return
+	}
+
+	breakdown, err := ch.scoreboardManager.ScoreBreakdown(s.Context(), *participant, competition.ScoringRules())
+	if err != nil {
+		errorHandlers.API().HandleBaekjoonUserNotFound(participant.BaekjoonID, err)
+		return
+	}
+
+	if _, err := s.ChannelMessageSend(m.ChannelID, formatScoreBreakdown(*participant, breakdown)); err != nil {
+		utils.Error("점수 내역 메시지 전송 실패: %v", err)
+	}
+}
+
+// formatScoreBreakdown 점수 내역을 반영 점수가 높은 문제부터 나열합니다
+func formatScoreBreakdown(participant models.Participant, breakdown models.ScoreBreakdown) string {
+	tm := models.NewTierManager()
+	counted := append([]models.ProblemScore(nil), breakdown.Counted...)
+	sort.SliceStable(counted, func(i, j int) bool {
+		if counted[i].Points != counted[j].Points {
+			return counted[i].Points > counted[j].Points
+		}
+		return counted[i].ProblemID < counted[j].ProblemID
+	})
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🧮 **%s (%s)** 점수 내역 - 총 **%.0f점** (%d문제, 시작 티어 %s)\n",
+		participant.Name, participant.BaekjoonID, breakdown.Total, len(counted), tm.GetTierName(participant.StartTier)))
+
+	if len(counted) == 0 {
+		sb.WriteString("등록 이후 새로 해결한 문제가 없습니다.\n")
+	} else {
+		sb.WriteString("```\n")
+		sb.WriteString(fmt.Sprintf("%6s %s %-12s %4s %-9s %6s\n", "문제",
+			utils.PadStringByWidth("제목", constants.BreakdownTitleWidth), "티어", "기본", "가중치", "점수"))
+		for i, p := range counted {
+			if i >= constants.MaxBreakdownEntries {
+				sb.WriteString(fmt.Sprintf("... 외 %d문제\n", len(counted)-constants.MaxBreakdownEntries))
+				break
+			}
+			capped := ""
+			if p.Capped {
+				capped = " (상한)"
+			}
+			sb.WriteString(fmt.Sprintf("%6d %s %-12s %4d %-9s %6.1f%s\n",
+				p.ProblemID,
+				utils.PadStringByWidth(utils.TruncateByWidth(p.Title, constants.BreakdownTitleWidth), constants.BreakdownTitleWidth),
+				tm.GetTierName(p.Level),
+				p.BasePoints,
+				fmt.Sprintf("×%g %s", p.Weight, p.Category),
+				p.Points, capped))
+		}
+		sb.WriteString("```")
+	}
+
+	if breakdown.TotalCapped {
+		sb.WriteString(fmt.Sprintf("\n총점 상한이 적용되었습니다 (합계 %.1f점).", breakdown.Subtotal))
+	}
+	if len(breakdown.PreSolved) > 0 {
+		sb.WriteString(fmt.Sprintf("\n등록 시점에 이미 해결해 제외된 문제: %d개 (%s)",
+			len(breakdown.PreSolved), formatProblemIDs(breakdown.PreSolved, constants.MaxPreSolvedListed)))
+	}
+	return sb.String()
+}
+
+// formatProblemIDs 문제 ID를 최대 limit개까지 쉼표로 나열합니다
+func formatProblemIDs(problems []models.ProblemScore, limit int) string {
+	ids := make([]string, 0, limit)
+	for i, p := range problems {
+		if i >= limit {
+			ids = append(ids, fmt.Sprintf("외 %d개", len(problems)-limit))
+			break
+		}
+		ids = append(ids, strconv.Itoa(p.ProblemID))
+	}
+	return strings.Join(ids, ", ")
+}
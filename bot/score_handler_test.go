@@ -0,0 +1,31 @@
+package bot
+
+import (
+	"discord-bot/models"
+	"strings"
+	"testing"
+)
+
+func TestFormatScoreBreakdown(t *testing.T) {
+	participant := models.Participant{Name: "홍길동", BaekjoonID: "gildong", StartTier: 11}
+	breakdown := models.ScoreBreakdown{
+		Counted: []models.ProblemScore{
+			{ProblemID: 1000, Title: "A+B", Level: 1, BasePoints: 1, Weight: 0.5, Category: models.WeightPractice, Points: 0.5},
+			{ProblemID: 2000, Title: "아주 긴 제목을 가진 어려운 도전 문제", Level: 12, BasePoints: 20, Weight: 1.4, Category: models.WeightChallenge, Points: 25, Capped: true},
+		},
+		PreSolved: []models.ProblemScore{{ProblemID: 1001}, {ProblemID: 1002}},
+		Subtotal:  25.5,
+		Total:     26,
+	}
+
+	text := formatScoreBreakdown(participant, breakdown)
+
+	for _, want := range []string{"총 **26점**", "2000", "×1.4 도전", "(상한)", "1001, 1002"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("breakdown does not contain %q:\n%s", want, text)
+		}
+	}
+	if strings.Index(text, "2000") > strings.Index(text, " 1000 ") {
+		t.Errorf("problems should be sorted by points:\n%s", text)
+	}
+}
@@ -1,7 +1,10 @@
 package bot
 
 import (
+	"context"
+	"discord-bot/api"
 	"discord-bot/constants"
+	"discord-bot/errors"
 	"discord-bot/interfaces"
 	"discord-bot/models"
 	"discord-bot/utils"
@@ -15,50 +18,202 @@ import (
 )
 
 type ScoreboardManager struct {
-	storage    interfaces.StorageRepository
+	storages   interfaces.GuildStorageProvider
 	calculator interfaces.ScoreCalculator
 	client     interfaces.APIClient
+
+	snapshotMu sync.RWMutex
+	snapshots  map[snapshotKey]*models.ScoreboardSnapshot
+	revealing  map[snapshotKey]bool // 최종 결과를 순차 발표 중인 대회 (snapshotMu로 보호)
+
+	// 같은 대회의 점수 갱신, 시작 기록, 최종 결과 확정이 동시에 실행되지 않도록 대회별로 직렬화합니다
+	refreshMu    sync.Mutex
+	refreshLocks map[snapshotKey]*sync.Mutex
+
+	// 풀이를 확인한 적 없는 참가자의 새 풀이는 언제 해결했는지 알 수 없으므로 Backlog로 기록합니다
+	pollMu    sync.Mutex
+	polled    map[snapshotKey]map[int]bool // 이 관리자가 풀이를 확인한 참가자 ID
+	startedAt time.Time                    // 이 시각 이후 등록한 참가자는 등록 때부터 확인한 것으로 봅니다
+}
+
+// snapshotKey 스냅샷을 길드와 대회 단위로 구분합니다
+type snapshotKey struct {
+	guildID       string
+	competitionID int
 }
 
-func NewScoreboardManager(storage interfaces.StorageRepository, calculator interfaces.ScoreCalculator, client interfaces.APIClient) *ScoreboardManager {
+func NewScoreboardManager(storages interfaces.GuildStorageProvider, calculator interfaces.ScoreCalculator, client interfaces.APIClient) *ScoreboardManager {
 	return &ScoreboardManager{
-		storage:    storage,
-		calculator: calculator,
-		client:     client,
+		storages:     storages,
+		calculator:   calculator,
+		client:       client,
+		snapshots:    make(map[snapshotKey]*models.ScoreboardSnapshot),
+		revealing:    make(map[snapshotKey]bool),
+		refreshLocks: make(map[snapshotKey]*sync.Mutex),
+		polled:       make(map[snapshotKey]map[int]bool),
+		startedAt:    time.Now(),
 	}
 }
 
-func (sm *ScoreboardManager) GenerateScoreboard(isAdmin bool) (*discordgo.MessageEmbed, error) {
-	competition := sm.storage.GetCompetition()
+func (sm *ScoreboardManager) GenerateScoreboard(ctx context.Context, guildID string, competitionID int, isAdmin bool) (*discordgo.MessageEmbed, error) {
+	storage, err := sm.storages.ForGuild(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	competition := storage.GetCompetition(competitionID)
+	if competition != nil && competition.IsFinalized() {
+		return sm.finalScoreboard(guildID, storage, competition, isAdmin)
+	}
 	if competition == nil || !competition.IsActive {
 		return nil, fmt.Errorf("활성화된 대회가 없습니다")
 	}
 
 	// 블랙아웃 체크
-	if embed := sm.checkBlackoutPeriod(competition, isAdmin); embed != nil {
+	if embed := sm.checkBlackoutPeriod(storage, competition, isAdmin); embed != nil {
 		return embed, nil
 	}
 
 	// 참가자 체크
-	participants := sm.storage.GetParticipants()
+	participants := storage.GetParticipants(competition.ID)
 	if embed := sm.checkEmptyParticipants(competition, participants); embed != nil {
 		return embed, nil
 	}
 
-	// 점수 데이터 수집
-	scores, err := sm.collectScoreData(participants)
+	snapshot, err := sm.CurrentStandings(ctx, guildID, competition.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sm.formatScoreboard(competition, snapshot, isAdmin), nil
+}
+
+// CurrentStandings 대회의 순위를 반환합니다. 최종 결과가 확정된 대회는 확정된 순위를,
+// 진행 중인 대회는 백그라운드에서 갱신된 스냅샷을 사용하고 없으면 즉시 계산합니다.
+func (sm *ScoreboardManager) CurrentStandings(ctx context.Context, guildID string, competitionID int) (*models.ScoreboardSnapshot, error) {
+	storage, err := sm.storages.ForGuild(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	if standings := storage.GetFinalStandings(competitionID); standings != nil {
+		return &models.ScoreboardSnapshot{UpdatedAt: standings.FinalizedAt, Scores: standings.Scores}, nil
+	}
+	if snapshot := sm.LatestSnapshot(guildID, competitionID); snapshot != nil {
+		return snapshot, nil
+	}
+	return sm.RefreshScores(ctx, guildID, competitionID)
+}
+
+// RefreshScores 대회 참가자 전원의 점수를 다시 계산하여 새 스냅샷으로 저장합니다.
+// 계산 중에 ctx가 취소되면 일부 참가자만 반영된 스냅샷을 남기지 않고 ctx의 오류를 반환합니다.
+func (sm *ScoreboardManager) RefreshScores(ctx context.Context, guildID string, competitionID int) (*models.ScoreboardSnapshot, error) {
+	defer sm.lockRefresh(guildID, competitionID)()
+
+	storage, err := sm.storages.ForGuild(guildID)
 	if err != nil {
 		return nil, err
 	}
 
-	// 정렬 및 포맷팅
+	competition := storage.GetCompetition(competitionID)
+	if competition == nil || !competition.IsActive {
+		return nil, fmt.Errorf("활성화된 대회가 없습니다")
+	}
+
+	scores, err := sm.collectScoreData(ctx, guildID, storage, competition, sm.captureDueSnapshots(ctx, storage, competition))
+	if err != nil {
+		return nil, err
+	}
 	sm.sortScores(scores)
-	return sm.formatScoreboard(competition, scores, isAdmin), nil
+
+	snapshot := &models.ScoreboardSnapshot{
+		UpdatedAt: time.Now(),
+		Scores:    scores,
+	}
+
+	sm.snapshotMu.Lock()
+	sm.snapshots[snapshotKey{guildID, competition.ID}] = snapshot
+	sm.snapshotMu.Unlock()
+
+	utils.Debug("길드 %s 대회 %d 스코어보드 스냅샷 갱신 완료 (%d명)", guildID, competition.ID, len(scores))
+	return snapshot, nil
+}
+
+// captureDueSnapshots 대회가 시작되었으면 시작 시점 기록을 기다리는 참가자의 기록을 남기고 참가자 목록을 반환합니다.
+// 기록에 실패한 참가자는 다음 갱신 때 다시 시도합니다.
+func (sm *ScoreboardManager) captureDueSnapshots(ctx context.Context, storage interfaces.StorageRepository, competition *models.Competition) []models.Participant {
+	participants := storage.GetParticipants(competition.ID)
+	if time.Now().Before(competition.StartDate) {
+		return participants
+	}
+
+	captured := 0
+	for _, p := range participants {
+		if !p.SnapshotPending {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if err := storage.CaptureStartSnapshot(ctx, competition.ID, p.ID); err != nil {
+			utils.Warn("참가자 %s 시작 시점 기록 실패: %v", p.BaekjoonID, err)
+			continue
+		}
+		captured++
+	}
+	if captured == 0 {
+		return participants
+	}
+
+	utils.Info("대회 %d 참가자 %d명의 시작 시점 기록을 남겼습니다", competition.ID, captured)
+	return storage.GetParticipants(competition.ID)
+}
+
+// ActiveCompetitions 길드에서 점수를 계산할 활성 대회 목록을 반환합니다
+func (sm *ScoreboardManager) ActiveCompetitions(guildID string) []*models.Competition {
+	storage, err := sm.storages.ForGuild(guildID)
+	if err != nil {
+		utils.Warn("길드 %s 저장소를 열 수 없습니다: %v", guildID, err)
+		return nil
+	}
+
+	var active []*models.Competition
+	for _, c := range storage.GetCompetitions() {
+		if c.IsActive {
+			active = append(active, c)
+		}
+	}
+	return active
+}
+
+// LatestSnapshot 대회의 가장 최근 스코어보드 스냅샷을 반환합니다 (없으면 nil)
+func (sm *ScoreboardManager) LatestSnapshot(guildID string, competitionID int) *models.ScoreboardSnapshot {
+	sm.snapshotMu.RLock()
+	defer sm.snapshotMu.RUnlock()
+	return sm.snapshots[snapshotKey{guildID, competitionID}]
+}
+
+// InvalidateSnapshot 참가자 변경 등으로 대회 스냅샷이 더 이상 유효하지 않을 때 호출합니다
+func (sm *ScoreboardManager) InvalidateSnapshot(guildID string, competitionID int) {
+	sm.snapshotMu.Lock()
+	delete(sm.snapshots, snapshotKey{guildID, competitionID})
+	sm.snapshotMu.Unlock()
+}
+
+// InvalidateGuildSnapshots 백업 복원 등으로 길드 데이터 전체가 바뀌었을 때 모든 대회 스냅샷을 버립니다
+func (sm *ScoreboardManager) InvalidateGuildSnapshots(guildID string) {
+	sm.snapshotMu.Lock()
+	for key := range sm.snapshots {
+		if key.guildID == guildID {
+			delete(sm.snapshots, key)
+		}
+	}
+	sm.snapshotMu.Unlock()
 }
 
 // checkBlackoutPeriod 블랙아웃 기간인지 확인하고 해당 embed 반환
-func (sm *ScoreboardManager) checkBlackoutPeriod(competition *models.Competition, isAdmin bool) *discordgo.MessageEmbed {
-	if sm.storage.IsBlackoutPeriod() && competition.ShowScoreboard && !isAdmin {
+func (sm *ScoreboardManager) checkBlackoutPeriod(storage interfaces.StorageRepository, competition *models.Competition, isAdmin bool) *discordgo.MessageEmbed {
+	if storage.IsBlackoutPeriod(competition.ID) && competition.ShowScoreboard && !isAdmin {
 		tm := models.NewTierManager()
 		return &discordgo.MessageEmbed{
 			Title:       "🔒 스코어보드 비공개",
@@ -82,14 +237,24 @@ func (sm *ScoreboardManager) checkEmptyParticipants(competition *models.Competit
 	return nil
 }
 
-// collectScoreData 참가자들의 점수 데이터를 병렬로 수집합니다
-func (sm *ScoreboardManager) collectScoreData(participants []models.Participant) ([]models.ScoreData, error) {
+// participantResult 참가자 한 명의 점수 계산 결과와 새로 확인된 풀이 기록입니다
+type participantResult struct {
+	score  models.ScoreData
+	solves []models.SolveRecord
+}
+
+// collectScoreData 참가자들의 점수 데이터를 병렬로 수집하고 풀이 기록을 갱신합니다.
+// ctx가 취소되면 남은 참가자는 계산하지 않고 ctx의 오류를 반환합니다.
+func (sm *ScoreboardManager) collectScoreData(ctx context.Context, guildID string, storage interfaces.StorageRepository, competition *models.Competition, participants []models.Participant) ([]models.ScoreData, error) {
 	if len(participants) == 0 {
 		return []models.ScoreData{}, nil
 	}
+	key := snapshotKey{guildID, competition.ID}
+	profile := competition.ScoringRules()
+	seenAt := solveSeenAt(competition, time.Now())
 
 	// 병렬 처리를 위한 채널과 대기 그룹
-	scoreChan := make(chan models.ScoreData, len(participants))
+	resultChan := make(chan participantResult, len(participants))
 	errorChan := make(chan error, len(participants))
 	semaphore := make(chan struct{}, constants.MaxConcurrentRequests)
 	var wg sync.WaitGroup
@@ -101,68 +266,180 @@ func (sm *ScoreboardManager) collectScoreData(participants []models.Participant)
 			defer wg.Done()
 			
 			// 동시 요청 수 제한
-			semaphore <- struct{}{}
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
 			defer func() { <-semaphore }()
 
-			scoreData, err := sm.calculateParticipantScore(p)
+			result, err := sm.calculateParticipantScore(ctx, competition.ID, p, profile, seenAt, !sm.hasPolled(key, p))
 			if err != nil {
-				utils.Warn("참가자 %s 점수 계산 실패: %v", p.Name, err)
+				if ctx.Err() == nil {
+					utils.Warn("참가자 %s 점수 계산 실패: %v", p.Name, err)
+				}
 				errorChan <- err
 				return
 			}
-			scoreChan <- scoreData
+			resultChan <- result
 		}(participant)
 	}
 
 	// 고루틴들이 완료될 때까지 대기
 	wg.Wait()
-	close(scoreChan)
+	close(resultChan)
 	close(errorChan)
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// 결과 수집
 	var scores []models.ScoreData
-	for score := range scoreChan {
-		scores = append(scores, score)
+	var solves []models.SolveRecord
+	for result := range resultChan {
+		scores = append(scores, result.score)
+		solves = append(solves, result.solves...)
+	}
+
+	if _, err := storage.RecordSolves(solves); err != nil {
+		utils.Warn("풀이 기록 저장 실패: %v", err)
+	} else {
+		sm.markPolled(key, scores)
 	}
 
 	utils.Info("참가자 %d명 중 %d명의 점수를 성공적으로 계산했습니다", len(participants), len(scores))
 	return scores, nil
 }
 
-// calculateParticipantScore 개별 참가자의 점수를 계산합니다
-func (sm *ScoreboardManager) calculateParticipantScore(participant models.Participant) (models.ScoreData, error) {
-	userInfo, err := sm.client.GetUserInfo(participant.BaekjoonID)
+// ScoreBreakdown 참가자의 현재 해결 문제 목록으로 대회 점수의 문제별 계산 내역을 만듭니다
+func (sm *ScoreboardManager) ScoreBreakdown(ctx context.Context, participant models.Participant, profile models.ScoringProfile) (models.ScoreBreakdown, error) {
+	solved, err := sm.client.GetUserSolvedProblems(ctx, participant.BaekjoonID)
 	if err != nil {
-		return models.ScoreData{}, err
+		return models.ScoreBreakdown{}, err
 	}
+	return sm.calculator.BreakdownFromProblems(solved.Items, participant.StartTier, participant.StartProblemIDs, profile), nil
+}
 
-	score, err := sm.calculator.CalculateScore(participant.BaekjoonID, participant.StartTier, participant.StartProblemIDs)
+// calculateParticipantScore 개별 참가자의 점수를 대회의 점수 계산 규칙으로 계산합니다.
+// 새로 확인된 풀이는 seenAt에 처음 확인한 것으로 기록하고, backlog이면 해결 시각을 알 수 없는 기록으로 남깁니다.
+func (sm *ScoreboardManager) calculateParticipantScore(ctx context.Context, competitionID int, participant models.Participant, profile models.ScoringProfile, seenAt time.Time, backlog bool) (participantResult, error) {
+	userInfo, err := sm.client.GetUserInfo(ctx, participant.BaekjoonID)
 	if err != nil {
-		return models.ScoreData{}, err
+		return participantResult{}, err
 	}
 
-	top100, err := sm.client.GetUserTop100(participant.BaekjoonID)
+	// 시작 시점 기록 전에는 점수와 풀이 기록을 남기지 않습니다
+	if participant.SnapshotPending {
+		return participantResult{
+			score: models.ScoreData{
+				ParticipantID: participant.ID,
+				Name:          participant.Name,
+				BaekjoonID:    participant.BaekjoonID,
+				CurrentTier:   userInfo.Tier,
+				CurrentRating: userInfo.Rating,
+			},
+		}, nil
+	}
+
+	solved, err := sm.client.GetUserSolvedProblems(ctx, participant.BaekjoonID)
 	if err != nil {
-		return models.ScoreData{}, err
+		return participantResult{}, err
 	}
 
+	score := sm.calculator.CalculateScoreFromProblems(solved.Items, participant.StartTier, participant.StartProblemIDs, profile)
+
 	// 새로 푼 문제 수 계산 (현재 - 시작시점)
-	newProblemCount := top100.Count - participant.StartProblemCount
+	newProblemCount := userInfo.SolvedCount - participant.StartProblemCount
 	if newProblemCount < 0 {
 		newProblemCount = 0
 	}
 
-	return models.ScoreData{
-		ParticipantID: participant.ID,
-		Name:          participant.Name,
-		BaekjoonID:    participant.BaekjoonID,
-		Score:         score,
-		CurrentTier:   userInfo.Tier,
-		CurrentRating: userInfo.Rating,
-		ProblemCount:  newProblemCount,
+	return participantResult{
+		score: models.ScoreData{
+			ParticipantID: participant.ID,
+			Name:          participant.Name,
+			BaekjoonID:    participant.BaekjoonID,
+			Score:         score,
+			CurrentTier:   userInfo.Tier,
+			CurrentRating: userInfo.Rating,
+			ProblemCount:  newProblemCount,
+		},
+		solves: newSolveRecords(competitionID, participant, solved.Items, seenAt, backlog),
 	}, nil
 }
 
+// solveSeenAt now에 확인한 풀이를 기록할 시각을 반환합니다.
+// 종료 시각이 지났지만 아직 확정되지 않은 대회에서 확인한 풀이는 종료 시점의 상태로 보고 종료 시각으로 기록합니다.
+// 마지막 점수 갱신 뒤에 푼 문제도 확정할 때 조회해 최종 점수에 반영하기 위함입니다.
+func solveSeenAt(competition *models.Competition, now time.Time) time.Time {
+	if !competition.EndDate.IsZero() && now.After(competition.EndDate) {
+		return competition.EndDate
+	}
+	return now
+}
+
+// newSolveRecords 시작 시점 이후 해결한 문제들을 대회의 풀이 기록 후보로 변환합니다
+func newSolveRecords(competitionID int, participant models.Participant, problems []api.ProblemInfo, seenAt time.Time, backlog bool) []models.SolveRecord {
+	startProblems := startProblemSet(participant)
+
+	var records []models.SolveRecord
+	for _, problem := range problems {
+		if startProblems[problem.ProblemID] {
+			continue
+		}
+		records = append(records, models.SolveRecord{
+			CompetitionID: competitionID,
+			ParticipantID: participant.ID,
+			BaekjoonID:    participant.BaekjoonID,
+			ProblemID:     problem.ProblemID,
+			Level:         problem.Level,
+			FirstSeenAt:   seenAt,
+			Backlog:       backlog,
+		})
+	}
+	return records
+}
+
+// lockRefresh 대회의 갱신 잠금을 잡고 해제 함수를 반환합니다. 다른 길드나 대회의 작업은 기다리지 않습니다
+func (sm *ScoreboardManager) lockRefresh(guildID string, competitionID int) func() {
+	key := snapshotKey{guildID, competitionID}
+
+	sm.refreshMu.Lock()
+	lock, exists := sm.refreshLocks[key]
+	if !exists {
+		lock = &sync.Mutex{}
+		sm.refreshLocks[key] = lock
+	}
+	sm.refreshMu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// hasPolled 참가자의 풀이를 이전에 확인했는지 반환합니다. 관리자가 만들어진 뒤 등록한 참가자는
+// 등록할 때 시작 기록을 남겼으므로 확인한 것으로 봅니다.
+func (sm *ScoreboardManager) hasPolled(key snapshotKey, participant models.Participant) bool {
+	sm.pollMu.Lock()
+	defer sm.pollMu.Unlock()
+	return sm.polled[key][participant.ID] || !participant.CreatedAt.Before(sm.startedAt)
+}
+
+// markPolled 점수를 계산한 참가자들의 풀이를 확인했다고 기록합니다
+func (sm *ScoreboardManager) markPolled(key snapshotKey, scores []models.ScoreData) {
+	sm.pollMu.Lock()
+	defer sm.pollMu.Unlock()
+
+	polled, exists := sm.polled[key]
+	if !exists {
+		polled = make(map[int]bool)
+		sm.polled[key] = polled
+	}
+	for _, score := range scores {
+		polled[score.ParticipantID] = true
+	}
+}
+
 // sortScores 점수 데이터를 정렬합니다
 func (sm *ScoreboardManager) sortScores(scores []models.ScoreData) {
 	sort.Slice(scores, func(i, j int) bool {
@@ -170,13 +447,17 @@ func (sm *ScoreboardManager) sortScores(scores []models.ScoreData) {
 	})
 }
 
-func (sm *ScoreboardManager) formatScoreboard(competition *models.Competition, scores []models.ScoreData, isAdmin bool) *discordgo.MessageEmbed {
+func (sm *ScoreboardManager) formatScoreboard(competition *models.Competition, snapshot *models.ScoreboardSnapshot, isAdmin bool) *discordgo.MessageEmbed {
+	scores := snapshot.Scores
 	embed := &discordgo.MessageEmbed{
 		Title: fmt.Sprintf("🏆 %s 스코어보드", competition.Name),
 		Description: fmt.Sprintf("%s ~ %s",
 			competition.StartDate.Format(constants.DateFormat),
 			competition.EndDate.Format(constants.DateFormat)),
 		Color: constants.ColorTierGold,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("%s 마지막 업데이트: %s", constants.EmojiClock, utils.FormatDateTime(snapshot.UpdatedAt)),
+		},
 	}
 
 	if len(scores) == 0 {
@@ -184,44 +465,53 @@ func (sm *ScoreboardManager) formatScoreboard(competition *models.Competition, s
 		return embed
 	}
 
+	embed.Description += "\n\n" + formatScoreTable(scores)
+
+	// 블랙아웃 경고 추가
+	now := time.Now()
+	if now.Before(competition.BlackoutStartDate) {
+		daysLeft := int(competition.BlackoutStartDate.Sub(now).Hours() / 24)
+		embed.Footer.Text += fmt.Sprintf(" · ⚠️ %d일 후 스코어보드가 비공개됩니다", daysLeft)
+	}
+
+	return embed
+}
+
+// formatScoreTable 순위, 이름, 점수를 고정폭 표로 만듭니다
+func formatScoreTable(scores []models.ScoreData) string {
 	var sb strings.Builder
 	sb.WriteString("```\n")
 	sb.WriteString(fmt.Sprintf("%-*s %-*s %*s\n",
-		constants.ScoreboardRankWidth, "순위", 
-		constants.ScoreboardNameWidth, "이름", 
+		constants.ScoreboardRankWidth, "순위",
+		constants.ScoreboardNameWidth, "이름",
 		constants.ScoreboardScoreWidth, "점수"))
 	sb.WriteString(constants.ScoreboardSeparator + "\n")
 
 	for i, score := range scores {
-		rank := i + 1
 		sb.WriteString(fmt.Sprintf("%-*d %-*s %*.0f\n",
-			constants.ScoreboardRankWidth, rank,
+			constants.ScoreboardRankWidth, i+1,
 			constants.ScoreboardNameWidth, utils.TruncateString(score.Name, constants.ScoreboardNameWidth),
 			constants.ScoreboardScoreWidth, score.Score))
 	}
 
 	sb.WriteString("```")
+	return sb.String()
+}
 
-	embed.Description += "\n\n" + sb.String()
-
-	// 블랙아웃 경고 추가
-	now := time.Now()
-	if now.Before(competition.BlackoutStartDate) {
-		daysLeft := int(competition.BlackoutStartDate.Sub(now).Hours() / 24)
-		embed.Footer = &discordgo.MessageEmbedFooter{
-			Text: fmt.Sprintf("⚠️ %d일 후 스코어보드가 비공개됩니다", daysLeft),
+// SendDailyScoreboard 길드의 활성 대회마다 스코어보드를 채널에 전송합니다
+func (sm *ScoreboardManager) SendDailyScoreboard(ctx context.Context, session errors.MessageSender, guildID, channelID string) error {
+	var lastErr error
+	for _, competition := range sm.ActiveCompetitions(guildID) {
+		embed, err := sm.GenerateScoreboard(ctx, guildID, competition.ID, false) // 자동 스코어보드는 관리자 권한 없음
+		if err != nil {
+			utils.Warn("길드 %s 대회 %d 스코어보드 생성 실패: %v", guildID, competition.ID, err)
+			lastErr = err
+			continue
 		}
-	}
-
-	return embed
-}
 
-func (sm *ScoreboardManager) SendDailyScoreboard(session *discordgo.Session, channelID string) error {
-	embed, err := sm.GenerateScoreboard(false) // 자동 스코어보드는 관리자 권한 없음
-	if err != nil {
-		return err
+		if _, err := session.ChannelMessageSendEmbed(channelID, embed); err != nil {
+			lastErr = err
+		}
 	}
-
-	_, err = session.ChannelMessageSendEmbed(channelID, embed)
-	return err
+	return lastErr
 }
@@ -0,0 +1,57 @@
+package bot
+
+import (
+	"context"
+	"discord-bot/api"
+	"discord-bot/models"
+	"discord-bot/scoring"
+	"errors"
+	"testing"
+	"time"
+)
+
+// blockingAPIClient ctx가 취소될 때까지 응답하지 않는 APIClient입니다
+type blockingAPIClient struct{}
+
+func (blockingAPIClient) GetUserInfo(ctx context.Context, handle string) (*api.UserInfo, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (blockingAPIClient) GetUserTop100(ctx context.Context, handle string) (*api.Top100Response, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (blockingAPIClient) GetUserSolvedProblems(ctx context.Context, handle string) (*api.SolvedProblemsResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestCollectScoreDataStopsOnCancel(t *testing.T) {
+	client := blockingAPIClient{}
+	sm := NewScoreboardManager(nil, scoring.NewScoreCalculator(client), client)
+
+	participants := make([]models.Participant, 10)
+	for i := range participants {
+		participants[i] = models.Participant{ID: i + 1, Name: "참가자", BaekjoonID: "user"}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := sm.collectScoreData(ctx, "guild", nil, &models.Competition{ID: 1}, participants)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the deadline error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("collectScoreData did not return after the context was canceled")
+	}
+}
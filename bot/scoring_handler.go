@@ -0,0 +1,184 @@
+package bot
+
+import (
+	"discord-bot/errors"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// scoringFields `!점수규칙 설정`으로 바꿀 수 있는 항목입니다
+var scoringFields = []string{"bands", "problem_cap", "total_cap", "points"}
+
+// handleScoringShow 대회의 점수 계산 규칙을 보여줍니다
+func (ch *CommandHandler) handleScoringShow(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	if _, err := s.ChannelMessageSend(m.ChannelID, formatScoringProfile(competition)); err != nil {
+		utils.Error("점수 규칙 메시지 전송 실패: %v", err)
+	}
+}
+
+// handleScoringSet 대회의 점수 계산 규칙 중 한 항목을 변경합니다
+func (ch *CommandHandler) handleScoringSet(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	profile := competition.ScoringRules()
+	if err := applyScoringField(&profile, args.String("field"), args.String("value")); err != nil {
+		errorHandlers.Validation().HandleInvalidParams("SCORING_INVALID_VALUE",
+			fmt.Sprintf("Invalid scoring value: %v", err),
+			fmt.Sprintf("%v\n예시: `!점수규칙 설정 bands ..-3=0.5 -2..2=1 3..=1.4`, `!점수규칙 설정 points 11 20`", err))
+		return
+	}
+
+	ch.saveScoringProfile(s, m, g, competition, profile)
+}
+
+// handleScoringReset 대회의 점수 계산 규칙을 기본값으로 되돌립니다
+func (ch *CommandHandler) handleScoringReset(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	ch.saveScoringProfile(s, m, g, competition, models.DefaultScoringProfile())
+}
+
+// saveScoringProfile 점수 계산 규칙을 저장하고 다음 조회 때 점수를 다시 계산하도록 스냅샷을 비웁니다
+func (ch *CommandHandler) saveScoringProfile(s *commandSession, m *discordgo.MessageCreate, g *guildScope, competition *models.Competition, profile models.ScoringProfile) {
+	if err := g.storage.UpdateCompetitionScoring(competition.ID, profile); err != nil {
+		botErr := errors.NewSystemError("SCORING_UPDATE_FAILED",
+			"Failed to update competition scoring profile", err)
+		botErr.UserMsg = "점수 규칙 변경에 실패했습니다: " + err.Error()
+		errors.HandleDiscordError(s, m.ChannelID, botErr)
+		return
+	}
+	ch.scoreboardManager.InvalidateSnapshot(g.guildID, competition.ID)
+
+	competition.Scoring = &profile
+	errors.SendDiscordSuccess(s, m.ChannelID, "점수 규칙이 변경되었습니다. 다음 스코어보드부터 적용됩니다.")
+	if _, err := s.ChannelMessageSend(m.ChannelID, formatScoringProfile(competition)); err != nil {
+		utils.Error("점수 규칙 메시지 전송 실패: %v", err)
+	}
+}
+
+// applyScoringField 입력된 항목과 값을 점수 계산 규칙에 반영하고 검증합니다
+func applyScoringField(profile *models.ScoringProfile, field, value string) error {
+	switch field {
+	case "bands":
+		var bands []models.WeightBand
+		for _, text := range strings.Fields(value) {
+			band, err := models.ParseWeightBand(text)
+			if err != nil {
+				return err
+			}
+			bands = append(bands, band)
+		}
+		profile.SetWeightBands(bands)
+		return profile.Validate()
+	case "points":
+		parts := strings.Fields(value)
+		if len(parts) != 2 {
+			return fmt.Errorf("points 값은 `<티어> <점수>` 형식으로 입력하세요")
+		}
+		tier, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return fmt.Errorf("티어는 숫자로 입력하세요 (1=Bronze V, %d=Master)", models.MaxTierLevel)
+		}
+		points, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return fmt.Errorf("점수는 정수로 입력하세요")
+		}
+		if profile.TierPoints == nil {
+			profile.TierPoints = make(map[int]int)
+		}
+		profile.TierPoints[tier] = points
+		return profile.Validate()
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("%s 값은 숫자로 입력하세요", field)
+	}
+
+	switch field {
+	case "problem_cap":
+		profile.MaxProblemPoints = number
+	case "total_cap":
+		profile.MaxTotalScore = number
+	default:
+		return fmt.Errorf("알 수 없는 항목입니다: %s", field)
+	}
+	return profile.Validate()
+}
+
+// formatScoringProfile 점수 계산 규칙을 메시지로 만듭니다. 기본 점수표와 다른 티어에는 *를 붙입니다
+func formatScoringProfile(competition *models.Competition) string {
+	profile := competition.ScoringRules()
+	tm := models.NewTierManager()
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📐 **%s** (`#%d`) 점수 계산 규칙\n", competition.Name, competition.ID))
+	sb.WriteString("• 가중치 (문제 티어 - 시작 티어): " + formatWeightBands(profile.WeightBands) + "\n")
+	sb.WriteString(fmt.Sprintf("• 문제당 상한: %s / 총점 상한: %s\n",
+		formatScoreCap(profile.MaxProblemPoints), formatScoreCap(profile.MaxTotalScore)))
+
+	sb.WriteString("```\n")
+	for tier := 1; tier <= models.MaxTierLevel; tier++ {
+		marker := " "
+		if _, overridden := profile.TierPoints[tier]; overridden {
+			marker = "*"
+		}
+		sb.WriteString(fmt.Sprintf("%2d %-13s %3d%s", tier, tm.GetTierName(tier), profile.PointsFor(tm, tier), marker))
+		if tier%2 == 0 || tier == models.MaxTierLevel {
+			sb.WriteString("\n")
+		} else {
+			sb.WriteString("   ")
+		}
+	}
+	sb.WriteString("```")
+	return sb.String()
+}
+
+func formatScoreCap(limit float64) string {
+	if limit <= 0 {
+		return "없음"
+	}
+	return fmt.Sprintf("%g점", limit)
+}
+
+// formatWeightBands 가중치 구간을 `..-1=0.5 (연습), 0=1 (기본)` 형식으로 나열합니다
+func formatWeightBands(bands []models.WeightBand) string {
+	parts := make([]string, len(bands))
+	for i, band := range bands {
+		parts[i] = fmt.Sprintf("`%s` (%s)", band, band.Category())
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,22 @@
+package bot
+
+import (
+	"context"
+	"discord-bot/interfaces"
+)
+
+// commandSession 명령 응답을 보낼 세션입니다.
+// 슬래시 명령이면 Messenger가 채널 메시지 대신 상호작용의 후속 메시지로 응답하므로
+// 같은 핸들러를 프리픽스 명령과 슬래시 명령에 그대로 사용할 수 있습니다.
+type commandSession struct {
+	interfaces.Messenger
+	ctx context.Context // 명령 처리 기한이 걸린 context (dispatch에서 설정)
+}
+
+// Context 명령 처리에 쓸 context를 반환합니다. 명령 기한이 지나거나 봇이 종료되면 취소됩니다
+func (cs *commandSession) Context() context.Context {
+	if cs.ctx == nil {
+		return context.Background()
+	}
+	return cs.ctx
+}
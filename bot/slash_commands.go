@@ -0,0 +1,304 @@
+package bot
+
+import (
+	"discord-bot/interfaces"
+	"discord-bot/utils"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// 슬래시 명령 옵션 이름
+const (
+	optionCompetition = "competition"
+	optionBaekjoonID  = "baekjoon_id"
+)
+
+// maxAutocompleteChoices 디스코드가 허용하는 자동완성 후보 최대 개수입니다
+const maxAutocompleteChoices = 25
+
+var (
+	adminPermission  int64 = discordgo.PermissionAdministrator
+	minCompetitionID       = 1.0
+)
+
+func ko(text string) *map[discordgo.Locale]string {
+	return &map[discordgo.Locale]string{discordgo.Korean: text}
+}
+
+func competitionOption() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:              discordgo.ApplicationCommandOptionInteger,
+		Name:              optionCompetition,
+		NameLocalizations: *ko("대회"),
+		Description:       "대상 대회 ID (생략하면 진행 중인 대회)",
+		MinValue:          &minCompetitionID,
+	}
+}
+
+// SlashCommandDefinitions 등록된 명령으로 디스코드에 등록할 슬래시 명령 정의를 만듭니다
+func (ch *CommandHandler) SlashCommandDefinitions() []*discordgo.ApplicationCommand {
+	definitions := make([]*discordgo.ApplicationCommand, len(ch.commands.commands))
+	for i, cmd := range ch.commands.commands {
+		definitions[i] = slashDefinition(cmd)
+	}
+	return definitions
+}
+
+// slashDefinition 명령 선언을 슬래시 명령 정의로 변환합니다.
+// 하위 명령은 슬래시 하위 명령이 되고, 관리자 명령은 기본적으로 관리자에게만 보입니다.
+func slashDefinition(cmd *commandSpec) *discordgo.ApplicationCommand {
+	definition := &discordgo.ApplicationCommand{
+		Name:        cmd.name,
+		Description: cmd.summary,
+		Options:     slashOptions(cmd),
+	}
+	if cmd.koName != "" {
+		definition.NameLocalizations = ko(cmd.koName)
+	}
+	if cmd.permission != permissionEveryone {
+		definition.DefaultMemberPermissions = &adminPermission
+	}
+	if cmd.scope == scopeGuildOnly {
+		dmPermission := false
+		definition.DMPermission = &dmPermission
+	}
+	return definition
+}
+
+// slashOptions 명령 인자를 선언 순서대로 슬래시 옵션으로 변환합니다. 대회 선택 옵션은 마지막에 붙습니다
+func slashOptions(cmd *commandSpec) []*discordgo.ApplicationCommandOption {
+	var options []*discordgo.ApplicationCommandOption
+	for _, sub := range cmd.subcommands {
+		option := &discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        sub.name,
+			Description: sub.summary,
+			Options:     slashOptions(sub),
+		}
+		if sub.koName != "" {
+			option.NameLocalizations = *ko(sub.koName)
+		}
+		options = append(options, option)
+	}
+
+	for _, arg := range cmd.args {
+		options = append(options, slashOption(arg))
+	}
+	if cmd.competition {
+		options = append(options, competitionOption())
+	}
+	return options
+}
+
+func slashOption(arg argSpec) *discordgo.ApplicationCommandOption {
+	option := &discordgo.ApplicationCommandOption{
+		Type:              discordgo.ApplicationCommandOptionString,
+		Name:              arg.name,
+		NameLocalizations: *ko(strings.ToLower(arg.label)),
+		Description:       arg.description,
+		Required:          !arg.optional,
+		Autocomplete:      arg.autocomplete,
+	}
+
+	switch arg.kind {
+	case argInteger:
+		minValue := float64(arg.min)
+		option.Type = discordgo.ApplicationCommandOptionInteger
+		option.MinValue = &minValue
+		option.MaxValue = float64(arg.max)
+	case argChoice:
+		for _, choice := range arg.choices {
+			option.Choices = append(option.Choices, &discordgo.ApplicationCommandOptionChoice{Name: choice, Value: choice})
+		}
+	}
+	return option
+}
+
+// RegisterSlashCommands 슬래시 명령을 전역 명령으로 등록합니다 (기존 정의는 덮어씁니다)
+func (ch *CommandHandler) RegisterSlashCommands(s *discordgo.Session) error {
+	registered, err := s.ApplicationCommandBulkOverwrite(s.State.User.ID, "", ch.SlashCommandDefinitions())
+	if err != nil {
+		return fmt.Errorf("슬래시 명령 등록 실패: %w", err)
+	}
+	utils.Info("Registered %d slash commands", len(registered))
+	return nil
+}
+
+// HandleInteraction 슬래시 명령과 자동완성 요청을 처리합니다
+func (ch *CommandHandler) HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		ch.handleSlashCommand(s, i)
+	case discordgo.InteractionApplicationCommandAutocomplete:
+		ch.handleAutocomplete(s, i)
+	}
+}
+
+// handleSlashCommand 응답을 지연시킨 뒤 프리픽스 명령과 같은 경로로 검증하고 실행합니다.
+// 점수 계산처럼 3초 이상 걸리는 명령이 있으므로 항상 지연 응답을 먼저 보냅니다.
+func (ch *CommandHandler) handleSlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	cmd := ch.commands.find(data.Name)
+	if cmd == nil {
+		utils.Warn("Unknown slash command: %s", data.Name)
+		return
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+	if err != nil {
+		utils.Error("슬래시 명령 지연 응답 실패: %v", err)
+		return
+	}
+
+	params, selector := slashParams(cmd, data.Options)
+	session := &commandSession{Messenger: newInteractionMessenger(s, i.Interaction)}
+	ch.dispatch(session, interactionMessage(i), cmd, params, selector)
+}
+
+// slashParams 슬래시 명령 옵션을 선언된 인자 순서의 매개변수와 대회 선택자로 변환합니다.
+// 하위 명령은 첫 번째 매개변수가 되고, 선택 인자가 비어 있으면 그 뒤의 인자는 생략됩니다.
+func slashParams(cmd *commandSpec, given []*discordgo.ApplicationCommandInteractionDataOption) ([]string, int) {
+	values := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(given))
+	for _, option := range given {
+		if option.Type == discordgo.ApplicationCommandOptionSubCommand {
+			sub := cmd.findSubcommand(option.Name)
+			if sub == nil {
+				return []string{option.Name}, 0
+			}
+			params, selector := slashParams(sub, option.Options)
+			return append([]string{sub.name}, params...), selector
+		}
+		values[option.Name] = option
+	}
+
+	selector := 0
+	if value, ok := values[optionCompetition]; ok {
+		selector = int(value.IntValue())
+	}
+
+	var params []string
+	for _, arg := range cmd.args {
+		value, ok := values[arg.name]
+		if !ok {
+			break
+		}
+		params = append(params, optionString(value))
+	}
+	return params, selector
+}
+
+func optionString(option *discordgo.ApplicationCommandInteractionDataOption) string {
+	switch option.Type {
+	case discordgo.ApplicationCommandOptionInteger:
+		return strconv.FormatInt(option.IntValue(), 10)
+	case discordgo.ApplicationCommandOptionBoolean:
+		return strconv.FormatBool(option.BoolValue())
+	default:
+		return strings.TrimSpace(option.StringValue())
+	}
+}
+
+// interactionMessage 상호작용을 프리픽스 명령 핸들러가 사용하는 메시지 형태로 바꿉니다
+func interactionMessage(i *discordgo.InteractionCreate) *discordgo.MessageCreate {
+	author := i.User
+	if i.Member != nil {
+		author = i.Member.User
+	}
+
+	return &discordgo.MessageCreate{Message: &discordgo.Message{
+		ID:        i.ID,
+		ChannelID: i.ChannelID,
+		GuildID:   i.GuildID,
+		Author:    author,
+		Member:    i.Member,
+	}}
+}
+
+// handleAutocomplete 백준 ID 옵션에 대회 참가자의 백준 ID를 제안합니다
+func (ch *CommandHandler) handleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	options := leafOptions(i.ApplicationCommandData().Options)
+
+	var focused *discordgo.ApplicationCommandInteractionDataOption
+	selector := 0
+	for _, option := range options {
+		if option.Focused {
+			focused = option
+		}
+		if option.Name == optionCompetition && option.Value != nil {
+			selector = int(option.IntValue())
+		}
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	if focused != nil && focused.Name == optionBaekjoonID {
+		choices = ch.participantChoices(newDiscordMessenger(s), i.GuildID, selector, focused.StringValue())
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{Choices: choices},
+	})
+	if err != nil {
+		utils.Warn("자동완성 응답 실패: %v", err)
+	}
+}
+
+// leafOptions 하위 명령 안의 옵션까지 펼쳐서 반환합니다
+func leafOptions(options []*discordgo.ApplicationCommandInteractionDataOption) []*discordgo.ApplicationCommandInteractionDataOption {
+	var leaves []*discordgo.ApplicationCommandInteractionDataOption
+	for _, option := range options {
+		if option.Type == discordgo.ApplicationCommandOptionSubCommand || option.Type == discordgo.ApplicationCommandOptionSubCommandGroup {
+			leaves = append(leaves, leafOptions(option.Options)...)
+			continue
+		}
+		leaves = append(leaves, option)
+	}
+	return leaves
+}
+
+// participantChoices 입력 중인 값으로 시작하는 참가자 백준 ID 목록을 만듭니다.
+// 자동완성은 오류 메시지를 보낼 수 없으므로 대상을 정할 수 없으면 빈 목록을 반환합니다.
+func (ch *CommandHandler) participantChoices(messenger interfaces.Messenger, guildID string, selector int, prefix string) []*discordgo.ApplicationCommandOptionChoice {
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+
+	if guildID == "" {
+		guildID = soleGuildID(messenger)
+	}
+	if guildID == "" {
+		return choices
+	}
+
+	storage, err := ch.storages.ForGuild(guildID)
+	if err != nil {
+		return choices
+	}
+
+	competition := storage.GetCompetition(selector)
+	if selector == 0 {
+		competition, _ = defaultCompetition(storage.GetCompetitions(), time.Now())
+	}
+	if competition == nil {
+		return choices
+	}
+
+	prefix = strings.ToLower(prefix)
+	for _, p := range competition.Participants {
+		if !strings.HasPrefix(strings.ToLower(p.BaekjoonID), prefix) {
+			continue
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  fmt.Sprintf("%s (%s)", p.BaekjoonID, p.Name),
+			Value: p.BaekjoonID,
+		})
+		if len(choices) >= maxAutocompleteChoices {
+			break
+		}
+	}
+	return choices
+}
@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func stringValue(name, value string) *discordgo.ApplicationCommandInteractionDataOption {
+	return &discordgo.ApplicationCommandInteractionDataOption{Name: name, Type: discordgo.ApplicationCommandOptionString, Value: value}
+}
+
+func intValue(name string, value int) *discordgo.ApplicationCommandInteractionDataOption {
+	return &discordgo.ApplicationCommandInteractionDataOption{Name: name, Type: discordgo.ApplicationCommandOptionInteger, Value: float64(value)}
+}
+
+func TestSlashParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		given    []*discordgo.ApplicationCommandInteractionDataOption
+		want     []string
+		selector int
+	}{
+		{
+			name:    "positional options keep definition order",
+			command: "register",
+			given:   []*discordgo.ApplicationCommandInteractionDataOption{stringValue("baekjoon_id", "koosaga"), stringValue("name", "홍길동")},
+			want:    []string{"홍길동", "koosaga"},
+		},
+		{
+			name:     "competition option becomes selector",
+			command:  "history",
+			given:    []*discordgo.ApplicationCommandInteractionDataOption{intValue("competition", 2), stringValue("baekjoon_id", "koosaga")},
+			want:     []string{"koosaga"},
+			selector: 2,
+		},
+		{
+			name:    "optional option may be omitted",
+			command: "ranking",
+			given:   nil,
+			want:    nil,
+		},
+		{
+			name:    "subcommand becomes first parameter",
+			command: "competition",
+			given: []*discordgo.ApplicationCommandInteractionDataOption{{
+				Name: "update",
+				Type: discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					stringValue("value", "새 대회"), stringValue("field", "name"), intValue("competition", 3),
+				},
+			}},
+			want:     []string{"update", "name", "새 대회"},
+			selector: 3,
+		},
+	}
+
+	registry := newCommandRegistry(defaultCommands())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := registry.find(tt.command)
+			if cmd == nil {
+				t.Fatalf("command %q not registered", tt.command)
+			}
+			got, selector := slashParams(cmd, tt.given)
+			if !reflect.DeepEqual(got, tt.want) || selector != tt.selector {
+				t.Fatalf("slashParams = %q #%d, want %q #%d", got, selector, tt.want, tt.selector)
+			}
+		})
+	}
+}
+
+func TestSlashDefinitions(t *testing.T) {
+	for _, cmd := range defaultCommands() {
+		definition := slashDefinition(cmd)
+		if definition.Description == "" || len([]rune(definition.Description)) > 100 {
+			t.Errorf("%s: description must be 1-100 characters, got %q", cmd.name, definition.Description)
+		}
+		if cmd.permission != permissionEveryone && definition.DefaultMemberPermissions == nil {
+			t.Errorf("%s: admin command must require permissions by default", cmd.name)
+		}
+		for _, option := range definition.Options {
+			if option.Name == optionCompetition && !cmd.competition {
+				t.Errorf("%s: unexpected competition option", cmd.name)
+			}
+			checkSlashNames(t, cmd.name, option)
+		}
+	}
+}
+
+// slashNamePattern 디스코드가 허용하는 명령/옵션 이름 형식입니다
+var slashNamePattern = regexp.MustCompile(`^[-_\p{L}\p{N}]{1,32}$`)
+
+// checkSlashNames 옵션과 하위 옵션의 이름, 한국어 이름이 디스코드 규칙에 맞는지 확인합니다
+func checkSlashNames(t *testing.T, command string, option *discordgo.ApplicationCommandOption) {
+	t.Helper()
+	names := []string{option.Name}
+	for _, name := range option.NameLocalizations {
+		names = append(names, name)
+	}
+	for _, name := range names {
+		if !slashNamePattern.MatchString(name) || strings.ToLower(name) != name {
+			t.Errorf("%s: invalid slash option name %q", command, name)
+		}
+	}
+	for _, sub := range option.Options {
+		checkSlashNames(t, command, sub)
+	}
+}
@@ -0,0 +1,219 @@
+package bot
+
+import (
+	"context"
+	"discord-bot/constants"
+	"discord-bot/errors"
+	"discord-bot/interfaces"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// CaptureStartSnapshots 시작 시각이 지난 대회에서 시작 기록을 기다리는 참가자의 기록을 남깁니다.
+// 아직 시작하지 않았고 기록을 기다리는 참가자가 있는 대회 중 가장 이른 시작 시각을 반환합니다 (없으면 zero).
+func (sm *ScoreboardManager) CaptureStartSnapshots(ctx context.Context, guildID string, now time.Time) time.Time {
+	storage, err := sm.storages.ForGuild(guildID)
+	if err != nil {
+		utils.Warn("길드 %s 저장소를 열 수 없습니다: %v", guildID, err)
+		return time.Time{}
+	}
+
+	var next time.Time
+	for _, competition := range sm.ActiveCompetitions(guildID) {
+		if !hasPendingSnapshot(competition.Participants) {
+			continue
+		}
+		if now.Before(competition.StartDate) {
+			if next.IsZero() || competition.StartDate.Before(next) {
+				next = competition.StartDate
+			}
+			continue
+		}
+		unlock := sm.lockRefresh(guildID, competition.ID)
+		sm.captureDueSnapshots(ctx, storage, competition)
+		unlock()
+		sm.InvalidateSnapshot(guildID, competition.ID)
+	}
+	return next
+}
+
+func hasPendingSnapshot(participants []models.Participant) bool {
+	for _, p := range participants {
+		if p.SnapshotPending {
+			return true
+		}
+	}
+	return false
+}
+
+// handleSnapshotStatus 참가자별 시작 시점 기록을 보여줍니다
+func (ch *CommandHandler) handleSnapshotStatus(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	if _, err := s.ChannelMessageSend(m.ChannelID, formatStartSnapshots(competition)); err != nil {
+		utils.Error("시작 기록 메시지 전송 실패: %v", err)
+	}
+}
+
+// handleSnapshotRetake 참가자(생략하면 전원)의 시작 시점 기록을 지금 상태로 다시 남깁니다
+func (ch *CommandHandler) handleSnapshotRetake(s *commandSession, m *discordgo.MessageCreate, args *commandArgs) {
+	errorHandlers := utils.NewErrorHandlerFactory(s, m.ChannelID)
+
+	g, ok := ch.resolveGuild(s, m)
+	if !ok {
+		return
+	}
+
+	competition, ok := ch.resolveCompetition(s, m, g, args.selector)
+	if !ok {
+		return
+	}
+
+	targets := competition.Participants
+	if baekjoonID := args.String("baekjoon_id"); baekjoonID != "" {
+		participant := findParticipant(g.storage, competition.ID, baekjoonID)
+		if participant == nil {
+			errorHandlers.Data().HandleParticipantNotFound(baekjoonID)
+			return
+		}
+		targets = []models.Participant{*participant}
+	}
+	if len(targets) == 0 {
+		errors.SendDiscordInfo(s, m.ChannelID, fmt.Sprintf("[%s] 대회에 참가자가 없습니다.", competition.Name))
+		return
+	}
+
+	// 참가자가 많으면 명령 기한 안에 끝나도록 동시에 기록하고 진행 상황을 알립니다
+	ctx := s.Context()
+	if len(targets) > constants.SnapshotProgressStep {
+		errors.SendDiscordInfo(s, m.ChannelID, fmt.Sprintf("[%s] 대회 참가자 %d명의 시작 기록을 다시 남기는 중입니다...",
+			competition.Name, len(targets)))
+	}
+
+	var failed []string
+	done := 0
+	for result := range retakeStartSnapshots(ctx, g.storage, competition.ID, targets) {
+		done++
+		if done%constants.SnapshotProgressStep == 0 && done < len(targets) && ctx.Err() == nil {
+			errors.SendDiscordInfo(s, m.ChannelID, fmt.Sprintf("시작 기록 재설정 진행 중: %d/%d명", done, len(targets)))
+		}
+
+		p := result.participant
+		if result.err != nil {
+			utils.Warn("참가자 %s 시작 기록 재설정 실패: %v", p.BaekjoonID, result.err)
+			failed = append(failed, p.BaekjoonID)
+			continue
+		}
+		updated := findParticipant(g.storage, competition.ID, p.BaekjoonID)
+		if updated == nil {
+			continue
+		}
+		ch.recordAudit(g, models.AuditEntry{
+			CompetitionID: competition.ID,
+			ParticipantID: p.ID,
+			Action:        models.AuditResnapshot,
+			ActorID:       m.Author.ID,
+			OldValue:      formatSnapshotSummary(p),
+			NewValue:      formatSnapshotSummary(*updated),
+		})
+	}
+	ch.scoreboardManager.InvalidateSnapshot(g.guildID, competition.ID)
+
+	captured := len(targets) - len(failed)
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		hint := "잠시 후 다시 시도하세요"
+		if ctx.Err() != nil {
+			hint = "처리 기한을 넘겨 남은 참가자는 기록하지 못했습니다. 실패한 참가자만 다시 시도하세요"
+		}
+		errors.SendDiscordWarning(s, m.ChannelID, fmt.Sprintf("%d명의 시작 기록을 다시 남겼습니다. 실패: %s (%s)",
+			captured, strings.Join(failed, ", "), hint))
+		return
+	}
+	errors.SendDiscordSuccess(s, m.ChannelID, fmt.Sprintf("[%s] 대회 참가자 %d명의 시작 기록을 지금 상태로 다시 남겼습니다.",
+		competition.Name, captured))
+}
+
+// retakeResult 참가자 한 명의 시작 기록 재설정 결과입니다
+type retakeResult struct {
+	participant models.Participant
+	err         error
+}
+
+// retakeStartSnapshots 참가자들의 시작 기록을 동시에 다시 남기고 끝나는 대로 결과를 보냅니다.
+// ctx가 취소되면 아직 시작하지 않은 참가자는 ctx의 오류로 끝나며, 모든 결과를 보낸 뒤 채널을 닫습니다.
+func retakeStartSnapshots(ctx context.Context, storage interfaces.StorageRepository, competitionID int, targets []models.Participant) <-chan retakeResult {
+	results := make(chan retakeResult, len(targets))
+	semaphore := make(chan struct{}, constants.MaxConcurrentRequests)
+
+	var wg sync.WaitGroup
+	for _, participant := range targets {
+		wg.Add(1)
+		go func(p models.Participant) {
+			defer wg.Done()
+
+			// 동시 요청 수 제한
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				results <- retakeResult{participant: p, err: ctx.Err()}
+				return
+			}
+			defer func() { <-semaphore }()
+
+			results <- retakeResult{participant: p, err: storage.CaptureStartSnapshot(ctx, competitionID, p.ID)}
+		}(participant)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// formatSnapshotSummary 변경 기록에 남길 시작 기록 요약입니다
+func formatSnapshotSummary(p models.Participant) string {
+	if p.SnapshotPending {
+		return "기록 대기"
+	}
+	return fmt.Sprintf("%s (%d), %d문제", getTierName(p.StartTier), p.StartRating, p.StartProblemCount)
+}
+
+// formatStartSnapshots 대회 참가자들의 시작 기록을 나열합니다
+func formatStartSnapshots(competition *models.Competition) string {
+	timing := "등록(승인) 시점"
+	if competition.Registration.Timing() == models.SnapshotAtStart {
+		timing = "대회 시작 시점 (" + competition.StartDate.Format(constants.DateTimeFormat) + ")"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📸 **%s** 시작 기록 (기록 시점: %s)\n", competition.Name, timing))
+	if len(competition.Participants) == 0 {
+		sb.WriteString("참가자가 없습니다.")
+		return sb.String()
+	}
+	for i, p := range competition.Participants {
+		if i >= constants.MaxSnapshotEntries {
+			sb.WriteString(fmt.Sprintf("... 외 %d명\n", len(competition.Participants)-constants.MaxSnapshotEntries))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("• %s (%s): %s\n", p.Name, p.BaekjoonID, formatSnapshotSummary(p)))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
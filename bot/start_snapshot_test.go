@@ -0,0 +1,44 @@
+package bot
+
+import (
+	"discord-bot/constants"
+	"discord-bot/models"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatSnapshotSummary(t *testing.T) {
+	if got := formatSnapshotSummary(models.Participant{SnapshotPending: true, StartRating: 900}); got != "기록 대기" {
+		t.Fatalf("pending summary = %q", got)
+	}
+	got := formatSnapshotSummary(models.Participant{StartTier: 7, StartRating: 900, StartProblemCount: 12})
+	if !strings.Contains(got, getTierName(7)) || !strings.Contains(got, "(900)") || !strings.Contains(got, "12문제") {
+		t.Fatalf("summary = %q", got)
+	}
+}
+
+func TestFormatStartSnapshots(t *testing.T) {
+	competition := &models.Competition{
+		Name:         "겨울 대회",
+		StartDate:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local),
+		Registration: models.RegistrationPolicy{SnapshotTiming: models.SnapshotAtStart},
+	}
+	if got := formatStartSnapshots(competition); !strings.Contains(got, "대회 시작 시점") || !strings.Contains(got, "참가자가 없습니다") {
+		t.Fatalf("empty competition message = %q", got)
+	}
+
+	for i := 0; i < constants.MaxSnapshotEntries+2; i++ {
+		competition.Participants = append(competition.Participants, models.Participant{
+			Name: fmt.Sprintf("참가자%d", i), BaekjoonID: fmt.Sprintf("user%d", i), SnapshotPending: true,
+		})
+	}
+	got := formatStartSnapshots(competition)
+	if !strings.Contains(got, "참가자0 (user0): 기록 대기") {
+		t.Fatalf("message does not list the first participant: %q", got)
+	}
+	if strings.Contains(got, fmt.Sprintf("(user%d)", constants.MaxSnapshotEntries)) || !strings.Contains(got, "외 2명") {
+		t.Fatalf("message is not capped at %d entries: %q", constants.MaxSnapshotEntries, got)
+	}
+}
@@ -5,11 +5,15 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Config 애플리케이션의 전체 설정을 관리합니다
 type Config struct {
 	Discord  DiscordConfig
+	Storage  StorageConfig
+	Backup   BackupConfig
+	API      APIConfig
 	Schedule ScheduleConfig
 	Logging  LoggingConfig
 	Features FeatureFlags
@@ -17,13 +21,32 @@ type Config struct {
 
 type DiscordConfig struct {
 	Token     string
-	ChannelID string
+	ChannelID string // 길드 분리 이전의 공지 채널 (LegacyGuildID 길드의 기본값으로 사용)
+	// LegacyGuildID 작업 디렉터리에 남아 있는 이전 데이터를 가져갈 길드 ID
+	LegacyGuildID string
+}
+
+type StorageConfig struct {
+	DataDir string
+	Backend string // constants.StorageBackendJSON 또는 constants.StorageBackendSQLite
+}
+
+type BackupConfig struct {
+	Interval  time.Duration // 0이면 자동 백업 비활성화
+	Retention int           // 길드별로 보관할 최근 백업 개수
+}
+
+// APIConfig solved.ac 요청 제한 설정입니다
+type APIConfig struct {
+	RequestsPerSecond float64
+	Burst             int
 }
 
 type ScheduleConfig struct {
-	ScoreboardHour   int
-	ScoreboardMinute int
-	Enabled          bool
+	ScoreboardHour    int
+	ScoreboardMinute  int
+	Enabled           bool
+	ScorePollInterval time.Duration // 0이면 백그라운드 점수 갱신 비활성화
 }
 
 type LoggingConfig struct {
@@ -34,19 +57,34 @@ type LoggingConfig struct {
 type FeatureFlags struct {
 	EnableAutoScoreboard bool
 	EnableDetailedErrors bool
+	EnableSlashCommands  bool
 }
 
 // Load는 환경변수에서 설정을 로드합니다
 func Load() *Config {
 	return &Config{
 		Discord: DiscordConfig{
-			Token:     getEnv(constants.EnvDiscordToken, ""),
-			ChannelID: getEnv(constants.EnvChannelID, ""),
+			Token:         getEnv(constants.EnvDiscordToken, ""),
+			ChannelID:     getEnv(constants.EnvChannelID, ""),
+			LegacyGuildID: getEnv(constants.EnvGuildID, ""),
+		},
+		Storage: StorageConfig{
+			DataDir: getEnv(constants.EnvDataDir, constants.DefaultDataDir),
+			Backend: strings.ToLower(getEnv(constants.EnvStorageBackend, constants.StorageBackendJSON)),
+		},
+		Backup: BackupConfig{
+			Interval:  time.Duration(getEnvInt(constants.EnvBackupHours, constants.DefaultBackupHours)) * time.Hour,
+			Retention: getEnvInt(constants.EnvBackupRetention, constants.DefaultBackupRetention),
+		},
+		API: APIConfig{
+			RequestsPerSecond: getEnvFloat(constants.EnvAPIRateLimit, constants.DefaultRequestsPerSec),
+			Burst:             getEnvInt(constants.EnvAPIBurst, constants.DefaultRequestBurst),
 		},
 		Schedule: ScheduleConfig{
-			ScoreboardHour:   getEnvInt("SCOREBOARD_HOUR", constants.DailyScoreboardHour),
-			ScoreboardMinute: getEnvInt("SCOREBOARD_MINUTE", constants.DailyScoreboardMinute),
-			Enabled:          getEnv(constants.EnvChannelID, "") != "",
+			ScoreboardHour:    getEnvInt("SCOREBOARD_HOUR", constants.DailyScoreboardHour),
+			ScoreboardMinute:  getEnvInt("SCOREBOARD_MINUTE", constants.DailyScoreboardMinute),
+			Enabled:           getEnvBool("ENABLE_AUTO_SCOREBOARD", true),
+			ScorePollInterval: getEnvMinutes(constants.EnvScorePollMinutes, constants.DefaultScorePollMinutes),
 		},
 		Logging: LoggingConfig{
 			Level:     getEnv(constants.EnvLogLevel, constants.LogLevelInfo),
@@ -55,6 +93,7 @@ func Load() *Config {
 		Features: FeatureFlags{
 			EnableAutoScoreboard: getEnvBool("ENABLE_AUTO_SCOREBOARD", true),
 			EnableDetailedErrors: getEnvBool("ENABLE_DETAILED_ERRORS", false),
+			EnableSlashCommands:  getEnvBool("ENABLE_SLASH_COMMANDS", true),
 		},
 	}
 }
@@ -67,6 +106,36 @@ func (c *Config) Validate() error {
 			Message: "Discord bot token is required",
 		}
 	}
+	if c.Discord.ChannelID != "" && c.Discord.LegacyGuildID == "" {
+		return &ConfigError{
+			Field:   "Discord.LegacyGuildID",
+			Message: constants.EnvGuildID + " is required when " + constants.EnvChannelID + " is set",
+		}
+	}
+	if c.Storage.Backend != constants.StorageBackendJSON && c.Storage.Backend != constants.StorageBackendSQLite {
+		return &ConfigError{
+			Field:   "Storage.Backend",
+			Message: constants.EnvStorageBackend + " must be " + constants.StorageBackendJSON + " or " + constants.StorageBackendSQLite,
+		}
+	}
+	if c.Backup.Retention < 1 {
+		return &ConfigError{
+			Field:   "Backup.Retention",
+			Message: constants.EnvBackupRetention + " must be at least 1",
+		}
+	}
+	if c.API.RequestsPerSecond <= 0 {
+		return &ConfigError{
+			Field:   "API.RequestsPerSecond",
+			Message: constants.EnvAPIRateLimit + " must be greater than 0",
+		}
+	}
+	if c.API.Burst < 1 {
+		return &ConfigError{
+			Field:   "API.Burst",
+			Message: constants.EnvAPIBurst + " must be at least 1",
+		}
+	}
 	return nil
 }
 
@@ -102,6 +171,15 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 func getEnvBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if boolValue, err := strconv.ParseBool(value); err == nil {
@@ -110,3 +188,7 @@ func getEnvBool(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+func getEnvMinutes(key string, defaultMinutes int) time.Duration {
+	return time.Duration(getEnvInt(key, defaultMinutes)) * time.Minute
+}
@@ -4,21 +4,71 @@ import "time"
 
 // 파일 관련 상수
 const (
-	ParticipantsFileName = "participants.json"
-	CompetitionFileName  = "competition.json"
+	ParticipantsFileName = "participants.json" // 단일 대회 시절 파일 (마이그레이션용)
+	CompetitionFileName  = "competition.json"  // 단일 대회 시절 파일 (마이그레이션용)
+	CompetitionsFileName = "competitions.json"
+	SolvesFileName       = "solves.json"
+	SettingsFileName     = "settings.json"
+	AccountsFileName     = "accounts.json"
+	HandleChangeFileName = "handle_changes.json" // 백준 ID 변경 요청
+	AuditLogFileName     = "audit.json"          // 참가자 정보 변경 기록
+	RegistrationFileName = "registrations.json"  // 승인 방식 대회의 등록 신청
+	ResultsFileName      = "results.json"        // 종료된 대회의 최종 순위
+	SQLiteFileName       = "bot.db"
+	GuildsDirName        = "guilds"
+	BackupsDirName       = "backups"
+	DefaultDataDir       = "data"
 	FilePermission       = 0644
+	DirPermission        = 0755
 	BackupFileSuffix     = ".corrupted"
 	JSONIndentSpaces     = "  "
 )
 
+// 백업 관련 상수
+const (
+	DefaultBackupHours     = 6  // 자동 백업 기본 주기 (시간, 0이면 비활성화)
+	DefaultBackupRetention = 28 // 길드별로 보관할 최근 백업 개수
+	BackupIDFormat         = "20060102-150405"
+	MaxBackupListEntries   = 15 // 백업 목록 메시지에 표시할 최대 개수
+)
+
+// 계정 연동 관련 상수
+const (
+	VerificationCodePrefix = "verify-"        // solved.ac 자기소개에 넣을 인증 코드 접두사
+	VerificationCodeLength = 8                // 접두사를 제외한 인증 코드 길이
+	VerificationCodeTTL    = 30 * time.Minute // 인증 코드 유효 시간
+)
+
 // API 관련 상수
 const (
 	SolvedACBaseURL       = "https://solved.ac/api/v3"
+	SolvedACProfileURL    = "https://solved.ac/profile/" // 프로필 카드에 연결할 solved.ac 프로필 주소
 	APITimeout            = 30 * time.Second
 	MaxRetries            = 3
 	RetryDelay            = 1 * time.Second
 	APIRetryMultiplier    = 2
 	MaxConcurrentRequests = 5
+	SolvedACPageSize      = 50  // 문제 검색 API의 페이지당 항목 수
+	MaxSolvedProblemPages = 400 // 해결한 문제 목록 조회 시 최대 페이지 수
+)
+
+// solved.ac 요청 제한 관련 상수
+const (
+	DefaultRequestsPerSec = 3.0 // 클라이언트 전체의 초당 요청 수
+	DefaultRequestBurst   = 5   // 한꺼번에 보낼 수 있는 최대 요청 수
+	MaxRetryBackoff       = 30 * time.Second
+	MaxRetryAfter         = 2 * time.Minute // Retry-After가 이보다 길면 이 시간만 기다립니다
+	BreakerFailureLimit   = 5               // 요청을 차단하기까지의 연속 실패 횟수
+	BreakerCooldown       = 30 * time.Second
+)
+
+// solved.ac 응답 캐시 관련 상수
+const (
+	UserInfoCacheTTL = 1 * time.Minute
+	SolvedCacheTTL   = 1 * time.Minute
+	Top100CacheTTL   = 5 * time.Minute
+	MaxCacheEntries  = 2000 // 캐시에 보관할 최대 응답 수
+	MaxETagEntries   = 2000 // ETag 재검증용으로 보관할 최대 응답 수 (문제 목록은 페이지마다 하나)
 )
 
 // 점수 계산 상수
@@ -30,11 +80,30 @@ const (
 
 // 대회 관련 상수
 const (
-	BlackoutDays          = 3
-	DailyScoreboardHour   = 9
-	DailyScoreboardMinute = 0
-	SchedulerInterval     = 24 * time.Hour
-	SchedulerTimeout      = 30 * time.Second
+	BlackoutDays            = 3
+	DailyScoreboardHour     = 9
+	DailyScoreboardMinute   = 0
+	SchedulerInterval       = 24 * time.Hour
+	SchedulerTimeout        = 30 * time.Second
+	CommandTimeout          = 2 * time.Minute
+	SnapshotCheckInterval   = 1 * time.Minute
+	RevealDelay             = 10 * time.Second
+	MaxRevealDelay          = 5 * time.Minute
+	AnnounceRetryDelay      = 10 * time.Second
+	AnnounceAttempts        = 3
+	DefaultScorePollMinutes = 10 // 점수 백그라운드 갱신 기본 주기 (분)
+	DefaultHistoryDays      = 7  // 풀이 기록/기간 랭킹의 기본 조회 기간
+	MaxHistoryDays          = 90 // 풀이 기록/기간 랭킹의 최대 조회 기간
+	MaxHistoryEntries       = 30 // 풀이 기록 메시지에 표시할 최대 문제 수
+	MaxBreakdownEntries     = 20 // 점수 내역 메시지에 표시할 최대 문제 수
+	MaxPreSolvedListed      = 10 // 점수 내역에 나열할 등록 전 해결 문제 ID 최대 개수
+	BreakdownTitleWidth     = 20 // 점수 내역의 문제 제목 표시 폭
+	MaxAuditLogEntries      = 20 // 변경 기록 메시지에 표시할 최대 항목 수
+	MaxSnapshotEntries      = 30 // 시작 기록 메시지에 표시할 최대 참가자 수
+	SnapshotProgressStep    = 10 // 시작 기록 재설정 진행 상황을 알릴 참가자 수 간격
+	FinalPodiumSize         = 3  // 최종 결과 발표에서 강조할 입상자 수
+	RevealGroupSize         = 5  // 순차 발표에서 입상자 밖의 순위를 한 번에 공개할 기본 인원
+	MaxRevealGroupSize      = 20 // 순차 발표에서 한 번에 공개할 최대 인원
 )
 
 // Discord 관련 상수
@@ -76,12 +145,12 @@ const (
 
 // 문자열 크기 제한
 const (
-	MaxUsernameLength     = 15
-	TruncateIndicator     = "..."
-	ScoreboardRankWidth   = 4
-	ScoreboardNameWidth   = 15
-	ScoreboardScoreWidth  = 6
-	ScoreboardSeparator   = "──────────────────────────────"
+	MaxUsernameLength    = 15
+	TruncateIndicator    = "..."
+	ScoreboardRankWidth  = 4
+	ScoreboardNameWidth  = 15
+	ScoreboardScoreWidth = 6
+	ScoreboardSeparator  = "──────────────────────────────"
 )
 
 // 메시지 템플릿
@@ -102,8 +171,22 @@ const (
 
 // 환경 변수 키
 const (
-	EnvDiscordToken = "DISCORD_BOT_TOKEN"
-	EnvChannelID    = "DISCORD_CHANNEL_ID"
-	EnvLogLevel     = "LOG_LEVEL"
-	EnvDebugMode    = "DEBUG_MODE"
+	EnvDiscordToken     = "DISCORD_BOT_TOKEN"
+	EnvChannelID        = "DISCORD_CHANNEL_ID"
+	EnvGuildID          = "DISCORD_GUILD_ID"
+	EnvDataDir          = "DATA_DIR"
+	EnvLogLevel         = "LOG_LEVEL"
+	EnvDebugMode        = "DEBUG_MODE"
+	EnvScorePollMinutes = "SCORE_POLL_INTERVAL_MINUTES"
+	EnvStorageBackend   = "STORAGE_BACKEND"
+	EnvBackupHours      = "BACKUP_INTERVAL_HOURS"
+	EnvBackupRetention  = "BACKUP_RETENTION"
+	EnvAPIRateLimit     = "SOLVEDAC_REQUESTS_PER_SECOND"
+	EnvAPIBurst         = "SOLVEDAC_BURST"
+)
+
+// 저장소 백엔드
+const (
+	StorageBackendJSON   = "json"
+	StorageBackendSQLite = "sqlite"
 )
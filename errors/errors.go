@@ -120,8 +120,15 @@ func NewSystemError(code, message string, err error) *AppError {
 
 // Discord 메시지 관련 헬퍼 함수들
 
+// MessageSender 디스코드 채널에 메시지를 보내는 기능입니다.
+// *discordgo.Session과 슬래시 명령 응답기가 구현합니다.
+type MessageSender interface {
+	ChannelMessageSend(channelID, content string, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageSendEmbed(channelID string, embed *discordgo.MessageEmbed, options ...discordgo.RequestOption) (*discordgo.Message, error)
+}
+
 // HandleDiscordError 오류를 처리하고 Discord 채널에 메시지를 전송합니다
-func HandleDiscordError(s *discordgo.Session, channelID string, err error) {
+func HandleDiscordError(s MessageSender, channelID string, err error) {
 	if appErr, ok := err.(*AppError); ok {
 		// 로그에 상세 정보 기록
 		if appErr.Internal != nil {
@@ -139,16 +146,16 @@ func HandleDiscordError(s *discordgo.Session, channelID string, err error) {
 }
 
 // SendDiscordSuccess 성공 메시지를 Discord 채널에 전송합니다
-func SendDiscordSuccess(s *discordgo.Session, channelID, message string) {
+func SendDiscordSuccess(s MessageSender, channelID, message string) {
 	s.ChannelMessageSend(channelID, constants.EmojiSuccess+" "+message)
 }
 
 // SendDiscordInfo 정보 메시지를 Discord 채널에 전송합니다
-func SendDiscordInfo(s *discordgo.Session, channelID, message string) {
+func SendDiscordInfo(s MessageSender, channelID, message string) {
 	s.ChannelMessageSend(channelID, constants.EmojiInfo+" "+message)
 }
 
 // SendDiscordWarning 경고 메시지를 Discord 채널에 전송합니다
-func SendDiscordWarning(s *discordgo.Session, channelID, message string) {
+func SendDiscordWarning(s MessageSender, channelID, message string) {
 	s.ChannelMessageSend(channelID, constants.EmojiWarning+" "+message)
 }
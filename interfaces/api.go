@@ -1,9 +1,19 @@
 package interfaces
 
-import "discord-bot/api"
+import (
+	"context"
+	"discord-bot/api"
+)
 
-// APIClient 외부 API와의 통신을 위한 인터페이스입니다
+// APIClient 외부 API와의 통신을 위한 인터페이스입니다.
+// ctx가 취소되거나 기한이 지나면 진행 중인 요청과 재시도 대기를 멈추고 ctx의 오류를 반환합니다.
 type APIClient interface {
-	GetUserInfo(handle string) (*api.UserInfo, error)
-	GetUserTop100(handle string) (*api.Top100Response, error)
+	GetUserInfo(ctx context.Context, handle string) (*api.UserInfo, error)
+	GetUserTop100(ctx context.Context, handle string) (*api.Top100Response, error)
+	GetUserSolvedProblems(ctx context.Context, handle string) (*api.SolvedProblemsResponse, error)
+}
+
+// CacheInvalidator 응답을 캐시하는 APIClient에서 특정 사용자의 캐시를 지울 때 사용합니다
+type CacheInvalidator interface {
+	Invalidate(handle string)
 }
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"discord-bot/errors"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Messenger 명령 처리에 필요한 디스코드 기능입니다.
+// 봇은 *discordgo.Session을 감싼 구현을, 테스트는 보낸 메시지를 기록하는 가짜 구현을 사용합니다.
+type Messenger interface {
+	errors.MessageSender
+
+	// Guild 봇이 속한 길드 정보를 반환합니다
+	Guild(guildID string) (*discordgo.Guild, error)
+	// GuildMember 길드 멤버 정보를 반환합니다
+	GuildMember(guildID, userID string) (*discordgo.Member, error)
+	// GuildRole 길드의 역할 정보를 반환합니다
+	GuildRole(guildID, roleID string) (*discordgo.Role, error)
+	// GuildIDs 봇이 속한 길드 ID 목록을 반환합니다
+	GuildIDs() []string
+}
@@ -1,6 +1,14 @@
 package interfaces
 
+import (
+	"context"
+	"discord-bot/api"
+	"discord-bot/models"
+)
+
 // ScoreCalculator 점수 계산을 위한 인터페이스입니다
 type ScoreCalculator interface {
-	CalculateScore(handle string, startTier int, startProblemIDs []int) (float64, error)
+	CalculateScore(ctx context.Context, handle string, startTier int, startProblemIDs []int, profile models.ScoringProfile) (float64, error)
+	CalculateScoreFromProblems(problems []api.ProblemInfo, startTier int, startProblemIDs []int, profile models.ScoringProfile) float64
+	BreakdownFromProblems(problems []api.ProblemInfo, startTier int, startProblemIDs []int, profile models.ScoringProfile) models.ScoreBreakdown
 }
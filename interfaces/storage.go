@@ -1,25 +1,82 @@
 package interfaces
 
 import (
+	"context"
 	"discord-bot/models"
 	"time"
 )
 
 // StorageRepository 데이터 저장소 작업을 위한 인터페이스입니다
 type StorageRepository interface {
-	// 참가자 작업
-	GetParticipants() []models.Participant
-	AddParticipant(name, baekjoonID string, startTier, startRating int) error
-	RemoveParticipant(baekjoonID string) error
-	SaveParticipants() error
+	// 참가자 작업 (대회별)
+	GetParticipants(competitionID int) []models.Participant
+	AddParticipant(ctx context.Context, competitionID int, name, baekjoonID, discordUserID string, startTier, startRating int) error
+	RemoveParticipant(competitionID int, baekjoonID string) error
+	RenameParticipant(competitionID, participantID int, name string) error
+
+	// 백준 ID 변경 요청과 변경 기록 작업
+	CreateHandleChangeRequest(competitionID, participantID int, newBaekjoonID, requestedBy string) (*models.HandleChangeRequest, error)
+	GetHandleChangeRequests(competitionID int) []models.HandleChangeRequest
+	ResolveHandleChangeRequest(requestID int, approve bool, resolvedBy string) (*models.HandleChangeRequest, error)
+	AppendAuditEntry(entry models.AuditEntry) error
+	GetAuditLog(competitionID int) []models.AuditEntry
+
+	// 등록 신청과 시작 시점 기록 작업
+	CreateRegistrationRequest(competitionID int, name, baekjoonID, discordUserID string) (*models.RegistrationRequest, error)
+	GetRegistrationRequests(competitionID int) []models.RegistrationRequest
+	GetRegistrationRequest(requestID int) *models.RegistrationRequest
+	ApproveRegistrationRequest(ctx context.Context, requestID int, resolvedBy string, startTier, startRating int) (*models.RegistrationRequest, error)
+	RejectRegistrationRequest(requestID int, resolvedBy string) (*models.RegistrationRequest, error)
+	CaptureStartSnapshot(ctx context.Context, competitionID, participantID int) error
 
 	// 대회 작업
-	GetCompetition() *models.Competition
-	CreateCompetition(name string, startDate, endDate time.Time) error
-	SetScoreboardVisibility(visible bool) error
-	IsBlackoutPeriod() bool
-	SaveCompetition() error
-	UpdateCompetitionName(name string) error
-	UpdateCompetitionStartDate(startDate time.Time) error
-	UpdateCompetitionEndDate(endDate time.Time) error
+	GetCompetitions() []*models.Competition
+	GetCompetition(competitionID int) *models.Competition
+	CreateCompetition(name string, startDate, endDate time.Time) (*models.Competition, error)
+	SetScoreboardVisibility(competitionID int, visible bool) error
+	IsBlackoutPeriod(competitionID int) bool
+	SaveCompetitions() error
+	UpdateCompetitionName(competitionID int, name string) error
+	UpdateCompetitionStartDate(competitionID int, startDate time.Time) error
+	UpdateCompetitionEndDate(competitionID int, endDate time.Time) error
+	UpdateCompetitionScoring(competitionID int, profile models.ScoringProfile) error
+	UpdateCompetitionRegistration(competitionID int, policy models.RegistrationPolicy) error
+	UpdateCompetitionReveal(competitionID int, settings models.RevealSettings) error
+
+	// 최종 결과 작업
+	FinalizeCompetition(standings models.FinalStandings) error
+	GetFinalStandings(competitionID int) *models.FinalStandings
+	MarkResultsAnnounced(competitionID int) error
+
+	// 풀이 기록 작업
+	RecordSolves(records []models.SolveRecord) (int, error)
+	GetSolveHistory(competitionID int, baekjoonID string, since time.Time) []models.SolveRecord
+	GetSolvesSince(competitionID int, since time.Time) []models.SolveRecord
+	SaveSolves() error
+
+	// 길드 설정 작업
+	GetGuildSettings() models.GuildSettings
+	SetAnnouncementChannel(channelID string) error
+
+	// 계정 연동 작업
+	GetAccountLink(discordUserID string) *models.AccountLink
+	FindAccountLinkByBaekjoonID(baekjoonID string) *models.AccountLink
+	LinkAccount(discordUserID, baekjoonID string) error
+	UnlinkAccount(discordUserID string) error
+
+	// 백업 작업
+	CreateBackup() (models.BackupInfo, error)
+	ListBackups() ([]models.BackupInfo, error)
+	RestoreBackup(backupID string) error
+	PruneBackups(keep int) error
+
+	// 종료 작업
+	Close() error
+}
+
+// GuildStorageProvider 길드별로 분리된 저장소를 제공하는 인터페이스입니다
+type GuildStorageProvider interface {
+	ForGuild(guildID string) (StorageRepository, error)
+	GuildIDs() []string
+	Close() error
 }
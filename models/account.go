@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// AccountLink 디스코드 사용자와 소유를 인증한 백준 ID의 연결입니다
+type AccountLink struct {
+	DiscordUserID string    `json:"discord_user_id"`
+	BaekjoonID    string    `json:"baekjoon_id"`
+	VerifiedAt    time.Time `json:"verified_at"` // solved.ac 자기소개로 소유를 확인한 시각
+}
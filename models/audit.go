@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// AuditAction 참가자 정보 변경 기록의 종류입니다
+type AuditAction string
+
+const (
+	AuditWithdraw      AuditAction = "withdraw"       // 참가자가 스스로 탈퇴
+	AuditRemove        AuditAction = "remove"         // 관리자가 참가자 삭제
+	AuditRename        AuditAction = "rename"         // 표시 이름 변경
+	AuditHandleRequest AuditAction = "handle_request" // 백준 ID 변경 요청
+	AuditHandleApprove AuditAction = "handle_approve" // 백준 ID 변경 승인
+	AuditHandleReject  AuditAction = "handle_reject"  // 백준 ID 변경 거절
+	AuditResnapshot    AuditAction = "resnapshot"     // 관리자가 시작 기록 재설정
+)
+
+// Label 변경 기록 종류를 메시지에 표시할 이름으로 반환합니다
+func (a AuditAction) Label() string {
+	switch a {
+	case AuditWithdraw:
+		return "탈퇴"
+	case AuditRemove:
+		return "삭제"
+	case AuditRename:
+		return "이름 변경"
+	case AuditHandleRequest:
+		return "ID 변경 요청"
+	case AuditHandleApprove:
+		return "ID 변경 승인"
+	case AuditHandleReject:
+		return "ID 변경 거절"
+	case AuditResnapshot:
+		return "시작 기록 재설정"
+	default:
+		return string(a)
+	}
+}
+
+// AuditEntry 참가자 정보가 바뀐 내역입니다. 누가 언제 무엇을 바꿨는지 남깁니다
+type AuditEntry struct {
+	ID            int         `json:"id"`
+	CompetitionID int         `json:"competition_id"`
+	ParticipantID int         `json:"participant_id"`
+	Action        AuditAction `json:"action"`
+	ActorID       string      `json:"actor_id"` // 변경한 디스코드 사용자 ID
+	OldValue      string      `json:"old_value,omitempty"`
+	NewValue      string      `json:"new_value,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+// RequestStatus 관리자 승인이 필요한 요청(백준 ID 변경, 등록 신청)의 처리 상태입니다
+type RequestStatus string
+
+const (
+	RequestPending  RequestStatus = "pending"
+	RequestApproved RequestStatus = "approved"
+	RequestRejected RequestStatus = "rejected"
+)
+
+// HandleChangeRequest 참가자가 요청한 백준 ID 변경입니다. 관리자가 승인하면 시작 시점 기록을 유지한 채 ID만 바뀝니다
+type HandleChangeRequest struct {
+	ID            int           `json:"id"`
+	CompetitionID int           `json:"competition_id"`
+	ParticipantID int           `json:"participant_id"`
+	OldBaekjoonID string        `json:"old_baekjoon_id"`
+	NewBaekjoonID string        `json:"new_baekjoon_id"`
+	RequestedBy   string        `json:"requested_by"` // 요청한 디스코드 사용자 ID
+	Status        RequestStatus `json:"status"`
+	RequestedAt   time.Time     `json:"requested_at"`
+	ResolvedBy    string        `json:"resolved_by,omitempty"` // 처리한 관리자 디스코드 사용자 ID
+	ResolvedAt    time.Time     `json:"resolved_at,omitempty"`
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// BackupInfo 저장소 백업 하나의 정보입니다
+type BackupInfo struct {
+	ID        string    `json:"id"` // 생성 시각 기반 식별자 (예: 20240301-090000)
+	CreatedAt time.Time `json:"created_at"`
+	Size      int64     `json:"size"` // 백업 파일 크기 합계 (바이트)
+}
@@ -8,22 +8,42 @@ type Participant struct {
 	ID                int       `json:"id"`
 	Name              string    `json:"name"`
 	BaekjoonID        string    `json:"baekjoon_id"`
+	DiscordUserID     string    `json:"discord_user_id,omitempty"` // 등록한 디스코드 사용자 ID (멘션으로 참가자를 찾을 때 사용)
 	StartTier         int       `json:"start_tier"`
 	StartRating       int       `json:"start_rating"`
 	CreatedAt         time.Time `json:"created_at"`
-	StartProblemIDs   []int     `json:"start_problem_ids"`   // 참가 시점의 해결한 문제 ID들
-	StartProblemCount int       `json:"start_problem_count"` // 참가 시점의 해결한 문제 수
+	StartProblemIDs   []int     `json:"start_problem_ids"`          // 참가 시점의 해결한 문제 ID들
+	StartProblemCount int       `json:"start_problem_count"`        // 참가 시점의 해결한 문제 수
+	SnapshotPending   bool      `json:"snapshot_pending,omitempty"` // 대회 시작 시점에 시작 기록을 남길 예정
 }
 
 type Competition struct {
-	ID                int           `json:"id"`
-	Name              string        `json:"name"`
-	StartDate         time.Time     `json:"start_date"`
-	EndDate           time.Time     `json:"end_date"`
-	BlackoutStartDate time.Time     `json:"blackout_start_date"`
-	IsActive          bool          `json:"is_active"`
-	ShowScoreboard    bool          `json:"show_scoreboard"`
-	Participants      []Participant `json:"participants"`
+	ID                int                `json:"id"`
+	Name              string             `json:"name"`
+	StartDate         time.Time          `json:"start_date"`
+	EndDate           time.Time          `json:"end_date"`
+	BlackoutStartDate time.Time          `json:"blackout_start_date"`
+	IsActive          bool               `json:"is_active"`
+	ShowScoreboard    bool               `json:"show_scoreboard"`
+	Participants      []Participant      `json:"participants"`
+	Scoring           *ScoringProfile    `json:"scoring,omitempty"` // 없으면 기본 점수 계산 규칙 사용
+	Registration      RegistrationPolicy `json:"registration"`
+	Reveal            RevealSettings     `json:"reveal,omitempty"`
+	FinalizedAt       time.Time          `json:"finalized_at,omitempty"`    // 최종 결과가 확정된 시각 (확정 전이면 zero)
+	ResultsPending    bool               `json:"results_pending,omitempty"` // 확정된 최종 결과를 아직 공지 채널에 발표하지 못함
+}
+
+// ScoringRules 대회의 점수 계산 규칙을 반환합니다 (설정되지 않았으면 기본 규칙)
+func (c *Competition) ScoringRules() ScoringProfile {
+	if c.Scoring == nil {
+		return DefaultScoringProfile()
+	}
+	return c.Scoring.Clone()
+}
+
+// IsOngoing 대회가 활성 상태이며 아직 종료되지 않았는지 확인합니다
+func (c *Competition) IsOngoing(now time.Time) bool {
+	return c.IsActive && !now.After(c.EndDate)
 }
 
 type ScoreData struct {
@@ -35,3 +55,26 @@ type ScoreData struct {
 	CurrentRating int     `json:"current_rating"`
 	ProblemCount  int     `json:"problem_count"`
 }
+
+// ScoreboardSnapshot 특정 시점에 계산된 스코어보드 점수 목록입니다
+type ScoreboardSnapshot struct {
+	UpdatedAt time.Time   `json:"updated_at"`
+	Scores    []ScoreData `json:"scores"`
+}
+
+// SolveRecord 참가자가 대회 중 해결한 문제가 처음 확인된 시점을 기록합니다.
+// 같은 백준 ID로 여러 대회에 참가할 수 있으므로 기록은 대회마다 따로 남깁니다.
+type SolveRecord struct {
+	CompetitionID int       `json:"competition_id"`
+	ParticipantID int       `json:"participant_id"`
+	BaekjoonID    string    `json:"baekjoon_id"`
+	ProblemID     int       `json:"problem_id"`
+	Level         int       `json:"level"`
+	FirstSeenAt   time.Time `json:"first_seen_at"`
+	Backlog       bool      `json:"backlog,omitempty"` // 직전 확인 시각을 몰라 FirstSeenAt이 실제 해결 시각보다 늦을 수 있는 기록
+}
+
+// GuildSettings 길드(디스코드 서버)별 봇 설정입니다
+type GuildSettings struct {
+	AnnouncementChannelID string `json:"announcement_channel_id"` // 자동 스코어보드/공지 채널
+}
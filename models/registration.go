@@ -0,0 +1,84 @@
+package models
+
+import (
+	"discord-bot/constants"
+	"fmt"
+	"time"
+)
+
+// SnapshotTiming 참가자의 시작 시점 기록(해결한 문제, 티어, 레이팅)을 언제 남길지 정합니다
+type SnapshotTiming string
+
+const (
+	SnapshotAtRegistration SnapshotTiming = "registration" // 등록(승인) 시점 (기본값)
+	SnapshotAtStart        SnapshotTiming = "start"        // 대회 시작 시점
+)
+
+// RegistrationPolicy 대회의 등록 기간과 승인 방식입니다. 비어 있으면 대회가 끝나기 전까지 누구나 바로 등록할 수 있습니다
+type RegistrationPolicy struct {
+	OpensAt         time.Time      `json:"opens_at,omitempty"`  // 등록 시작일 (없으면 제한 없음)
+	ClosesAt        time.Time      `json:"closes_at,omitempty"` // 등록 마감일, 그날까지 등록 가능 (없으면 대회 종료 시까지)
+	RequireApproval bool           `json:"require_approval,omitempty"`
+	SnapshotTiming  SnapshotTiming `json:"snapshot_timing,omitempty"`
+}
+
+// Timing 시작 시점 기록 시기를 반환합니다 (설정되지 않았으면 등록 시점)
+func (p RegistrationPolicy) Timing() SnapshotTiming {
+	if p.SnapshotTiming == "" {
+		return SnapshotAtRegistration
+	}
+	return p.SnapshotTiming
+}
+
+// Validate 등록 기간과 기록 시기가 올바른지 확인합니다
+func (p RegistrationPolicy) Validate() error {
+	if !p.OpensAt.IsZero() && !p.ClosesAt.IsZero() && p.ClosesAt.Before(p.OpensAt) {
+		return fmt.Errorf("등록 마감일은 등록 시작일보다 빠를 수 없습니다")
+	}
+	switch p.Timing() {
+	case SnapshotAtRegistration, SnapshotAtStart:
+		return nil
+	default:
+		return fmt.Errorf("알 수 없는 기록 시기입니다: %s (registration 또는 start)", p.SnapshotTiming)
+	}
+}
+
+// RegistrationDeadline 등록이 마감되는 시각을 반환합니다. 마감일을 정하지 않았으면 대회 종료 시각입니다
+func (c *Competition) RegistrationDeadline() time.Time {
+	if c.Registration.ClosesAt.IsZero() {
+		return c.EndDate
+	}
+	return c.Registration.ClosesAt.AddDate(0, 0, 1)
+}
+
+// CheckRegistrationOpen now에 등록할 수 있는지 확인하고, 없으면 그 이유를 반환합니다
+func (c *Competition) CheckRegistrationOpen(now time.Time) error {
+	if !c.IsActive {
+		return fmt.Errorf("비활성화된 대회입니다")
+	}
+	if opensAt := c.Registration.OpensAt; !opensAt.IsZero() && now.Before(opensAt) {
+		return fmt.Errorf("등록은 %s부터 가능합니다", opensAt.Format(constants.DateFormat))
+	}
+	if deadline := c.RegistrationDeadline(); !now.Before(deadline) {
+		return fmt.Errorf("등록 기간이 끝났습니다 (%s 마감)", deadline.Format(constants.DateTimeFormat))
+	}
+	return nil
+}
+
+// DefersSnapshot now에 등록(승인)하는 참가자의 시작 시점 기록을 대회 시작까지 미뤄야 하는지 확인합니다
+func (c *Competition) DefersSnapshot(now time.Time) bool {
+	return c.Registration.Timing() == SnapshotAtStart && now.Before(c.StartDate)
+}
+
+// RegistrationRequest 승인 방식 대회에서 관리자의 처리를 기다리는 등록 신청입니다
+type RegistrationRequest struct {
+	ID            int           `json:"id"`
+	CompetitionID int           `json:"competition_id"`
+	Name          string        `json:"name"`
+	BaekjoonID    string        `json:"baekjoon_id"`
+	DiscordUserID string        `json:"discord_user_id"` // 신청한 디스코드 사용자 ID
+	Status        RequestStatus `json:"status"`
+	RequestedAt   time.Time     `json:"requested_at"`
+	ResolvedBy    string        `json:"resolved_by,omitempty"` // 처리한 관리자 디스코드 사용자 ID
+	ResolvedAt    time.Time     `json:"resolved_at,omitempty"`
+}
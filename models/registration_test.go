@@ -0,0 +1,66 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckRegistrationOpen(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	competition := &Competition{IsActive: true, StartDate: day(10), EndDate: day(20)}
+
+	cases := []struct {
+		name   string
+		policy RegistrationPolicy
+		now    time.Time
+		open   bool
+	}{
+		{"기본은 종료 전까지", RegistrationPolicy{}, day(19), true},
+		{"종료 후 마감", RegistrationPolicy{}, day(20), false},
+		{"시작일 전", RegistrationPolicy{OpensAt: day(5)}, day(4), false},
+		{"시작일 당일", RegistrationPolicy{OpensAt: day(5)}, day(5), true},
+		{"마감일 당일 포함", RegistrationPolicy{ClosesAt: day(12)}, day(12).Add(23 * time.Hour), true},
+		{"마감일 다음 날", RegistrationPolicy{ClosesAt: day(12)}, day(13), false},
+	}
+	for _, tc := range cases {
+		competition.Registration = tc.policy
+		if err := competition.CheckRegistrationOpen(tc.now); (err == nil) != tc.open {
+			t.Errorf("%s: CheckRegistrationOpen() = %v, want open=%v", tc.name, err, tc.open)
+		}
+	}
+
+	competition.IsActive = false
+	competition.Registration = RegistrationPolicy{}
+	if err := competition.CheckRegistrationOpen(day(11)); err == nil {
+		t.Error("expected an inactive competition to be closed")
+	}
+}
+
+func TestDefersSnapshot(t *testing.T) {
+	start := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	competition := &Competition{StartDate: start}
+	if competition.DefersSnapshot(start.Add(-time.Hour)) {
+		t.Error("default timing should snapshot at registration")
+	}
+
+	competition.Registration.SnapshotTiming = SnapshotAtStart
+	if !competition.DefersSnapshot(start.Add(-time.Hour)) {
+		t.Error("expected the snapshot to wait for the start before the competition begins")
+	}
+	if competition.DefersSnapshot(start) {
+		t.Error("expected registrations after the start to snapshot immediately")
+	}
+}
+
+func TestRegistrationPolicyValidate(t *testing.T) {
+	opens := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	if err := (RegistrationPolicy{OpensAt: opens, ClosesAt: opens.AddDate(0, 0, -1)}).Validate(); err == nil {
+		t.Error("expected a close date before the open date to be rejected")
+	}
+	if err := (RegistrationPolicy{OpensAt: opens, ClosesAt: opens}).Validate(); err != nil {
+		t.Errorf("same-day window: %v", err)
+	}
+	if err := (RegistrationPolicy{SnapshotTiming: "later"}).Validate(); err == nil {
+		t.Error("expected an unknown snapshot timing to be rejected")
+	}
+}
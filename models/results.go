@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// FinalStandings 대회 종료 시각에 확정되어 더 이상 바뀌지 않는 최종 순위입니다
+type FinalStandings struct {
+	CompetitionID int         `json:"competition_id"`
+	FinalizedAt   time.Time   `json:"finalized_at"`
+	Scores        []ScoreData `json:"scores"` // 순위 순
+}
+
+// Clone 잠금 밖에서 안전하게 읽을 수 있도록 최종 순위를 복사합니다
+func (f FinalStandings) Clone() FinalStandings {
+	f.Scores = append([]ScoreData(nil), f.Scores...)
+	return f
+}
+
+// Podium 상위 n명의 최종 순위를 반환합니다
+func (f FinalStandings) Podium(n int) []ScoreData {
+	if n > len(f.Scores) {
+		n = len(f.Scores)
+	}
+	return f.Scores[:n]
+}
+
+// IsFinalized 최종 결과가 확정된 대회인지 확인합니다
+func (c *Competition) IsFinalized() bool {
+	return !c.FinalizedAt.IsZero()
+}
+
+// IsDueForFinalization now에 최종 결과를 확정해야 하는지 확인합니다
+func (c *Competition) IsDueForFinalization(now time.Time) bool {
+	return c.IsActive && !c.IsFinalized() && !now.Before(c.EndDate)
+}
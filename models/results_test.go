@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsDueForFinalization(t *testing.T) {
+	end := time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)
+	competition := &Competition{IsActive: true, EndDate: end}
+
+	if competition.IsDueForFinalization(end.Add(-time.Second)) {
+		t.Error("competition is due before its end date")
+	}
+	if !competition.IsDueForFinalization(end) {
+		t.Error("competition is not due at its end date")
+	}
+
+	competition.FinalizedAt = end
+	if competition.IsDueForFinalization(end.Add(time.Hour)) {
+		t.Error("finalized competition is due again")
+	}
+}
+
+func TestFinalStandingsPodium(t *testing.T) {
+	standings := FinalStandings{Scores: []ScoreData{{Name: "a"}, {Name: "b"}}}
+	if got := standings.Podium(3); len(got) != 2 {
+		t.Fatalf("Podium(3) with two participants = %d entries", len(got))
+	}
+	if got := standings.Podium(1); len(got) != 1 || got[0].Name != "a" {
+		t.Fatalf("Podium(1) = %+v", got)
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"discord-bot/constants"
+	"fmt"
+	"time"
+)
+
+// RevealSettings 대회 종료 시 최종 순위를 아래 순위부터 차례로 공개하는 발표 방식입니다.
+// 꺼져 있으면 최종 결과를 한 번에 발표합니다.
+type RevealSettings struct {
+	Enabled      bool `json:"enabled,omitempty"`
+	DelaySeconds int  `json:"delay_seconds,omitempty"` // 메시지 사이 간격 (0이면 기본값)
+	GroupSize    int  `json:"group_size,omitempty"`    // 입상자 밖의 순위를 한 메시지에 공개할 인원 (0이면 기본값)
+}
+
+// Delay 발표 메시지 사이 간격을 반환합니다
+func (r RevealSettings) Delay() time.Duration {
+	if r.DelaySeconds == 0 {
+		return constants.RevealDelay
+	}
+	return time.Duration(r.DelaySeconds) * time.Second
+}
+
+// Group 입상자 밖의 순위를 한 메시지에 공개할 인원을 반환합니다
+func (r RevealSettings) Group() int {
+	if r.GroupSize == 0 {
+		return constants.RevealGroupSize
+	}
+	return r.GroupSize
+}
+
+// Validate 발표 간격과 인원이 허용 범위인지 확인합니다
+func (r RevealSettings) Validate() error {
+	maxDelaySeconds := int(constants.MaxRevealDelay / time.Second)
+	if r.DelaySeconds < 0 || r.DelaySeconds > maxDelaySeconds {
+		return fmt.Errorf("발표 간격은 1~%d초로 입력하세요", maxDelaySeconds)
+	}
+	if r.GroupSize < 0 || r.GroupSize > constants.MaxRevealGroupSize {
+		return fmt.Errorf("한 번에 공개할 인원은 1~%d명으로 입력하세요", constants.MaxRevealGroupSize)
+	}
+	return nil
+}
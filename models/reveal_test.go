@@ -0,0 +1,31 @@
+package models
+
+import (
+	"discord-bot/constants"
+	"testing"
+	"time"
+)
+
+func TestRevealSettingsDefaults(t *testing.T) {
+	var settings RevealSettings
+	if settings.Delay() != constants.RevealDelay || settings.Group() != constants.RevealGroupSize {
+		t.Fatalf("default delay %v, group %d", settings.Delay(), settings.Group())
+	}
+
+	settings = RevealSettings{DelaySeconds: 30, GroupSize: 2}
+	if settings.Delay() != 30*time.Second || settings.Group() != 2 {
+		t.Fatalf("delay %v, group %d", settings.Delay(), settings.Group())
+	}
+	if err := settings.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	tooSlow := RevealSettings{DelaySeconds: int(constants.MaxRevealDelay/time.Second) + 1}
+	if err := tooSlow.Validate(); err == nil {
+		t.Error("expected a delay above the maximum to be rejected")
+	}
+	tooLarge := RevealSettings{GroupSize: constants.MaxRevealGroupSize + 1}
+	if err := tooLarge.Validate(); err == nil {
+		t.Error("expected a group above the maximum to be rejected")
+	}
+}
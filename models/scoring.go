@@ -0,0 +1,240 @@
+package models
+
+import (
+	"discord-bot/constants"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxTierLevel 점수표에 별도 항목이 있는 가장 높은 티어입니다 (그 이상은 마스터로 처리)
+const MaxTierLevel = 31
+
+// WeightCategory 문제 난이도와 참가자 시작 티어의 관계에 따른 분류입니다
+type WeightCategory string
+
+const (
+	WeightChallenge WeightCategory = "도전" // 시작 티어보다 높은 문제
+	WeightBase      WeightCategory = "기본" // 시작 티어 근처 문제
+	WeightPractice  WeightCategory = "연습" // 시작 티어보다 낮은 문제
+	WeightExcluded  WeightCategory = "제외" // 어느 구간에도 속하지 않는 문제
+)
+
+// WeightBand 티어 차이(문제 티어 - 시작 티어)가 [MinDistance, MaxDistance]인 문제에 적용할 가중치입니다.
+// 범위 끝이 ±MaxTierLevel이면 그 방향으로 제한이 없는 것으로 표시합니다.
+type WeightBand struct {
+	MinDistance int     `json:"min_distance"`
+	MaxDistance int     `json:"max_distance"`
+	Weight      float64 `json:"weight"`
+}
+
+// Contains 티어 차이가 구간에 속하는지 확인합니다
+func (b WeightBand) Contains(distance int) bool {
+	return distance >= b.MinDistance && distance <= b.MaxDistance
+}
+
+// Category 구간이 시작 티어보다 위, 근처, 아래 중 어디에 있는지 반환합니다
+func (b WeightBand) Category() WeightCategory {
+	switch {
+	case b.MinDistance > 0:
+		return WeightChallenge
+	case b.MaxDistance < 0:
+		return WeightPractice
+	default:
+		return WeightBase
+	}
+}
+
+// String 구간을 `min..max=weight` 형식으로 반환합니다 (제한 없는 끝은 생략)
+func (b WeightBand) String() string {
+	var sb strings.Builder
+	if b.MinDistance > -MaxTierLevel {
+		sb.WriteString(strconv.Itoa(b.MinDistance))
+	}
+	if b.MinDistance != b.MaxDistance {
+		sb.WriteString("..")
+		if b.MaxDistance < MaxTierLevel {
+			sb.WriteString(strconv.Itoa(b.MaxDistance))
+		}
+	}
+	sb.WriteString("=" + strconv.FormatFloat(b.Weight, 'g', -1, 64))
+	return sb.String()
+}
+
+// ParseWeightBand `min..max=weight` 형식의 구간을 파싱합니다. `3..=1.4`처럼 끝을 생략하면 제한이 없고, `0=1.0`은 한 티어 차이만 뜻합니다
+func ParseWeightBand(text string) (WeightBand, error) {
+	rangeText, weightText, found := strings.Cut(text, "=")
+	if !found {
+		return WeightBand{}, fmt.Errorf("구간은 `최소..최대=가중치` 형식으로 입력하세요: %s", text)
+	}
+
+	weight, err := strconv.ParseFloat(weightText, 64)
+	if err != nil {
+		return WeightBand{}, fmt.Errorf("가중치는 숫자로 입력하세요: %s", text)
+	}
+
+	band := WeightBand{MinDistance: -MaxTierLevel, MaxDistance: MaxTierLevel, Weight: weight}
+	minText, maxText, isRange := strings.Cut(rangeText, "..")
+	if !isRange {
+		maxText = minText
+	}
+	if minText != "" {
+		if band.MinDistance, err = strconv.Atoi(minText); err != nil {
+			return WeightBand{}, fmt.Errorf("티어 차이는 정수로 입력하세요: %s", text)
+		}
+	}
+	if maxText != "" {
+		if band.MaxDistance, err = strconv.Atoi(maxText); err != nil {
+			return WeightBand{}, fmt.Errorf("티어 차이는 정수로 입력하세요: %s", text)
+		}
+	}
+	return band, nil
+}
+
+// DefaultWeightBands 기본 가중치 구간입니다 (상위 티어 1.4배, 같은 티어 1.0배, 하위 티어 0.5배)
+func DefaultWeightBands() []WeightBand {
+	return weightBandsFromMultipliers(constants.ChallengeMultiplier, constants.BaseMultiplier, constants.PenaltyMultiplier)
+}
+
+func weightBandsFromMultipliers(challenge, base, penalty float64) []WeightBand {
+	return []WeightBand{
+		{MinDistance: -MaxTierLevel, MaxDistance: -1, Weight: penalty},
+		{MinDistance: 0, MaxDistance: 0, Weight: base},
+		{MinDistance: 1, MaxDistance: MaxTierLevel, Weight: challenge},
+	}
+}
+
+// ScoringProfile 대회별 점수 계산 규칙입니다. 대회와 함께 저장되며 점수 계산 시점에 읽습니다
+type ScoringProfile struct {
+	TierPoints       map[int]int  `json:"tier_points,omitempty"` // 티어별 기본 점수 재정의 (없는 티어는 기본 점수표 사용)
+	WeightBands      []WeightBand `json:"weight_bands"`          // 티어 차이별 가중치 구간 (겹치지 않음, 오름차순)
+	MaxProblemPoints float64      `json:"max_problem_points"`    // 문제 하나로 얻을 수 있는 최대 점수 (0이면 제한 없음)
+	MaxTotalScore    float64      `json:"max_total_score"`       // 참가자 총점 상한 (0이면 제한 없음)
+}
+
+// UnmarshalJSON 가중치 구간이 도입되기 전의 상위/같은/하위 배율 형식도 읽습니다
+func (p *ScoringProfile) UnmarshalJSON(data []byte) error {
+	type profileFields ScoringProfile
+	var aux struct {
+		profileFields
+		ChallengeMultiplier *float64 `json:"challenge_multiplier"`
+		BaseMultiplier      *float64 `json:"base_multiplier"`
+		PenaltyMultiplier   *float64 `json:"penalty_multiplier"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	*p = ScoringProfile(aux.profileFields)
+	if p.WeightBands == nil && aux.ChallengeMultiplier != nil && aux.BaseMultiplier != nil && aux.PenaltyMultiplier != nil {
+		p.WeightBands = weightBandsFromMultipliers(*aux.ChallengeMultiplier, *aux.BaseMultiplier, *aux.PenaltyMultiplier)
+	}
+	return nil
+}
+
+// DefaultScoringProfile 기본 점수 계산 규칙을 반환합니다
+func DefaultScoringProfile() ScoringProfile {
+	return ScoringProfile{
+		WeightBands: DefaultWeightBands(),
+	}
+}
+
+// Clone 점수표와 가중치 구간까지 복사한 사본을 반환합니다
+func (p ScoringProfile) Clone() ScoringProfile {
+	clone := p
+	if p.TierPoints != nil {
+		clone.TierPoints = make(map[int]int, len(p.TierPoints))
+		for tier, points := range p.TierPoints {
+			clone.TierPoints[tier] = points
+		}
+	}
+	clone.WeightBands = append([]WeightBand(nil), p.WeightBands...)
+	return clone
+}
+
+// PointsFor 티어의 기본 점수를 반환합니다. 재정의된 값이 없으면 기본 점수표를 사용합니다
+func (p ScoringProfile) PointsFor(tm *TierManager, tier int) int {
+	if tier > MaxTierLevel {
+		tier = MaxTierLevel
+	}
+	if points, exists := p.TierPoints[tier]; exists {
+		return points
+	}
+	return tm.GetTierPoints(tier)
+}
+
+// WeightFor 문제 티어와 시작 티어의 차이에 해당하는 가중치와 분류를 반환합니다.
+// 어느 구간에도 속하지 않으면 가중치 0과 WeightExcluded를 반환합니다
+func (p ScoringProfile) WeightFor(problemTier, startTier int) (float64, WeightCategory) {
+	distance := problemTier - startTier
+	for _, band := range p.WeightBands {
+		if band.Contains(distance) {
+			return band.Weight, band.Category()
+		}
+	}
+	return 0, WeightExcluded
+}
+
+// SetWeightBands 가중치 구간을 티어 차이 순으로 정렬해 설정합니다
+func (p *ScoringProfile) SetWeightBands(bands []WeightBand) {
+	sorted := append([]WeightBand(nil), bands...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].MinDistance < sorted[j].MinDistance
+	})
+	p.WeightBands = sorted
+}
+
+// Validate 점수 계산 규칙이 올바른지 확인합니다
+func (p ScoringProfile) Validate() error {
+	for tier, points := range p.TierPoints {
+		if tier < 1 || tier > MaxTierLevel {
+			return fmt.Errorf("티어는 1에서 %d 사이여야 합니다: %d", MaxTierLevel, tier)
+		}
+		if points < 0 {
+			return fmt.Errorf("점수는 0 이상이어야 합니다: %d", points)
+		}
+	}
+
+	if len(p.WeightBands) == 0 {
+		return fmt.Errorf("가중치 구간이 하나 이상 있어야 합니다")
+	}
+	for i, band := range p.WeightBands {
+		if band.MinDistance > band.MaxDistance {
+			return fmt.Errorf("구간의 최소 차이가 최대 차이보다 큽니다: %s", band)
+		}
+		if band.Weight < 0 {
+			return fmt.Errorf("가중치는 0 이상이어야 합니다: %s", band)
+		}
+		if i > 0 && band.MinDistance <= p.WeightBands[i-1].MaxDistance {
+			return fmt.Errorf("가중치 구간이 겹칩니다: %s, %s", p.WeightBands[i-1], band)
+		}
+	}
+
+	if p.MaxProblemPoints < 0 || p.MaxTotalScore < 0 {
+		return fmt.Errorf("상한은 0 이상이어야 합니다 (0은 제한 없음)")
+	}
+	return nil
+}
+
+// ProblemScore 문제 하나가 점수에 반영된 방식입니다
+type ProblemScore struct {
+	ProblemID  int
+	Title      string
+	Level      int
+	BasePoints int            // 점수표의 기본 점수
+	Weight     float64        // 티어 차이에 따른 가중치
+	Category   WeightCategory // 가중치 분류
+	Points     float64        // 가중치와 문제당 상한을 적용한 점수
+	Capped     bool           // 문제당 상한이 적용되었는지
+}
+
+// ScoreBreakdown 참가자 점수의 문제별 계산 내역입니다
+type ScoreBreakdown struct {
+	Counted     []ProblemScore // 대회 중 해결한 문제 (가중치 구간 밖이라 0점인 문제 포함)
+	PreSolved   []ProblemScore // 참가 시점에 이미 해결해 제외된 문제
+	Subtotal    float64        // 총점 상한 적용 전 합계
+	Total       float64        // 총점 상한과 반올림을 적용한 최종 점수
+	TotalCapped bool           // 총점 상한이 적용되었는지
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseWeightBand(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    WeightBand
+		wantErr bool
+	}{
+		{text: "-2..2=1", want: WeightBand{MinDistance: -2, MaxDistance: 2, Weight: 1}},
+		{text: "3..=1.4", want: WeightBand{MinDistance: 3, MaxDistance: MaxTierLevel, Weight: 1.4}},
+		{text: "..-3=0.5", want: WeightBand{MinDistance: -MaxTierLevel, MaxDistance: -3, Weight: 0.5}},
+		{text: "0=1", want: WeightBand{MinDistance: 0, MaxDistance: 0, Weight: 1}},
+		{text: "1..2", wantErr: true},
+		{text: "a..2=1", wantErr: true},
+		{text: "1..2=x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseWeightBand(tt.text)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("ParseWeightBand = %+v, %v; want %+v", got, err, tt.want)
+			}
+			// String은 다시 같은 구간으로 파싱되어야 합니다
+			if again, err := ParseWeightBand(got.String()); err != nil || again != got {
+				t.Fatalf("round trip %q = %+v, %v", got.String(), again, err)
+			}
+		})
+	}
+}
+
+func TestScoringProfileValidateRejectsOverlap(t *testing.T) {
+	profile := DefaultScoringProfile()
+	profile.SetWeightBands([]WeightBand{
+		{MinDistance: -2, MaxDistance: 2, Weight: 1},
+		{MinDistance: 2, MaxDistance: MaxTierLevel, Weight: 1.4},
+	})
+	if err := profile.Validate(); err == nil {
+		t.Fatal("expected overlapping bands to be rejected")
+	}
+}
+
+func TestScoringProfileReadsLegacyMultipliers(t *testing.T) {
+	var profile ScoringProfile
+	data := `{"challenge_multiplier": 2, "base_multiplier": 1, "penalty_multiplier": 0.25, "max_total_score": 100}`
+	if err := json.Unmarshal([]byte(data), &profile); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if weight, category := profile.WeightFor(15, 11); weight != 2 || category != WeightChallenge {
+		t.Errorf("challenge weight = %v %s", weight, category)
+	}
+	if weight, category := profile.WeightFor(5, 11); weight != 0.25 || category != WeightPractice {
+		t.Errorf("practice weight = %v %s", weight, category)
+	}
+	if profile.MaxTotalScore != 100 {
+		t.Errorf("max total score = %v", profile.MaxTotalScore)
+	}
+}
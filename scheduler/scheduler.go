@@ -1,9 +1,11 @@
 package scheduler
 
 import (
+	"context"
 	"discord-bot/bot"
 	"discord-bot/config"
 	"discord-bot/constants"
+	"discord-bot/interfaces"
 	"discord-bot/utils"
 	"time"
 
@@ -11,22 +13,41 @@ import (
 )
 
 type Scheduler struct {
+	ctx               context.Context // Stop에서 취소되어 진행 중인 점수 계산과 발표를 멈춥니다
+	cancel            context.CancelFunc
 	session           *discordgo.Session
 	config            *config.Config
 	scoreboardManager *bot.ScoreboardManager
+	storages          interfaces.GuildStorageProvider
 	ticker            *time.Ticker
 	customTicker      *time.Ticker
+	pollTicker        *time.Ticker
+	backupTicker      *time.Ticker
+	snapshotWatching  bool
+	resultsWatching   bool
 	stopChan          chan bool
 	customStopChan    chan bool
+	pollStopChan      chan bool
+	backupStopChan    chan bool
+	snapshotStopChan  chan bool
+	resultsStopChan   chan bool
 }
 
-func NewScheduler(session *discordgo.Session, config *config.Config, scoreboardManager *bot.ScoreboardManager) *Scheduler {
+func NewScheduler(ctx context.Context, session *discordgo.Session, config *config.Config, scoreboardManager *bot.ScoreboardManager, storages interfaces.GuildStorageProvider) *Scheduler {
+	ctx, cancel := context.WithCancel(ctx)
 	return &Scheduler{
+		ctx:               ctx,
+		cancel:            cancel,
 		session:           session,
 		config:            config,
 		scoreboardManager: scoreboardManager,
+		storages:          storages,
 		stopChan:          make(chan bool),
 		customStopChan:    make(chan bool),
+		pollStopChan:      make(chan bool),
+		backupStopChan:    make(chan bool),
+		snapshotStopChan:  make(chan bool),
+		resultsStopChan:   make(chan bool),
 	}
 }
 
@@ -86,28 +107,263 @@ func (s *Scheduler) StartCustomSchedule(hour, minute int) {
 	utils.Info("일일 스코어보드 스케줄러가 매일 %02d:%02d에 실행되도록 설정되었습니다", hour, minute)
 }
 
-func (s *Scheduler) sendDailyScoreboard() {
-	if s.config.Discord.ChannelID == "" {
-		utils.Error("채널 ID가 설정되지 않아 스코어보드를 전송할 수 없습니다")
+// StartScorePolling 주기적으로 점수를 갱신하여 스코어보드 스냅샷을 최신 상태로 유지합니다
+func (s *Scheduler) StartScorePolling(interval time.Duration) {
+	if interval <= 0 {
+		utils.Info("백그라운드 점수 갱신이 비활성화되었습니다")
 		return
 	}
 
-	err := s.scoreboardManager.SendDailyScoreboard(s.session, s.config.Discord.ChannelID)
-	if err != nil {
-		utils.Error("일일 스코어보드 전송 실패: %v", err)
+	s.pollTicker = time.NewTicker(interval)
+
+	go func() {
+		// 시작 직후 한 번 갱신하여 첫 스코어보드 요청도 바로 응답할 수 있도록 함
+		s.refreshScores()
+
+		for {
+			select {
+			case <-s.pollTicker.C:
+				s.refreshScores()
+			case <-s.pollStopChan:
+				return
+			}
+		}
+	}()
+
+	utils.Info("백그라운드 점수 갱신이 %v 간격으로 시작되었습니다", interval)
+}
+
+// StartBackups 주기적으로 모든 길드의 저장소를 백업하고 오래된 백업을 정리합니다
+func (s *Scheduler) StartBackups(interval time.Duration, retention int) {
+	if interval <= 0 {
+		utils.Info("자동 백업이 비활성화되었습니다")
 		return
 	}
 
-	utils.Info("일일 스코어보드를 성공적으로 전송했습니다")
+	s.backupTicker = time.NewTicker(interval)
+
+	go func() {
+		for {
+			select {
+			case <-s.backupTicker.C:
+				s.backupAll(retention)
+			case <-s.backupStopChan:
+				return
+			}
+		}
+	}()
+
+	utils.Info("자동 백업이 %v 간격으로 시작되었습니다 (최근 %d개 보관)", interval, retention)
+}
+
+// StartSnapshotWatcher 대회 시작 시각에 맞춰 시작 기록을 기다리는 참가자들의 기록을 남깁니다.
+// 대회 시작일은 바뀔 수 있으므로 가장 가까운 시작 시각까지 기다리되 SnapshotCheckInterval마다 다시 확인합니다.
+func (s *Scheduler) StartSnapshotWatcher() {
+	s.snapshotWatching = true
+
+	go func() {
+		for {
+			select {
+			case <-time.After(s.captureStartSnapshots()):
+			case <-s.snapshotStopChan:
+				return
+			}
+		}
+	}()
+
+	utils.Info("대회 시작 기록 스케줄러가 시작되었습니다")
+}
+
+// captureStartSnapshots 시작된 대회의 시작 기록을 남기고 다음 확인까지 기다릴 시간을 반환합니다
+func (s *Scheduler) captureStartSnapshots() time.Duration {
+	wait := constants.SnapshotCheckInterval
+	for _, guildID := range s.storages.GuildIDs() {
+		next := s.scoreboardManager.CaptureStartSnapshots(s.ctx, guildID, time.Now())
+		if until := time.Until(next); !next.IsZero() && until < wait {
+			wait = until
+		}
+	}
+	return wait
+}
+
+// StartResultsWatcher 대회 종료 시각에 최종 순위를 확정하고 공지 채널에 입상자를 발표합니다.
+// 시작 기록 스케줄러와 마찬가지로 가장 가까운 종료 시각까지 기다리되 SnapshotCheckInterval마다 다시 확인합니다.
+func (s *Scheduler) StartResultsWatcher() {
+	s.resultsWatching = true
+
+	go func() {
+		s.announcePendingResults()
+		for {
+			select {
+			case <-time.After(s.finalizeCompetitions()):
+			case <-s.resultsStopChan:
+				return
+			}
+		}
+	}()
+
+	utils.Info("대회 최종 결과 스케줄러가 시작되었습니다")
+}
+
+// finalizeCompetitions 종료된 대회의 최종 결과를 확정해 발표하고 다음 확인까지 기다릴 시간을 반환합니다
+func (s *Scheduler) finalizeCompetitions() time.Duration {
+	wait := constants.SnapshotCheckInterval
+	for _, guildID := range s.storages.GuildIDs() {
+		finalized, next := s.scoreboardManager.FinalizeDueCompetitions(s.ctx, guildID, time.Now())
+		for _, result := range finalized {
+			s.announceFinalResults(guildID, result)
+		}
+		if until := time.Until(next); !next.IsZero() && until < wait {
+			wait = until
+		}
+	}
+	return wait
+}
+
+// announceFinalResults 확정된 최종 결과를 길드의 공지 채널에 전송합니다
+func (s *Scheduler) announceFinalResults(guildID string, result bot.FinalizedCompetition) {
+	channelID := s.announcementChannel(guildID)
+	if channelID == "" {
+		utils.Debug("길드 %s에 공지 채널이 설정되지 않아 대회 %d 최종 결과를 발표하지 않습니다", guildID, result.Competition.ID)
+		s.scoreboardManager.CancelReveal(guildID, result.Competition.ID)
+		return
+	}
+
+	if result.Competition.Reveal.Enabled {
+		// 순차 발표는 몇 분이 걸릴 수 있으므로 다른 대회의 확정을 막지 않도록 따로 실행합니다
+		go func() {
+			if err := s.scoreboardManager.RevealFinalResults(s.ctx, s.session, guildID, channelID, result); err != nil {
+				utils.Error("길드 %s 대회 %d 최종 결과 순차 발표 실패: %v", guildID, result.Competition.ID, err)
+			}
+		}()
+		utils.Info("길드 %s에서 대회 %d 최종 결과 순차 발표를 시작했습니다", guildID, result.Competition.ID)
+		return
+	}
+
+	if err := s.scoreboardManager.AnnounceFinalResults(s.ctx, s.session, guildID, channelID, result); err != nil {
+		utils.Error("길드 %s 대회 %d 최종 결과 발표 실패: %v", guildID, result.Competition.ID, err)
+		return
+	}
+	utils.Info("길드 %s에 대회 %d 최종 결과를 발표했습니다", guildID, result.Competition.ID)
+}
+
+// announcePendingResults 봇이 꺼지는 등으로 발표하지 못한 최종 결과를 순차 발표 없이 한 번에 발표합니다
+func (s *Scheduler) announcePendingResults() {
+	for _, guildID := range s.storages.GuildIDs() {
+		for _, result := range s.scoreboardManager.PendingAnnouncements(guildID) {
+			channelID := s.announcementChannel(guildID)
+			if channelID == "" {
+				s.scoreboardManager.CancelReveal(guildID, result.Competition.ID)
+				continue
+			}
+			if err := s.scoreboardManager.AnnounceFinalResults(s.ctx, s.session, guildID, channelID, result); err != nil {
+				utils.Error("길드 %s 대회 %d 발표하지 못한 최종 결과 발표 실패: %v", guildID, result.Competition.ID, err)
+				continue
+			}
+			utils.Info("길드 %s에 발표하지 못했던 대회 %d 최종 결과를 발표했습니다", guildID, result.Competition.ID)
+		}
+	}
+}
+
+func (s *Scheduler) backupAll(retention int) {
+	for _, guildID := range s.storages.GuildIDs() {
+		storage, err := s.storages.ForGuild(guildID)
+		if err != nil {
+			utils.Warn("길드 %s 저장소를 열 수 없습니다: %v", guildID, err)
+			continue
+		}
+
+		if _, err := storage.CreateBackup(); err != nil {
+			utils.Error("길드 %s 자동 백업 실패: %v", guildID, err)
+			continue
+		}
+		if err := storage.PruneBackups(retention); err != nil {
+			utils.Warn("길드 %s 오래된 백업 정리 실패: %v", guildID, err)
+		}
+	}
+}
+
+func (s *Scheduler) refreshScores() {
+	for _, guildID := range s.storages.GuildIDs() {
+		for _, competition := range s.scoreboardManager.ActiveCompetitions(guildID) {
+			if s.ctx.Err() != nil {
+				return
+			}
+			if _, err := s.scoreboardManager.RefreshScores(s.ctx, guildID, competition.ID); err != nil {
+				utils.Warn("길드 %s 대회 %d 백그라운드 점수 갱신 실패: %v", guildID, competition.ID, err)
+			}
+		}
+	}
+}
+
+func (s *Scheduler) sendDailyScoreboard() {
+	for _, guildID := range s.storages.GuildIDs() {
+		channelID := s.announcementChannel(guildID)
+		if channelID == "" {
+			utils.Debug("길드 %s에 공지 채널이 설정되지 않아 스코어보드를 전송하지 않습니다", guildID)
+			continue
+		}
+
+		err := s.scoreboardManager.SendDailyScoreboard(s.ctx, s.session, guildID, channelID)
+		if err != nil {
+			utils.Error("길드 %s 일일 스코어보드 전송 실패: %v", guildID, err)
+			continue
+		}
+
+		utils.Info("길드 %s에 일일 스코어보드를 성공적으로 전송했습니다", guildID)
+	}
+}
+
+// announcementChannel 길드의 공지 채널을 반환합니다.
+// 길드 설정이 없으면 이전 버전 호환을 위해 DISCORD_CHANNEL_ID를 해당 길드의 기본값으로 사용합니다.
+func (s *Scheduler) announcementChannel(guildID string) string {
+	storage, err := s.storages.ForGuild(guildID)
+	if err != nil {
+		utils.Warn("길드 %s 저장소를 열 수 없습니다: %v", guildID, err)
+		return ""
+	}
+
+	if channelID := storage.GetGuildSettings().AnnouncementChannelID; channelID != "" {
+		return channelID
+	}
+	if guildID == s.config.Discord.LegacyGuildID {
+		return s.config.Discord.ChannelID
+	}
+	return ""
 }
 
 func (s *Scheduler) Stop() {
+	// 진행 중인 점수 계산, 최종 결과 확정, 순차 발표를 먼저 멈춥니다
+	s.cancel()
+
 	if s.ticker != nil {
 		s.ticker.Stop()
 	}
 
 	s.stopCustomScheduler()
 
+	if s.pollTicker != nil {
+		s.pollTicker.Stop()
+		close(s.pollStopChan)
+		s.pollTicker = nil
+	}
+
+	if s.backupTicker != nil {
+		s.backupTicker.Stop()
+		close(s.backupStopChan)
+		s.backupTicker = nil
+	}
+
+	if s.snapshotWatching {
+		close(s.snapshotStopChan)
+		s.snapshotWatching = false
+	}
+
+	if s.resultsWatching {
+		close(s.resultsStopChan)
+		s.resultsWatching = false
+	}
+
 	select {
 	case s.stopChan <- true:
 	default:
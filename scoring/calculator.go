@@ -1,7 +1,8 @@
 package scoring
 
 import (
-	"discord-bot/constants"
+	"context"
+	"discord-bot/api"
 	"discord-bot/interfaces"
 	"discord-bot/models"
 	"math"
@@ -19,48 +20,66 @@ func NewScoreCalculator(apiClient interfaces.APIClient) interfaces.ScoreCalculat
 	}
 }
 
-func (sc *ScoreCalculator) CalculateScore(handle string, startTier int, startProblemIDs []int) (float64, error) {
-	top100, err := sc.client.GetUserTop100(handle)
+func (sc *ScoreCalculator) CalculateScore(ctx context.Context, handle string, startTier int, startProblemIDs []int, profile models.ScoringProfile) (float64, error) {
+	solved, err := sc.client.GetUserSolvedProblems(ctx, handle)
 	if err != nil {
 		return 0, err
 	}
 
+	return sc.CalculateScoreFromProblems(solved.Items, startTier, startProblemIDs, profile), nil
+}
+
+// CalculateScoreFromProblems 이미 조회한 해결 문제 목록으로 대회의 점수 계산 규칙에 따라 점수를 계산합니다
+func (sc *ScoreCalculator) CalculateScoreFromProblems(problems []api.ProblemInfo, startTier int, startProblemIDs []int, profile models.ScoringProfile) float64 {
+	return sc.BreakdownFromProblems(problems, startTier, startProblemIDs, profile).Total
+}
+
+// BreakdownFromProblems 문제마다 기본 점수, 가중치, 반영 점수를 계산한 내역을 반환합니다.
+// 점수표 점수가 0인 문제(언랭크)는 내역에서 제외합니다.
+func (sc *ScoreCalculator) BreakdownFromProblems(problems []api.ProblemInfo, startTier int, startProblemIDs []int, profile models.ScoringProfile) models.ScoreBreakdown {
 	// 시작 시점 문제 ID들을 맵으로 변환
 	startProblemsMap := make(map[int]bool)
 	for _, id := range startProblemIDs {
 		startProblemsMap[id] = true
 	}
 
-	totalScore := 0.0
+	breakdown := models.ScoreBreakdown{}
+	for _, problem := range problems {
+		points := profile.PointsFor(sc.tierManager, problem.Level)
+		if points == 0 {
+			continue
+		}
+
+		entry := models.ProblemScore{
+			ProblemID:  problem.ProblemID,
+			Title:      problem.TitleKo,
+			Level:      problem.Level,
+			BasePoints: points,
+		}
 
-	for _, problem := range top100.Items {
 		// 참가 시점에 이미 해결한 문제는 제외
 		if startProblemsMap[problem.ProblemID] {
+			breakdown.PreSolved = append(breakdown.PreSolved, entry)
 			continue
 		}
 
-		problemTier := problem.Level
-		points := sc.tierManager.GetTierPoints(problemTier)
-		if points == 0 {
-			continue
+		entry.Weight, entry.Category = profile.WeightFor(problem.Level, startTier)
+		entry.Points = float64(points) * entry.Weight
+		if profile.MaxProblemPoints > 0 && entry.Points > profile.MaxProblemPoints {
+			entry.Points = profile.MaxProblemPoints
+			entry.Capped = true
 		}
-
-		weight := sc.getWeight(problemTier, startTier)
-		score := float64(points) * weight
-		totalScore += score
+		breakdown.Counted = append(breakdown.Counted, entry)
+		breakdown.Subtotal += entry.Points
 	}
 
-	return math.Round(totalScore), nil
-}
-
-func (sc *ScoreCalculator) getWeight(problemTier, startTier int) float64 {
-	if problemTier > startTier {
-		return constants.ChallengeMultiplier
-	} else if problemTier == startTier {
-		return constants.BaseMultiplier
-	} else {
-		return constants.PenaltyMultiplier
+	total := breakdown.Subtotal
+	if profile.MaxTotalScore > 0 && total > profile.MaxTotalScore {
+		total = profile.MaxTotalScore
+		breakdown.TotalCapped = true
 	}
+	breakdown.Total = math.Round(total)
+	return breakdown
 }
 
 // GetTierName 티어 번호에 해당하는 티어 이름을 반환합니다
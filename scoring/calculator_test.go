@@ -0,0 +1,112 @@
+package scoring
+
+import (
+	"discord-bot/api"
+	"discord-bot/models"
+	"testing"
+)
+
+func TestCalculateScoreFromProblemsUsesProfile(t *testing.T) {
+	sc := &ScoreCalculator{tierManager: models.NewTierManager()}
+	problems := []api.ProblemInfo{
+		{ProblemID: 1, Level: 11}, // 시작 티어와 같음 (Gold V, 기본 18점)
+		{ProblemID: 2, Level: 12}, // 상위 티어 (Gold IV, 기본 20점)
+		{ProblemID: 3, Level: 5},  // 하위 티어 (Bronze I, 기본 5점)
+		{ProblemID: 4, Level: 15}, // 시작 시점에 이미 해결
+	}
+	startTier := 11
+	startProblems := []int{4}
+
+	tests := []struct {
+		name    string
+		profile func() models.ScoringProfile
+		want    float64
+	}{
+		{
+			name:    "default profile",
+			profile: models.DefaultScoringProfile,
+			want:    18 + 28 + 3, // 18×1.0 + 20×1.4 + 5×0.5 = 48.5 → 반올림
+		},
+		{
+			name: "overridden points and multipliers",
+			profile: func() models.ScoringProfile {
+				p := models.DefaultScoringProfile()
+				p.TierPoints = map[int]int{11: 30}
+				p.SetWeightBands([]models.WeightBand{
+					{MinDistance: -models.MaxTierLevel, MaxDistance: -1, Weight: 0},
+					{MinDistance: 0, MaxDistance: 0, Weight: 1},
+					{MinDistance: 1, MaxDistance: models.MaxTierLevel, Weight: 2},
+				})
+				return p
+			},
+			want: 30 + 40,
+		},
+		{
+			name: "distance bands treat nearby tiers as base",
+			profile: func() models.ScoringProfile {
+				p := models.DefaultScoringProfile()
+				p.SetWeightBands([]models.WeightBand{
+					{MinDistance: 3, MaxDistance: models.MaxTierLevel, Weight: 1.4},
+					{MinDistance: -2, MaxDistance: 2, Weight: 1},
+					{MinDistance: -models.MaxTierLevel, MaxDistance: -3, Weight: 0.5},
+				})
+				return p
+			},
+			want: 18 + 20 + 3, // Gold IV는 1티어 차이이므로 기본, Bronze I은 6티어 아래
+		},
+		{
+			name: "per-problem cap",
+			profile: func() models.ScoringProfile {
+				p := models.DefaultScoringProfile()
+				p.MaxProblemPoints = 20
+				return p
+			},
+			want: 18 + 20 + 3,
+		},
+		{
+			name: "total cap",
+			profile: func() models.ScoringProfile {
+				p := models.DefaultScoringProfile()
+				p.MaxTotalScore = 40
+				return p
+			},
+			want: 40,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sc.CalculateScoreFromProblems(problems, startTier, startProblems, tt.profile())
+			if got != tt.want {
+				t.Fatalf("score = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakdownFromProblems(t *testing.T) {
+	sc := &ScoreCalculator{tierManager: models.NewTierManager()}
+	problems := []api.ProblemInfo{
+		{ProblemID: 1000, Level: 1, TitleKo: "A+B"},
+		{ProblemID: 1001, Level: 12, TitleKo: "도전 문제"},
+		{ProblemID: 1002, Level: 0}, // 언랭크는 내역에서 제외
+	}
+	profile := models.DefaultScoringProfile()
+	profile.MaxProblemPoints = 25
+
+	breakdown := sc.BreakdownFromProblems(problems, 11, []int{1000}, profile)
+	if len(breakdown.PreSolved) != 1 || breakdown.PreSolved[0].ProblemID != 1000 {
+		t.Fatalf("pre-solved = %+v", breakdown.PreSolved)
+	}
+	if len(breakdown.Counted) != 1 {
+		t.Fatalf("counted = %+v", breakdown.Counted)
+	}
+	got := breakdown.Counted[0]
+	if got.Title != "도전 문제" || got.BasePoints != 20 || got.Weight != 1.4 ||
+		got.Category != models.WeightChallenge || got.Points != 25 || !got.Capped {
+		t.Fatalf("counted problem = %+v", got)
+	}
+	if breakdown.Total != 25 {
+		t.Fatalf("total = %v, want 25", breakdown.Total)
+	}
+}
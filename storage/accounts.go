@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"discord-bot/constants"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// errAccountLinkedToOther 다른 디스코드 사용자가 이미 인증한 백준 ID를 연동하려 할 때의 오류를 만듭니다
+func errAccountLinkedToOther(baekjoonID string) error {
+	return fmt.Errorf("백준 ID %s은(는) 이미 다른 디스코드 계정에 연동되어 있습니다", baekjoonID)
+}
+
+// GetAccountLink 디스코드 사용자의 계정 연동을 반환합니다 (없으면 nil)
+func (s *Storage) GetAccountLink(discordUserID string) *models.AccountLink {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.findAccountLink(discordUserID)
+}
+
+func (s *Storage) findAccountLink(discordUserID string) *models.AccountLink {
+	for _, link := range s.accounts {
+		if link.DiscordUserID == discordUserID {
+			found := link
+			return &found
+		}
+	}
+	return nil
+}
+
+// FindAccountLinkByBaekjoonID 백준 ID를 인증한 계정 연동을 반환합니다 (없으면 nil)
+func (s *Storage) FindAccountLinkByBaekjoonID(baekjoonID string) *models.AccountLink {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.findAccountLinkByBaekjoonID(baekjoonID)
+}
+
+func (s *Storage) findAccountLinkByBaekjoonID(baekjoonID string) *models.AccountLink {
+	for _, link := range s.accounts {
+		if strings.EqualFold(link.BaekjoonID, baekjoonID) {
+			found := link
+			return &found
+		}
+	}
+	return nil
+}
+
+// LinkAccount 소유를 인증한 백준 ID를 디스코드 사용자에 연동합니다. 기존 연동은 새 백준 ID로 바뀝니다
+func (s *Storage) LinkAccount(discordUserID, baekjoonID string) error {
+	if !utils.IsValidBaekjoonID(baekjoonID) {
+		return fmt.Errorf("잘못된 백준 ID: %s", baekjoonID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing := s.findAccountLinkByBaekjoonID(baekjoonID); existing != nil && existing.DiscordUserID != discordUserID {
+		return errAccountLinkedToOther(baekjoonID)
+	}
+
+	link := models.AccountLink{DiscordUserID: discordUserID, BaekjoonID: baekjoonID, VerifiedAt: time.Now()}
+	s.accounts = append(removeAccountLink(s.accounts, discordUserID), link)
+	utils.Info("Linked discord user %s to baekjoon ID %s", discordUserID, baekjoonID)
+	return s.saveAccounts()
+}
+
+// UnlinkAccount 디스코드 사용자의 계정 연동을 해제합니다
+func (s *Storage) UnlinkAccount(discordUserID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	remaining := removeAccountLink(s.accounts, discordUserID)
+	if len(remaining) == len(s.accounts) {
+		return fmt.Errorf("연동된 백준 계정이 없습니다")
+	}
+	s.accounts = remaining
+	utils.Info("Unlinked discord user %s", discordUserID)
+	return s.saveAccounts()
+}
+
+func removeAccountLink(accounts []models.AccountLink, discordUserID string) []models.AccountLink {
+	remaining := make([]models.AccountLink, 0, len(accounts))
+	for _, link := range accounts {
+		if link.DiscordUserID != discordUserID {
+			remaining = append(remaining, link)
+		}
+	}
+	return remaining
+}
+
+// saveAccounts 호출자가 mu를 잡은 상태에서 계정 연동 목록을 저장합니다
+func (s *Storage) saveAccounts() error {
+	return s.saveDataFile(constants.AccountsFileName, s.accounts)
+}
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// writeFileAtomic 같은 디렉터리의 임시 파일에 쓰고 fsync한 뒤 이름을 바꿔 파일을 교체합니다.
+// 쓰는 도중 프로세스가 종료되어도 기존 파일이나 새 파일 중 하나가 온전히 남습니다.
+func writeFileAtomic(fileName string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(fileName)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(fileName)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	// 이름 변경에 성공하면 tmpName이 더 이상 없으므로 제거는 실패해도 무방합니다
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpName, fileName); err != nil {
+		return err
+	}
+
+	return syncDir(dir)
+}
+
+// syncDir 이름 변경이 디스크에 반영되도록 디렉터리를 fsync합니다
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	// 일부 플랫폼(Windows 등)은 디렉터리 fsync를 지원하지 않으므로 오류를 무시합니다
+	d.Sync()
+	return nil
+}
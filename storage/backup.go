@@ -0,0 +1,289 @@
+package storage
+
+import (
+	"discord-bot/constants"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// backupIDPattern 백업 ID 형식입니다. 사용자가 입력한 ID로 경로를 만들기 때문에 엄격하게 검사합니다
+var backupIDPattern = regexp.MustCompile(`^[0-9]{8}-[0-9]{6}(-[0-9]+)?$`)
+
+// backupFileNames JSON 저장소가 백업하는 파일 목록입니다
+var backupFileNames = []string{
+	constants.CompetitionsFileName,
+	constants.SolvesFileName,
+	constants.SettingsFileName,
+	constants.AccountsFileName,
+	constants.HandleChangeFileName,
+	constants.AuditLogFileName,
+	constants.RegistrationFileName,
+	constants.ResultsFileName,
+}
+
+// backupsDir 데이터 디렉터리의 백업 보관 위치를 반환합니다
+func backupsDir(dataDir string) string {
+	return filepath.Join(dataDir, constants.BackupsDirName)
+}
+
+// backupPath 백업 ID에 해당하는 디렉터리를 반환합니다
+func backupPath(dataDir, backupID string) (string, error) {
+	if !backupIDPattern.MatchString(backupID) {
+		return "", fmt.Errorf("잘못된 백업 ID: %q", backupID)
+	}
+
+	dir := filepath.Join(backupsDir(dataDir), backupID)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("백업 %s을(를) 찾을 수 없습니다", backupID)
+	}
+	return dir, nil
+}
+
+// newBackupDir 현재 시각으로 새 백업 디렉터리를 만듭니다. 같은 초에 만든 백업이 있으면 번호를 붙입니다
+func newBackupDir(dataDir string) (string, string, error) {
+	base := time.Now().Format(constants.BackupIDFormat)
+	for n := 0; ; n++ {
+		backupID := base
+		if n > 0 {
+			backupID = base + "-" + strconv.Itoa(n)
+		}
+
+		dir := filepath.Join(backupsDir(dataDir), backupID)
+		if err := os.MkdirAll(filepath.Dir(dir), constants.DirPermission); err != nil {
+			return "", "", err
+		}
+		err := os.Mkdir(dir, constants.DirPermission)
+		if err == nil {
+			return backupID, dir, nil
+		}
+		if !os.IsExist(err) {
+			return "", "", err
+		}
+	}
+}
+
+// listBackups 데이터 디렉터리의 백업을 최신순으로 반환합니다
+func listBackups(dataDir string) ([]models.BackupInfo, error) {
+	entries, err := os.ReadDir(backupsDir(dataDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []models.BackupInfo{}, nil
+		}
+		return nil, err
+	}
+
+	backups := []models.BackupInfo{}
+	for _, entry := range entries {
+		if !entry.IsDir() || !backupIDPattern.MatchString(entry.Name()) {
+			continue
+		}
+		backups = append(backups, readBackupInfo(dataDir, entry.Name()))
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].ID > backups[j].ID // ID가 생성 시각 순서이므로 문자열 비교로 충분
+	})
+	return backups, nil
+}
+
+func readBackupInfo(dataDir, backupID string) models.BackupInfo {
+	info := models.BackupInfo{ID: backupID}
+	if createdAt, err := time.ParseInLocation(constants.BackupIDFormat, backupID[:len(constants.BackupIDFormat)], time.Local); err == nil {
+		info.CreatedAt = createdAt
+	}
+
+	files, err := os.ReadDir(filepath.Join(backupsDir(dataDir), backupID))
+	if err != nil {
+		return info
+	}
+	for _, file := range files {
+		if fileInfo, err := file.Info(); err == nil && !file.IsDir() {
+			info.Size += fileInfo.Size()
+		}
+	}
+	return info
+}
+
+// pruneBackups 최근 keep개를 제외한 오래된 백업을 삭제합니다
+func pruneBackups(dataDir string, keep int) error {
+	backups, err := listBackups(dataDir)
+	if err != nil {
+		return err
+	}
+	if keep < 1 || len(backups) <= keep {
+		return nil
+	}
+
+	for _, backup := range backups[keep:] {
+		if err := os.RemoveAll(filepath.Join(backupsDir(dataDir), backup.ID)); err != nil {
+			return fmt.Errorf("백업 %s 삭제 실패: %w", backup.ID, err)
+		}
+		utils.Debug("Pruned old backup %s in %s", backup.ID, dataDir)
+	}
+	utils.Info("Pruned %d old backups in %s", len(backups)-keep, dataDir)
+	return nil
+}
+
+// recoverFromBackups 손상된 데이터 파일 대신 최신 백업부터 차례로 읽을 수 있는 복사본을 찾습니다
+func recoverFromBackups(dataDir, fileName string, v interface{}) bool {
+	backups, err := listBackups(dataDir)
+	if err != nil {
+		utils.Error("Failed to list backups for recovery: %v", err)
+		return false
+	}
+
+	for _, backup := range backups {
+		if readJSONFile(filepath.Join(backupsDir(dataDir), backup.ID, fileName), v) {
+			utils.Warn("Recovered %s from backup %s", fileName, backup.ID)
+			return true
+		}
+	}
+	return false
+}
+
+// CreateBackup 현재 메모리 상태를 새 백업으로 저장합니다
+func (s *Storage) CreateBackup() (models.BackupInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.createBackup()
+}
+
+// createBackup 호출자가 mu를 잡은 상태에서 백업을 만듭니다.
+// 디스크 파일을 복사하지 않고 메모리 상태를 직렬화하므로 모든 파일이 항상 같은 시점을 가리킵니다.
+func (s *Storage) createBackup() (models.BackupInfo, error) {
+	backupID, dir, err := newBackupDir(s.dataDir)
+	if err != nil {
+		utils.Error("Failed to create backup directory: %v", err)
+		return models.BackupInfo{}, err
+	}
+
+	contents := map[string]interface{}{
+		constants.CompetitionsFileName: s.competitions,
+		constants.SolvesFileName:       s.solves,
+		constants.SettingsFileName:     s.settings,
+		constants.AccountsFileName:     s.accounts,
+		constants.HandleChangeFileName: s.changes,
+		constants.AuditLogFileName:     s.auditLog,
+		constants.RegistrationFileName: s.applications,
+		constants.ResultsFileName:      s.results,
+	}
+	for fileName, v := range contents {
+		data, err := json.MarshalIndent(v, "", constants.JSONIndentSpaces)
+		if err != nil {
+			os.RemoveAll(dir)
+			return models.BackupInfo{}, err
+		}
+		if err := writeFileAtomic(filepath.Join(dir, fileName), data, constants.FilePermission); err != nil {
+			utils.Error("Failed to write backup file %s: %v", fileName, err)
+			os.RemoveAll(dir)
+			return models.BackupInfo{}, err
+		}
+	}
+
+	utils.Info("Created backup %s in %s", backupID, s.dataDir)
+	return readBackupInfo(s.dataDir, backupID), nil
+}
+
+// ListBackups 백업 목록을 최신순으로 반환합니다
+func (s *Storage) ListBackups() ([]models.BackupInfo, error) {
+	return listBackups(s.dataDir)
+}
+
+// PruneBackups 최근 keep개를 제외한 오래된 백업을 삭제합니다
+func (s *Storage) PruneBackups(keep int) error {
+	return pruneBackups(s.dataDir, keep)
+}
+
+// RestoreBackup 지정된 백업으로 데이터를 되돌립니다.
+// 되돌리기 전에 현재 상태를 새 백업으로 남기므로 복원도 다시 되돌릴 수 있습니다.
+func (s *Storage) RestoreBackup(backupID string) error {
+	dir, err := backupPath(s.dataDir, backupID)
+	if err != nil {
+		return err
+	}
+
+	// 백업을 모두 읽어 검증한 뒤에만 현재 상태를 교체합니다
+	competitions := []*models.Competition{}
+	solves := []models.SolveRecord{}
+	settings := models.GuildSettings{}
+	accounts := []models.AccountLink{}
+	changes := []models.HandleChangeRequest{}
+	auditLog := []models.AuditEntry{}
+	applications := []models.RegistrationRequest{}
+	results := []models.FinalStandings{}
+	targets := map[string]interface{}{
+		constants.CompetitionsFileName: &competitions,
+		constants.SolvesFileName:       &solves,
+		constants.SettingsFileName:     &settings,
+		constants.AccountsFileName:     &accounts,
+		constants.HandleChangeFileName: &changes,
+		constants.AuditLogFileName:     &auditLog,
+		constants.RegistrationFileName: &applications,
+		constants.ResultsFileName:      &results,
+	}
+	for _, fileName := range backupFileNames {
+		data, err := os.ReadFile(filepath.Join(dir, fileName))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		if err := json.Unmarshal(data, targets[fileName]); err != nil {
+			return fmt.Errorf("백업 파일 %s이(가) 손상되었습니다: %w", fileName, err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.createBackup(); err != nil {
+		return fmt.Errorf("복원 전 현재 상태 백업 실패: %w", err)
+	}
+
+	s.competitions = competitions
+	s.solves, _ = scopeLegacySolves(competitions, solves)
+	s.settings = settings
+	s.accounts = accounts
+	s.changes = changes
+	s.auditLog = auditLog
+	s.applications = applications
+	s.results = results
+	s.rebuildSolveIndex()
+
+	if err := s.saveCompetitions(); err != nil {
+		return err
+	}
+	if err := s.saveSolves(); err != nil {
+		return err
+	}
+	if err := s.saveSettings(); err != nil {
+		return err
+	}
+	if err := s.saveAccounts(); err != nil {
+		return err
+	}
+	if err := s.saveDataFile(constants.HandleChangeFileName, s.changes); err != nil {
+		return err
+	}
+	if err := s.saveDataFile(constants.AuditLogFileName, s.auditLog); err != nil {
+		return err
+	}
+	if err := s.saveDataFile(constants.RegistrationFileName, s.applications); err != nil {
+		return err
+	}
+	if err := s.saveDataFile(constants.ResultsFileName, s.results); err != nil {
+		return err
+	}
+
+	utils.Info("Restored backup %s in %s", backupID, s.dataDir)
+	return nil
+}
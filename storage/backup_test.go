@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"context"
+	"discord-bot/constants"
+	"discord-bot/interfaces"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		competition := newTestCompetition(t, s)
+		if err := s.AddParticipant(context.Background(), competition.ID, "참가자", "kept", "", 1, 100); err != nil {
+			t.Fatalf("AddParticipant: %v", err)
+		}
+
+		backup, err := s.CreateBackup()
+		if err != nil {
+			t.Fatalf("CreateBackup: %v", err)
+		}
+
+		if err := s.AddParticipant(context.Background(), competition.ID, "참가자", "dropped", "", 1, 100); err != nil {
+			t.Fatalf("AddParticipant: %v", err)
+		}
+		if err := s.RestoreBackup(backup.ID); err != nil {
+			t.Fatalf("RestoreBackup: %v", err)
+		}
+
+		participants := s.GetParticipants(competition.ID)
+		if len(participants) != 1 || participants[0].BaekjoonID != "kept" {
+			t.Fatalf("participants after restore = %+v", participants)
+		}
+
+		// 복원 전 상태도 백업으로 남아야 합니다
+		backups, err := s.ListBackups()
+		if err != nil {
+			t.Fatalf("ListBackups: %v", err)
+		}
+		if len(backups) != 2 {
+			t.Fatalf("backups = %d, want 2", len(backups))
+		}
+
+		if err := s.PruneBackups(1); err != nil {
+			t.Fatalf("PruneBackups: %v", err)
+		}
+		if backups, _ := s.ListBackups(); len(backups) != 1 {
+			t.Fatalf("backups after prune = %d, want 1", len(backups))
+		}
+	})
+}
+
+func TestRestoreBackupRejectsInvalidID(t *testing.T) {
+	s, err := NewStorage(fakeAPIClient{}, t.TempDir(), "")
+	if err != nil {
+		t.Fatalf("new storage: %v", err)
+	}
+	for _, id := range []string{"../guilds", "20240101-000000", ""} {
+		if err := s.RestoreBackup(id); err == nil {
+			t.Errorf("RestoreBackup(%q) succeeded, want error", id)
+		}
+	}
+}
+
+func TestCorruptedFileRecoversFromLatestBackup(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewStorage(fakeAPIClient{}, dir, "")
+	if err != nil {
+		t.Fatalf("new storage: %v", err)
+	}
+	competition := newTestCompetition(t, s)
+	if err := s.AddParticipant(context.Background(), competition.ID, "참가자", "survivor", "", 1, 100); err != nil {
+		t.Fatalf("AddParticipant: %v", err)
+	}
+	if _, err := s.CreateBackup(); err != nil {
+		t.Fatalf("CreateBackup: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, constants.CompetitionsFileName), []byte("{broken"), constants.FilePermission); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := NewStorage(fakeAPIClient{}, dir, "")
+	if err != nil {
+		t.Fatalf("reload storage: %v", err)
+	}
+	participants := reloaded.GetParticipants(competition.ID)
+	if len(participants) != 1 || participants[0].BaekjoonID != "survivor" {
+		t.Fatalf("participants after recovery = %+v", participants)
+	}
+}
+
+func TestSQLiteRestoreMigratesOlderBackup(t *testing.T) {
+	// 첫 번째 스키마 버전으로만 만든 데이터베이스에서 백업을 만듭니다.
+	// 이후 버전에 추가된 테이블을 읽는 JSON 가져오기는 건너뛰도록 저장소를 직접 만듭니다.
+	allMigrations := migrations
+	migrations = allMigrations[:1]
+	dir := t.TempDir()
+	db, err := openSQLiteDB(dir)
+	migrations = allMigrations
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	s := &SQLiteStorage{db: db, apiClient: fakeAPIClient{}, dataDir: dir}
+	backup, err := s.CreateBackup()
+	db.Close()
+	if err != nil {
+		t.Fatalf("CreateBackup: %v", err)
+	}
+
+	upgraded, err := NewSQLiteStorage(fakeAPIClient{}, dir, "")
+	if err != nil {
+		t.Fatalf("reopen storage: %v", err)
+	}
+	newTestCompetition(t, upgraded)
+
+	if err := upgraded.RestoreBackup(backup.ID); err != nil {
+		t.Fatalf("RestoreBackup: %v", err)
+	}
+	if competitions := upgraded.GetCompetitions(); len(competitions) != 0 {
+		t.Fatalf("competitions after restore = %d, want 0", len(competitions))
+	}
+	if matches, _ := filepath.Glob(filepath.Join(dir, "restore-*.db")); len(matches) != 0 {
+		t.Fatalf("temporary restore files left behind: %v", matches)
+	}
+}
@@ -0,0 +1,252 @@
+package storage
+
+import (
+	"database/sql"
+	"discord-bot/utils"
+	"fmt"
+	"time"
+)
+
+// migration 데이터베이스 스키마의 한 버전입니다
+type migration struct {
+	version     int
+	description string
+	statements  []string
+}
+
+// migrations 적용 순서대로 나열된 스키마 마이그레이션 목록입니다.
+// 이미 배포된 항목은 수정하지 말고 새 버전을 추가하세요.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "initial schema",
+		statements: []string{
+			`CREATE TABLE competitions (
+				id                  INTEGER PRIMARY KEY,
+				name                TEXT    NOT NULL,
+				start_date          TEXT    NOT NULL,
+				end_date            TEXT    NOT NULL,
+				blackout_start_date TEXT    NOT NULL,
+				is_active           INTEGER NOT NULL DEFAULT 1,
+				show_scoreboard     INTEGER NOT NULL DEFAULT 1
+			)`,
+			`CREATE TABLE participants (
+				competition_id      INTEGER NOT NULL REFERENCES competitions(id) ON DELETE CASCADE,
+				id                  INTEGER NOT NULL,
+				name                TEXT    NOT NULL,
+				baekjoon_id         TEXT    NOT NULL,
+				start_tier          INTEGER NOT NULL,
+				start_rating        INTEGER NOT NULL,
+				created_at          TEXT    NOT NULL,
+				start_problem_count INTEGER NOT NULL,
+				PRIMARY KEY (competition_id, id),
+				UNIQUE (competition_id, baekjoon_id)
+			)`,
+			`CREATE TABLE participant_start_problems (
+				competition_id INTEGER NOT NULL,
+				participant_id INTEGER NOT NULL,
+				problem_id     INTEGER NOT NULL,
+				PRIMARY KEY (competition_id, participant_id, problem_id),
+				FOREIGN KEY (competition_id, participant_id)
+					REFERENCES participants(competition_id, id) ON DELETE CASCADE
+			)`,
+			`CREATE TABLE solves (
+				baekjoon_id    TEXT    NOT NULL,
+				problem_id     INTEGER NOT NULL,
+				participant_id INTEGER NOT NULL,
+				level          INTEGER NOT NULL,
+				first_seen_at  TEXT    NOT NULL,
+				PRIMARY KEY (baekjoon_id, problem_id)
+			)`,
+			`CREATE INDEX idx_solves_first_seen_at ON solves(first_seen_at)`,
+			`CREATE TABLE guild_settings (
+				key   TEXT PRIMARY KEY,
+				value TEXT NOT NULL
+			)`,
+		},
+	},
+	{
+		version:     2,
+		description: "per-competition scoring profile",
+		statements: []string{
+			// JSON으로 직렬화한 models.ScoringProfile (NULL이면 기본 규칙)
+			`ALTER TABLE competitions ADD COLUMN scoring TEXT`,
+		},
+	},
+	{
+		version:     3,
+		description: "participant discord user id",
+		statements: []string{
+			// 등록한 디스코드 사용자 ID (이전에 등록된 참가자는 빈 문자열)
+			`ALTER TABLE participants ADD COLUMN discord_user_id TEXT NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		version:     4,
+		description: "verified discord account links",
+		statements: []string{
+			`CREATE TABLE account_links (
+				discord_user_id TEXT PRIMARY KEY,
+				baekjoon_id     TEXT NOT NULL UNIQUE COLLATE NOCASE,
+				verified_at     TEXT NOT NULL
+			)`,
+		},
+	},
+	{
+		version:     5,
+		description: "handle change requests and participant audit log",
+		statements: []string{
+			`CREATE TABLE handle_change_requests (
+				id              INTEGER PRIMARY KEY,
+				competition_id  INTEGER NOT NULL REFERENCES competitions(id) ON DELETE CASCADE,
+				participant_id  INTEGER NOT NULL,
+				old_baekjoon_id TEXT    NOT NULL,
+				new_baekjoon_id TEXT    NOT NULL,
+				requested_by    TEXT    NOT NULL,
+				status          TEXT    NOT NULL,
+				requested_at    TEXT    NOT NULL,
+				resolved_by     TEXT    NOT NULL DEFAULT '',
+				resolved_at     TEXT    NOT NULL DEFAULT ''
+			)`,
+			// 참가자가 삭제된 뒤에도 남아야 하므로 외래 키를 두지 않습니다
+			`CREATE TABLE audit_log (
+				id             INTEGER PRIMARY KEY,
+				competition_id INTEGER NOT NULL,
+				participant_id INTEGER NOT NULL,
+				action         TEXT    NOT NULL,
+				actor_id       TEXT    NOT NULL,
+				old_value      TEXT    NOT NULL DEFAULT '',
+				new_value      TEXT    NOT NULL DEFAULT '',
+				created_at     TEXT    NOT NULL
+			)`,
+			`CREATE INDEX idx_audit_log_competition ON audit_log(competition_id)`,
+		},
+	},
+	{
+		version:     6,
+		description: "registration windows and approval queue",
+		statements: []string{
+			// NULL이면 등록 기간 제한과 승인 절차가 없는 기본 방식
+			`ALTER TABLE competitions ADD COLUMN registration TEXT`,
+			`ALTER TABLE participants ADD COLUMN snapshot_pending INTEGER NOT NULL DEFAULT 0`,
+			`CREATE TABLE registration_requests (
+				id              INTEGER PRIMARY KEY,
+				competition_id  INTEGER NOT NULL REFERENCES competitions(id) ON DELETE CASCADE,
+				name            TEXT    NOT NULL,
+				baekjoon_id     TEXT    NOT NULL,
+				discord_user_id TEXT    NOT NULL,
+				status          TEXT    NOT NULL,
+				requested_at    TEXT    NOT NULL,
+				resolved_by     TEXT    NOT NULL DEFAULT '',
+				resolved_at     TEXT    NOT NULL DEFAULT ''
+			)`,
+		},
+	},
+	{
+		version:     7,
+		description: "final standings",
+		statements: []string{
+			`ALTER TABLE competitions ADD COLUMN finalized_at TEXT NOT NULL DEFAULT ''`,
+			// 확정된 순위는 참가자가 삭제되어도 바뀌지 않아야 하므로 참가자 외래 키를 두지 않습니다
+			`CREATE TABLE final_standings (
+				competition_id INTEGER NOT NULL REFERENCES competitions(id) ON DELETE CASCADE,
+				rank           INTEGER NOT NULL,
+				participant_id INTEGER NOT NULL,
+				name           TEXT    NOT NULL,
+				baekjoon_id    TEXT    NOT NULL,
+				score          REAL    NOT NULL,
+				current_tier   INTEGER NOT NULL,
+				current_rating INTEGER NOT NULL,
+				problem_count  INTEGER NOT NULL,
+				PRIMARY KEY (competition_id, rank)
+			)`,
+		},
+	},
+	{
+		version:     8,
+		description: "staged final results reveal",
+		statements: []string{
+			// NULL이면 최종 결과를 한 번에 발표하는 기본 방식
+			`ALTER TABLE competitions ADD COLUMN reveal TEXT`,
+		},
+	},
+	{
+		version:     9,
+		description: "per-competition solve ledger",
+		statements: []string{
+			`CREATE TABLE competition_solves (
+				competition_id INTEGER NOT NULL REFERENCES competitions(id) ON DELETE CASCADE,
+				participant_id INTEGER NOT NULL,
+				baekjoon_id    TEXT    NOT NULL,
+				problem_id     INTEGER NOT NULL,
+				level          INTEGER NOT NULL,
+				first_seen_at  TEXT    NOT NULL,
+				backlog        INTEGER NOT NULL DEFAULT 0,
+				PRIMARY KEY (competition_id, baekjoon_id, problem_id)
+			)`,
+			// 대회 구분 없던 기록은 그 백준 ID로 등록된 대회마다 나누되, 참가자의 시작 시점 기록에 있는 문제는 제외합니다
+			`INSERT INTO competition_solves (competition_id, participant_id, baekjoon_id, problem_id, level, first_seen_at)
+				SELECT p.competition_id, p.id, s.baekjoon_id, s.problem_id, s.level, s.first_seen_at
+				FROM solves s JOIN participants p ON p.baekjoon_id = s.baekjoon_id
+				WHERE NOT EXISTS (SELECT 1 FROM participant_start_problems sp
+					WHERE sp.competition_id = p.competition_id AND sp.participant_id = p.id AND sp.problem_id = s.problem_id)`,
+			`DROP TABLE solves`,
+			`ALTER TABLE competition_solves RENAME TO solves`,
+			`CREATE INDEX idx_solves_first_seen_at ON solves(competition_id, first_seen_at)`,
+		},
+	},
+	{
+		version:     10,
+		description: "pending final results announcement",
+		statements: []string{
+			// 이미 확정된 대회는 발표를 마친 것으로 봅니다
+			`ALTER TABLE competitions ADD COLUMN results_pending INTEGER NOT NULL DEFAULT 0`,
+		},
+	},
+}
+
+// migrate 아직 적용되지 않은 마이그레이션을 버전 순서대로 적용합니다.
+// 각 버전은 하나의 트랜잭션으로 적용되며 schema_migrations 테이블에 기록됩니다.
+func migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TEXT NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("마이그레이션 테이블 생성 실패: %w", err)
+	}
+
+	var current int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return fmt.Errorf("스키마 버전 조회 실패: %w", err)
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if err := applyMigration(db, m); err != nil {
+			return fmt.Errorf("마이그레이션 %d (%s) 적용 실패: %w", m.version, m.description, err)
+		}
+		utils.Info("Applied database migration %d: %s", m.version, m.description)
+	}
+	return nil
+}
+
+func applyMigration(db *sql.DB, m migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range m.statements {
+		if _, err := tx.Exec(stmt); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`,
+		m.version, formatTime(time.Now())); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
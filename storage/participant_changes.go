@@ -0,0 +1,267 @@
+package storage
+
+import (
+	"discord-bot/constants"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RenameParticipant 참가자의 표시 이름을 바꿉니다
+func (s *Storage) RenameParticipant(competitionID, participantID int, name string) error {
+	if !utils.IsValidUsername(name) {
+		return fmt.Errorf("잘못된 사용자명: %s", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	participant, err := s.findParticipantByID(competitionID, participantID)
+	if err != nil {
+		return err
+	}
+	participant.Name = utils.SanitizeString(name)
+	utils.Info("Renamed participant %d of competition %d to %s", participantID, competitionID, participant.Name)
+	return s.saveCompetitions()
+}
+
+// findParticipantByID 호출자가 mu를 잡은 상태에서 대회의 참가자를 찾습니다
+func (s *Storage) findParticipantByID(competitionID, participantID int) (*models.Participant, error) {
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range competition.Participants {
+		if competition.Participants[i].ID == participantID {
+			return &competition.Participants[i], nil
+		}
+	}
+	return nil, fmt.Errorf("참가자를 찾을 수 없습니다: %d", participantID)
+}
+
+// CreateHandleChangeRequest 참가자의 백준 ID 변경 요청을 만듭니다. 참가자마다 처리되지 않은 요청은 하나만 둘 수 있습니다
+func (s *Storage) CreateHandleChangeRequest(competitionID, participantID int, newBaekjoonID, requestedBy string) (*models.HandleChangeRequest, error) {
+	if !utils.IsValidBaekjoonID(newBaekjoonID) {
+		return nil, fmt.Errorf("잘못된 백준 ID: %s", newBaekjoonID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	participant, err := s.findParticipantByID(competitionID, participantID)
+	if err != nil {
+		return nil, err
+	}
+	competition, _ := s.findCompetition(competitionID)
+	if err := checkHandleChange(*participant, competition.Participants, newBaekjoonID); err != nil {
+		return nil, err
+	}
+
+	nextID := 1
+	for _, r := range s.changes {
+		if r.CompetitionID == competitionID && r.ParticipantID == participantID && r.Status == models.RequestPending {
+			return nil, fmt.Errorf("이미 처리되지 않은 변경 요청이 있습니다 (요청 %d)", r.ID)
+		}
+		if r.ID >= nextID {
+			nextID = r.ID + 1
+		}
+	}
+
+	request := models.HandleChangeRequest{
+		ID:            nextID,
+		CompetitionID: competitionID,
+		ParticipantID: participantID,
+		OldBaekjoonID: participant.BaekjoonID,
+		NewBaekjoonID: newBaekjoonID,
+		RequestedBy:   requestedBy,
+		Status:        models.RequestPending,
+		RequestedAt:   time.Now(),
+	}
+	s.changes = append(s.changes, request)
+	utils.Info("Created handle change request %d: %s -> %s", request.ID, request.OldBaekjoonID, newBaekjoonID)
+	return &request, s.saveDataFile(constants.HandleChangeFileName, s.changes)
+}
+
+// checkHandleChange 참가자의 백준 ID를 newBaekjoonID로 바꿀 수 있는지 확인합니다
+func checkHandleChange(participant models.Participant, participants []models.Participant, newBaekjoonID string) error {
+	if strings.EqualFold(participant.BaekjoonID, newBaekjoonID) {
+		return fmt.Errorf("현재 백준 ID와 같습니다: %s", newBaekjoonID)
+	}
+	for _, p := range participants {
+		if p.ID != participant.ID && strings.EqualFold(p.BaekjoonID, newBaekjoonID) {
+			return fmt.Errorf("백준 ID %s로 이미 등록된 참가자가 있습니다", newBaekjoonID)
+		}
+	}
+	return nil
+}
+
+// GetHandleChangeRequests 대회의 백준 ID 변경 요청을 요청 순서대로 반환합니다
+func (s *Storage) GetHandleChangeRequests(competitionID int) []models.HandleChangeRequest {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	requests := []models.HandleChangeRequest{}
+	for _, r := range s.changes {
+		if r.CompetitionID == competitionID {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+// ResolveHandleChangeRequest 변경 요청을 승인하거나 거절합니다.
+// 승인하면 참가자의 시작 티어와 시작 시점 해결 문제는 그대로 두고 백준 ID만 바꾸며, 이 대회의 풀이 기록도 새 ID로 옮깁니다.
+// 요청한 뒤에 요청자가 새 백준 ID의 계정 연동을 해제했거나 다른 ID로 바꿨으면 승인하지 않습니다.
+// 변경은 사본에 적용해 모든 파일을 저장한 뒤에만 메모리에 반영하므로, 저장에 실패하면 메모리는 바뀌지 않습니다.
+func (s *Storage) ResolveHandleChangeRequest(requestID int, approve bool, resolvedBy string) (*models.HandleChangeRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := -1
+	for i, r := range s.changes {
+		if r.ID == requestID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("변경 요청 %d을(를) 찾을 수 없습니다", requestID)
+	}
+	request := s.changes[index]
+	if request.Status != models.RequestPending {
+		return nil, fmt.Errorf("이미 처리된 변경 요청입니다 (요청 %d)", requestID)
+	}
+
+	competitions, solves := s.competitions, s.solves
+	request.Status = models.RequestRejected
+	if approve {
+		if err := checkHandleChangeLink(request, s.findAccountLink(request.RequestedBy)); err != nil {
+			return nil, err
+		}
+		var err error
+		if competitions, err = s.withHandleChanged(request); err != nil {
+			return nil, err
+		}
+		solves = renamedSolves(s.solves, request.CompetitionID, request.OldBaekjoonID, request.NewBaekjoonID)
+		request.Status = models.RequestApproved
+	}
+	request.ResolvedBy = resolvedBy
+	request.ResolvedAt = time.Now()
+
+	changes := slices.Clone(s.changes)
+	changes[index] = request
+	if err := s.saveResolvedHandleChange(approve, competitions, solves, changes); err != nil {
+		return nil, err
+	}
+
+	s.competitions = competitions
+	if approve {
+		s.solves = solves
+		s.rebuildSolveIndex()
+	}
+	s.changes = changes
+	utils.Info("Resolved handle change request %d: %s", requestID, request.Status)
+	return &request, nil
+}
+
+// checkHandleChangeLink 요청자가 아직 새 백준 ID의 계정 연동을 가지고 있는지 확인합니다.
+// 소유 인증은 요청할 때 확인했으므로, 그 뒤에 연동이 해제되거나 바뀌었으면 승인하지 않습니다.
+func checkHandleChangeLink(request models.HandleChangeRequest, link *models.AccountLink) error {
+	if link == nil || !strings.EqualFold(link.BaekjoonID, request.NewBaekjoonID) {
+		return fmt.Errorf("요청자의 백준 ID %s 계정 연동이 해제되었거나 바뀌어 승인할 수 없습니다", request.NewBaekjoonID)
+	}
+	return nil
+}
+
+// withHandleChanged 호출자가 mu를 잡은 상태에서 요청한 참가자의 백준 ID만 바꾼 대회 목록 사본을 만듭니다.
+// 바뀌는 대회와 그 참가자 목록만 복사하므로 저장된 상태는 그대로입니다.
+func (s *Storage) withHandleChanged(request models.HandleChangeRequest) ([]*models.Competition, error) {
+	competition, err := s.findCompetition(request.CompetitionID)
+	if err != nil {
+		return nil, err
+	}
+	participant, err := s.findParticipantByID(request.CompetitionID, request.ParticipantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkHandleChange(*participant, competition.Participants, request.NewBaekjoonID); err != nil {
+		return nil, err
+	}
+
+	changed := *competition
+	changed.Participants = slices.Clone(competition.Participants)
+	for i := range changed.Participants {
+		if changed.Participants[i].ID == request.ParticipantID {
+			changed.Participants[i].BaekjoonID = request.NewBaekjoonID
+		}
+	}
+	return s.withCompetition(&changed), nil
+}
+
+// saveResolvedHandleChange 처리한 변경 요청과 (승인했으면) 바뀐 대회와 풀이 기록을 저장합니다.
+// 중간에 실패하면 먼저 저장한 파일을 메모리의 현재 상태로 다시 씁니다. 세 파일을 한 번에 쓰는 것은 아니므로
+// 되돌리기에 실패하거나 그 사이 프로세스가 종료되면 대회, 풀이 기록, 변경 요청 파일이 서로 어긋날 수 있습니다.
+func (s *Storage) saveResolvedHandleChange(approve bool, competitions []*models.Competition, solves []models.SolveRecord, changes []models.HandleChangeRequest) error {
+	if !approve {
+		return s.saveDataFile(constants.HandleChangeFileName, changes)
+	}
+
+	if err := s.saveDataFile(constants.CompetitionsFileName, competitions); err != nil {
+		return err
+	}
+	if err := s.saveDataFile(constants.SolvesFileName, solves); err != nil {
+		s.restoreDataFile(constants.CompetitionsFileName, s.competitions)
+		return err
+	}
+	if err := s.saveDataFile(constants.HandleChangeFileName, changes); err != nil {
+		s.restoreDataFile(constants.CompetitionsFileName, s.competitions)
+		s.restoreDataFile(constants.SolvesFileName, s.solves)
+		return err
+	}
+	return nil
+}
+
+// renamedSolves 대회의 이전 백준 ID 풀이 기록을 새 ID로 바꾼 풀이 기록 사본을 반환합니다.
+// 풀이 기록은 대회마다 따로 남으므로 다른 대회의 기록은 그대로 둡니다.
+func renamedSolves(solves []models.SolveRecord, competitionID int, oldBaekjoonID, newBaekjoonID string) []models.SolveRecord {
+	renamed := slices.Clone(solves)
+	for i, record := range renamed {
+		if record.CompetitionID == competitionID && record.BaekjoonID == oldBaekjoonID {
+			renamed[i].BaekjoonID = newBaekjoonID
+		}
+	}
+	return renamed
+}
+
+// AppendAuditEntry 참가자 정보 변경 기록을 남깁니다
+func (s *Storage) AppendAuditEntry(entry models.AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry.ID = len(s.auditLog) + 1
+	if n := len(s.auditLog); n > 0 && s.auditLog[n-1].ID >= entry.ID {
+		entry.ID = s.auditLog[n-1].ID + 1
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	s.auditLog = append(s.auditLog, entry)
+	return s.saveDataFile(constants.AuditLogFileName, s.auditLog)
+}
+
+// GetAuditLog 대회의 참가자 정보 변경 기록을 최신순으로 반환합니다
+func (s *Storage) GetAuditLog(competitionID int) []models.AuditEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := []models.AuditEntry{}
+	for i := len(s.auditLog) - 1; i >= 0; i-- {
+		if s.auditLog[i].CompetitionID == competitionID {
+			entries = append(entries, s.auditLog[i])
+		}
+	}
+	return entries
+}
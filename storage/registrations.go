@@ -0,0 +1,325 @@
+package storage
+
+import (
+	"context"
+	"discord-bot/constants"
+	"discord-bot/interfaces"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// UpdateCompetitionRegistration 대회의 등록 기간과 승인 방식을 변경합니다.
+// 대회 시작 전에 시작 기록 시점을 대회 시작으로 바꾸면 이미 등록한 참가자도 시작 시각에 다시 기록합니다.
+func (s *Storage) UpdateCompetitionRegistration(competitionID int, policy models.RegistrationPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return err
+	}
+	competition.Registration = policy
+	if competition.DefersSnapshot(time.Now()) {
+		for i := range competition.Participants {
+			competition.Participants[i].SnapshotPending = true
+		}
+	}
+	return s.saveCompetitions()
+}
+
+// CreateRegistrationRequest 승인 방식 대회에 등록 신청을 만듭니다. 같은 사람의 처리되지 않은 신청은 하나만 둘 수 있습니다
+func (s *Storage) CreateRegistrationRequest(competitionID int, name, baekjoonID, discordUserID string) (*models.RegistrationRequest, error) {
+	if err := validateParticipantInput(name, baekjoonID); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDuplicateParticipant(competition, baekjoonID); err != nil {
+		return nil, err
+	}
+
+	nextID := 1
+	for _, r := range s.applications {
+		if r.CompetitionID == competitionID && r.Status == models.RequestPending && isSameApplicant(r, baekjoonID, discordUserID) {
+			return nil, fmt.Errorf("이미 처리되지 않은 등록 신청이 있습니다 (신청 %d)", r.ID)
+		}
+		if r.ID >= nextID {
+			nextID = r.ID + 1
+		}
+	}
+
+	request := models.RegistrationRequest{
+		ID:            nextID,
+		CompetitionID: competitionID,
+		Name:          utils.SanitizeString(name),
+		BaekjoonID:    baekjoonID,
+		DiscordUserID: discordUserID,
+		Status:        models.RequestPending,
+		RequestedAt:   time.Now(),
+	}
+	s.applications = append(s.applications, request)
+	utils.Info("Created registration request %d for competition %d: %s", request.ID, competitionID, baekjoonID)
+	return &request, s.saveDataFile(constants.RegistrationFileName, s.applications)
+}
+
+// isSameApplicant 신청이 같은 백준 ID 또는 같은 디스코드 사용자의 것인지 확인합니다
+func isSameApplicant(r models.RegistrationRequest, baekjoonID, discordUserID string) bool {
+	return strings.EqualFold(r.BaekjoonID, baekjoonID) || (discordUserID != "" && r.DiscordUserID == discordUserID)
+}
+
+// GetRegistrationRequests 대회의 등록 신청을 신청 순서대로 반환합니다
+func (s *Storage) GetRegistrationRequests(competitionID int) []models.RegistrationRequest {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	requests := []models.RegistrationRequest{}
+	for _, r := range s.applications {
+		if r.CompetitionID == competitionID {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+// GetRegistrationRequest ID로 등록 신청을 찾습니다 (없으면 nil)
+func (s *Storage) GetRegistrationRequest(requestID int) *models.RegistrationRequest {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, r := range s.applications {
+		if r.ID == requestID {
+			request := r
+			return &request
+		}
+	}
+	return nil
+}
+
+// ApproveRegistrationRequest 등록 신청을 승인하고 신청자를 참가자로 추가합니다.
+// 대회 파일과 신청 파일을 차례로 저장하고 둘 다 저장한 뒤에만 메모리에 반영합니다.
+// 신청 파일을 저장하지 못하면 대회 파일을 이전 상태로 다시 쓰지만 한 번에 쓰는 것은 아니므로,
+// 되돌리기에 실패하거나 그 사이 프로세스가 종료되면 참가자는 추가되고 신청은 대기 중인 채로 남을 수 있습니다.
+func (s *Storage) ApproveRegistrationRequest(ctx context.Context, requestID int, resolvedBy string, startTier, startRating int) (*models.RegistrationRequest, error) {
+	s.mu.RLock()
+	_, request, competition, err := s.findApprovableRequest(requestID)
+	deferred := err == nil && competition.DefersSnapshot(time.Now())
+	s.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// 시작 문제 데이터 수집 (네트워크 요청이므로 잠금 없이 수행)
+	startProblemIDs, pending := loadStartingProblems(ctx, s.apiClient, request.BaekjoonID, deferred)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 요청 중에 신청이 처리되거나 계정 연동이 바뀌었을 수 있으므로 다시 확인
+	index, request, competition, err := s.findApprovableRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	participant := s.createParticipant(competition, request.Name, request.BaekjoonID, request.DiscordUserID,
+		startTier, startRating, startProblemIDs, len(startProblemIDs))
+	participant.SnapshotPending = pending
+	changed := *competition
+	changed.Participants = append(slices.Clone(competition.Participants), participant)
+	competitions := s.withCompetition(&changed)
+
+	request.Status = models.RequestApproved
+	request.ResolvedBy = resolvedBy
+	request.ResolvedAt = time.Now()
+	applications := slices.Clone(s.applications)
+	applications[index] = request
+
+	if err := s.saveDataFile(constants.CompetitionsFileName, competitions); err != nil {
+		return nil, err
+	}
+	if err := s.saveDataFile(constants.RegistrationFileName, applications); err != nil {
+		s.restoreDataFile(constants.CompetitionsFileName, s.competitions)
+		return nil, err
+	}
+
+	s.competitions = competitions
+	s.applications = applications
+	utils.Info("Added new participant to competition %d: %s (%s)", competition.ID, participant.Name, participant.BaekjoonID)
+	utils.Info("Resolved registration request %d: %s", requestID, request.Status)
+	return &request, nil
+}
+
+// findApprovableRequest 호출자가 mu를 잡은 상태에서 승인할 등록 신청과 그 대회를 찾고 승인할 수 있는지 확인합니다
+func (s *Storage) findApprovableRequest(requestID int) (int, models.RegistrationRequest, *models.Competition, error) {
+	index := slices.IndexFunc(s.applications, func(r models.RegistrationRequest) bool { return r.ID == requestID })
+	if index < 0 {
+		return -1, models.RegistrationRequest{}, nil, fmt.Errorf("등록 신청 %d을(를) 찾을 수 없습니다", requestID)
+	}
+	request := s.applications[index]
+
+	competition, err := s.findCompetition(request.CompetitionID)
+	if err != nil {
+		return -1, request, nil, err
+	}
+	if err := checkRegistrationApproval(request, competition.IsFinalized(), s.findAccountLink(request.DiscordUserID)); err != nil {
+		return -1, request, nil, err
+	}
+	if err := s.checkDuplicateParticipant(competition, request.BaekjoonID); err != nil {
+		return -1, request, nil, err
+	}
+	return index, request, competition, nil
+}
+
+// checkRegistrationApproval 등록 신청을 승인해 참가자로 추가할 수 있는지 확인합니다.
+// 신청한 뒤에 계정 연동이 해제되거나 다른 백준 ID로 바뀌었거나 대회 결과가 확정되었으면 승인하지 않습니다.
+func checkRegistrationApproval(request models.RegistrationRequest, finalized bool, link *models.AccountLink) error {
+	if request.Status != models.RequestPending {
+		return fmt.Errorf("이미 처리된 등록 신청입니다 (신청 %d)", request.ID)
+	}
+	if finalized {
+		return fmt.Errorf("최종 결과가 확정된 대회에는 참가자를 추가할 수 없습니다")
+	}
+	if link == nil || !strings.EqualFold(link.BaekjoonID, request.BaekjoonID) {
+		return fmt.Errorf("신청자의 백준 ID %s 계정 연동이 해제되었거나 바뀌어 승인할 수 없습니다", request.BaekjoonID)
+	}
+	return nil
+}
+
+// RejectRegistrationRequest 등록 신청을 거절 상태로 바꿉니다
+func (s *Storage) RejectRegistrationRequest(requestID int, resolvedBy string) (*models.RegistrationRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.applications {
+		request := &s.applications[i]
+		if request.ID != requestID {
+			continue
+		}
+		if request.Status != models.RequestPending {
+			return nil, fmt.Errorf("이미 처리된 등록 신청입니다 (신청 %d)", requestID)
+		}
+
+		request.Status = models.RequestRejected
+		request.ResolvedBy = resolvedBy
+		request.ResolvedAt = time.Now()
+		utils.Info("Resolved registration request %d: %s", requestID, request.Status)
+		resolved := *request
+		return &resolved, s.saveDataFile(constants.RegistrationFileName, s.applications)
+	}
+	return nil, fmt.Errorf("등록 신청 %d을(를) 찾을 수 없습니다", requestID)
+}
+
+// CaptureStartSnapshot 참가자의 현재 티어, 레이팅, 해결한 문제를 시작 시점 기록으로 저장하고 대기 표시를 지웁니다
+func (s *Storage) CaptureStartSnapshot(ctx context.Context, competitionID, participantID int) error {
+	s.mu.RLock()
+	participant, err := s.findParticipantByID(competitionID, participantID)
+	if err != nil {
+		s.mu.RUnlock()
+		return err
+	}
+	baekjoonID := participant.BaekjoonID
+	s.mu.RUnlock()
+
+	// 네트워크 요청이므로 잠금 없이 수행
+	snapshot, err := fetchStartSnapshot(ctx, s.apiClient, baekjoonID)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 요청 중에 참가자가 삭제되거나 백준 ID가 바뀌었을 수 있으므로 다시 확인
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return err
+	}
+	participant, err = s.findParticipantByID(competitionID, participantID)
+	if err != nil {
+		return err
+	}
+	if participant.BaekjoonID != baekjoonID {
+		return fmt.Errorf("기록 중에 참가자 %d의 백준 ID가 바뀌었습니다", participantID)
+	}
+	snapshot.without(s.solvedDuring(competition, baekjoonID)).applyTo(participant)
+	utils.Info("Captured start snapshot for participant %s of competition %d", baekjoonID, competitionID)
+	return s.saveCompetitions()
+}
+
+// startSnapshot 참가자의 시작 시점 기록입니다
+type startSnapshot struct {
+	tier       int
+	rating     int
+	problemIDs []int
+}
+
+// fetchStartSnapshot solved.ac에서 시작 시점 기록을 가져옵니다.
+// 실패하면 빈 기록을 남기지 않고 오류를 반환해 다음에 다시 시도하게 합니다.
+func fetchStartSnapshot(ctx context.Context, apiClient interfaces.APIClient, baekjoonID string) (startSnapshot, error) {
+	// 시작 기록은 기록하는 시점의 정보여야 하므로 캐시된 응답을 쓰지 않습니다
+	if cache, ok := apiClient.(interfaces.CacheInvalidator); ok {
+		cache.Invalidate(baekjoonID)
+	}
+	userInfo, err := apiClient.GetUserInfo(ctx, baekjoonID)
+	if err != nil {
+		return startSnapshot{}, fmt.Errorf("%s 사용자 정보 조회 실패: %w", baekjoonID, err)
+	}
+	solved, err := apiClient.GetUserSolvedProblems(ctx, baekjoonID)
+	if err != nil {
+		return startSnapshot{}, fmt.Errorf("%s 해결 문제 조회 실패: %w", baekjoonID, err)
+	}
+
+	snapshot := startSnapshot{tier: userInfo.Tier, rating: userInfo.Rating, problemIDs: []int{}}
+	for _, problem := range solved.Items {
+		snapshot.problemIDs = append(snapshot.problemIDs, problem.ProblemID)
+	}
+	return snapshot, nil
+}
+
+// solvedDuring 호출자가 mu를 잡은 상태에서 대회가 시작한 뒤 풀이 기록에 처음 확인된 참가자의 문제를 반환합니다
+func (s *Storage) solvedDuring(competition *models.Competition, baekjoonID string) map[int]bool {
+	solved := make(map[int]bool)
+	for _, record := range s.solves {
+		if record.CompetitionID == competition.ID && record.BaekjoonID == baekjoonID && !record.FirstSeenAt.Before(competition.StartDate) {
+			solved[record.ProblemID] = true
+		}
+	}
+	return solved
+}
+
+// without 대회 중에 푼 문제를 시작 기록에서 뺍니다.
+// 대회가 시작한 뒤에 시작 기록을 다시 남겨도 그동안 푼 문제가 이미 푼 문제로 바뀌어 점수에서 빠지지 않게 합니다.
+func (snapshot startSnapshot) without(solvedDuring map[int]bool) startSnapshot {
+	if len(solvedDuring) == 0 {
+		return snapshot
+	}
+	kept := []int{}
+	for _, problemID := range snapshot.problemIDs {
+		if !solvedDuring[problemID] {
+			kept = append(kept, problemID)
+		}
+	}
+	snapshot.problemIDs = kept
+	return snapshot
+}
+
+func (snapshot startSnapshot) applyTo(participant *models.Participant) {
+	participant.StartTier = snapshot.tier
+	participant.StartRating = snapshot.rating
+	participant.StartProblemIDs = snapshot.problemIDs
+	participant.StartProblemCount = len(snapshot.problemIDs)
+	participant.SnapshotPending = false
+}
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"discord-bot/constants"
+	"discord-bot/interfaces"
+	"discord-bot/utils"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// guildIDPattern 디스코드 길드 ID(snowflake) 형식입니다. 경로 조작을 막기 위해 숫자만 허용합니다
+var guildIDPattern = regexp.MustCompile(`^[0-9]{1,20}$`)
+
+// Registry 길드별로 분리된 저장소 인스턴스를 관리합니다.
+// 각 길드의 데이터는 <baseDir>/guilds/<길드ID>/ 아래에 저장됩니다.
+type Registry struct {
+	mu            sync.Mutex
+	storages      map[string]interfaces.StorageRepository
+	apiClient     interfaces.APIClient
+	baseDir       string
+	backend       string // constants.StorageBackendJSON 또는 constants.StorageBackendSQLite
+	legacyGuildID string // 길드 분리 이전 데이터를 가져갈 길드 (없으면 빈 문자열)
+}
+
+// NewRegistry 새로운 Registry 인스턴스를 생성합니다
+func NewRegistry(apiClient interfaces.APIClient, baseDir, backend, legacyGuildID string) *Registry {
+	return &Registry{
+		storages:      make(map[string]interfaces.StorageRepository),
+		apiClient:     apiClient,
+		baseDir:       baseDir,
+		backend:       backend,
+		legacyGuildID: legacyGuildID,
+	}
+}
+
+// ForGuild 길드의 저장소를 반환합니다. 처음 요청된 길드는 데이터를 로드하여 생성합니다
+func (r *Registry) ForGuild(guildID string) (interfaces.StorageRepository, error) {
+	if !guildIDPattern.MatchString(guildID) {
+		return nil, fmt.Errorf("잘못된 길드 ID: %q", guildID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if storage, exists := r.storages[guildID]; exists {
+		return storage, nil
+	}
+
+	legacyDir := ""
+	if guildID == r.legacyGuildID {
+		legacyDir = "." // 길드 분리 이전에는 작업 디렉터리에 저장했음
+	}
+
+	newStorage := NewStorage
+	if r.backend == constants.StorageBackendSQLite {
+		newStorage = NewSQLiteStorage
+	}
+
+	storage, err := newStorage(r.apiClient, r.guildDir(guildID), legacyDir)
+	if err != nil {
+		return nil, err
+	}
+
+	r.storages[guildID] = storage
+	utils.Info("Storage for guild %s is ready", guildID)
+	return storage, nil
+}
+
+// GuildIDs 데이터가 있는 모든 길드 ID를 반환합니다
+func (r *Registry) GuildIDs() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[string]bool)
+	for guildID := range r.storages {
+		seen[guildID] = true
+	}
+
+	entries, err := os.ReadDir(filepath.Join(r.baseDir, constants.GuildsDirName))
+	if err != nil && !os.IsNotExist(err) {
+		utils.Warn("Failed to list guild data directories: %v", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && guildIDPattern.MatchString(entry.Name()) {
+			seen[entry.Name()] = true
+		}
+	}
+
+	if r.legacyGuildID != "" {
+		seen[r.legacyGuildID] = true
+	}
+
+	guildIDs := make([]string, 0, len(seen))
+	for guildID := range seen {
+		guildIDs = append(guildIDs, guildID)
+	}
+	sort.Strings(guildIDs)
+	return guildIDs
+}
+
+// Close 지금까지 연 모든 길드의 저장소를 닫습니다. 닫지 못한 저장소의 오류는 모아서 반환합니다
+func (r *Registry) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for guildID, storage := range r.storages {
+		if err := storage.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("길드 %s 저장소 닫기 실패: %w", guildID, err))
+		}
+	}
+	r.storages = make(map[string]interfaces.StorageRepository)
+	return errors.Join(errs...)
+}
+
+func (r *Registry) guildDir(guildID string) string {
+	return filepath.Join(r.baseDir, constants.GuildsDirName, guildID)
+}
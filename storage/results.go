@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"discord-bot/constants"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+)
+
+// FinalizeCompetition 대회의 최종 순위를 저장하고 대회를 종료 상태로 바꿉니다.
+// 한 번 확정된 최종 순위는 바꿀 수 없으며, 발표를 마칠 때까지 발표 대기로 표시합니다.
+// 종료 상태는 사본에 적용해 저장한 뒤에만 반영하므로, 저장에 실패하면 대회는 확정되지 않은 채로 남아 다시 확정할 수 있습니다.
+func (s *Storage) FinalizeCompetition(standings models.FinalStandings) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	competition, err := s.findCompetition(standings.CompetitionID)
+	if err != nil {
+		return err
+	}
+	if competition.IsFinalized() {
+		return fmt.Errorf("이미 최종 결과가 확정된 대회입니다 (%s)", utils.FormatDateTime(competition.FinalizedAt))
+	}
+
+	// 이전에 대회 저장에 실패해 남은 순위가 있으면 새 순위로 교체합니다
+	results := []models.FinalStandings{standings.Clone()}
+	for _, r := range s.results {
+		if r.CompetitionID != standings.CompetitionID {
+			results = append(results, r)
+		}
+	}
+	if err := s.saveDataFile(constants.ResultsFileName, results); err != nil {
+		return err
+	}
+	s.results = results
+
+	changed := *competition
+	changed.IsActive = false
+	changed.FinalizedAt = standings.FinalizedAt
+	changed.ResultsPending = true
+	competitions := s.withCompetition(&changed)
+	if err := s.saveDataFile(constants.CompetitionsFileName, competitions); err != nil {
+		return err
+	}
+	s.competitions = competitions
+	utils.Info("Finalized competition %d with %d participants", competition.ID, len(standings.Scores))
+	return nil
+}
+
+// GetFinalStandings 대회의 최종 순위를 반환합니다 (확정되지 않았으면 nil)
+func (s *Storage) GetFinalStandings(competitionID int) *models.FinalStandings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	competition, err := s.findCompetition(competitionID)
+	if err != nil || !competition.IsFinalized() {
+		return nil
+	}
+	for _, r := range s.results {
+		if r.CompetitionID == competitionID {
+			standings := r.Clone()
+			return &standings
+		}
+	}
+	return nil
+}
+
+// MarkResultsAnnounced 대회의 최종 결과 발표 대기 표시를 지웁니다
+func (s *Storage) MarkResultsAnnounced(competitionID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return err
+	}
+	if !competition.ResultsPending {
+		return nil
+	}
+	competition.ResultsPending = false
+	return s.saveCompetitions()
+}
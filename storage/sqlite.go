@@ -0,0 +1,745 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"discord-bot/constants"
+	"discord-bot/interfaces"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // 순수 Go SQLite 드라이버 (cgo 불필요)
+)
+
+// sqliteTimeLayout 시각을 TEXT 컬럼에 저장하는 형식입니다.
+// 항상 UTC와 고정 길이 소수부를 사용하므로 문자열 비교가 시간 순서와 일치합니다.
+const sqliteTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// settingAnnouncementChannel guild_settings 테이블의 공지 채널 키입니다
+const settingAnnouncementChannel = "announcement_channel_id"
+
+const solveColumns = `competition_id, participant_id, baekjoon_id, problem_id, level, first_seen_at, backlog`
+
+// SQLiteStorage 한 길드의 데이터를 내장 SQLite 데이터베이스에 저장하는 저장소입니다.
+// 모든 변경은 즉시 트랜잭션으로 반영되므로 SaveCompetitions/SaveSolves는 아무 일도 하지 않습니다.
+type SQLiteStorage struct {
+	db        *sql.DB
+	apiClient interfaces.APIClient
+	dataDir   string
+}
+
+// NewSQLiteStorage dataDir의 데이터베이스를 열고 스키마 마이그레이션을 적용합니다.
+// 데이터베이스가 비어 있으면 같은 위치(또는 legacyDir)의 JSON 데이터를 가져옵니다.
+func NewSQLiteStorage(apiClient interfaces.APIClient, dataDir, legacyDir string) (interfaces.StorageRepository, error) {
+	utils.Info("Initializing SQLite storage in %s", dataDir)
+	if err := os.MkdirAll(dataDir, constants.DirPermission); err != nil {
+		return nil, fmt.Errorf("데이터 디렉터리 생성 실패: %w", err)
+	}
+
+	db, err := openSQLiteDB(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SQLiteStorage{db: db, apiClient: apiClient, dataDir: dataDir}
+	if err := s.importJSONIfEmpty(dataDir, legacyDir); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	utils.Info("SQLite storage initialized successfully")
+	return s, nil
+}
+
+// openSQLiteDB dataDir의 데이터베이스를 열고 스키마를 최신 버전으로 올립니다
+func openSQLiteDB(dataDir string) (*sql.DB, error) {
+	dsn := "file:" + filepath.Join(dataDir, constants.SQLiteFileName) +
+		"?_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("데이터베이스 열기 실패: %w", err)
+	}
+	// SQLite는 동시에 하나의 쓰기만 허용하므로 연결을 하나로 제한합니다
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// importedTables JSON 저장소에서 가져온 데이터가 들어가는 테이블입니다.
+// 참가자와 시작 시점 문제는 대회 없이 존재할 수 없으므로 대회 테이블로 확인합니다.
+var importedTables = []string{
+	"competitions", "solves", "guild_settings", "account_links", "handle_change_requests",
+	"audit_log", "registration_requests", "final_standings",
+}
+
+// importJSONIfEmpty 비어 있는 데이터베이스에 JSON 저장소의 데이터를 한 트랜잭션으로 가져옵니다
+func (s *SQLiteStorage) importJSONIfEmpty(dataDir, legacyDir string) error {
+	counts := make([]string, len(importedTables))
+	for i, table := range importedTables {
+		counts[i] = "(SELECT COUNT(*) FROM " + table + ")"
+	}
+	var rows int
+	if err := s.db.QueryRow("SELECT " + strings.Join(counts, " + ")).Scan(&rows); err != nil {
+		return fmt.Errorf("데이터베이스 상태 확인 실패: %w", err)
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	// JSON 파일은 가져오기 원본일 뿐이므로 옮기거나 복구한 내용을 다시 쓰지 않고 읽기만 합니다
+	source := &Storage{apiClient: s.apiClient, dataDir: dataDir, legacyDir: legacyDir}
+	source.readData()
+	if source.isEmpty() {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, c := range source.competitions {
+		if err := insertCompetition(tx, c); err != nil {
+			return fmt.Errorf("대회 %d 가져오기 실패: %w", c.ID, err)
+		}
+		for _, p := range c.Participants {
+			if err := insertParticipant(tx, c.ID, p); err != nil {
+				return fmt.Errorf("참가자 %s 가져오기 실패: %w", p.BaekjoonID, err)
+			}
+		}
+	}
+	if _, err := insertSolves(tx, source.solves); err != nil {
+		return fmt.Errorf("풀이 기록 가져오기 실패: %w", err)
+	}
+	if err := putSetting(tx, settingAnnouncementChannel, source.settings.AnnouncementChannelID); err != nil {
+		return fmt.Errorf("설정 가져오기 실패: %w", err)
+	}
+	for _, link := range source.accounts {
+		if err := insertAccountLink(tx, link); err != nil {
+			return fmt.Errorf("계정 연동 %s 가져오기 실패: %w", link.BaekjoonID, err)
+		}
+	}
+	for _, request := range source.changes {
+		if err := insertHandleChangeRequest(tx, request); err != nil {
+			return fmt.Errorf("변경 요청 %d 가져오기 실패: %w", request.ID, err)
+		}
+	}
+	for _, entry := range source.auditLog {
+		if err := insertAuditEntry(tx, entry); err != nil {
+			return fmt.Errorf("변경 기록 %d 가져오기 실패: %w", entry.ID, err)
+		}
+	}
+	for _, request := range source.applications {
+		if err := insertRegistrationRequest(tx, request); err != nil {
+			return fmt.Errorf("등록 신청 %d 가져오기 실패: %w", request.ID, err)
+		}
+	}
+	for _, standings := range source.results {
+		if err := insertFinalStandings(tx, standings); err != nil {
+			return fmt.Errorf("대회 %d 최종 순위 가져오기 실패: %w", standings.CompetitionID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	utils.Info("Imported %d competitions and %d solve records from JSON storage", len(source.competitions), len(source.solves))
+	return nil
+}
+
+// execer 트랜잭션과 데이터베이스 모두에서 쓰기 쿼리를 실행하기 위한 인터페이스입니다
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func insertCompetition(tx execer, c *models.Competition) error {
+	scoring, err := encodeScoring(c.Scoring)
+	if err != nil {
+		return err
+	}
+	registration, err := encodeRegistration(c.Registration)
+	if err != nil {
+		return err
+	}
+	reveal, err := encodeReveal(c.Reveal)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO competitions
+		(id, name, start_date, end_date, blackout_start_date, is_active, show_scoreboard, scoring, registration, finalized_at, reveal,
+		results_pending)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		c.ID, c.Name, formatTime(c.StartDate), formatTime(c.EndDate), formatTime(c.BlackoutStartDate),
+		c.IsActive, c.ShowScoreboard, scoring, registration, formatOptionalTime(c.FinalizedAt), reveal, c.ResultsPending)
+	return err
+}
+
+// encodeScoring 점수 계산 규칙을 scoring 컬럼 값으로 변환합니다 (nil이면 NULL)
+func encodeScoring(profile *models.ScoringProfile) (interface{}, error) {
+	if profile == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return nil, fmt.Errorf("점수 계산 규칙 직렬화 실패: %w", err)
+	}
+	return string(data), nil
+}
+
+// decodeScoring scoring 컬럼 값을 점수 계산 규칙으로 변환합니다 (NULL이면 nil)
+func decodeScoring(value sql.NullString) (*models.ScoringProfile, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	profile := &models.ScoringProfile{}
+	if err := json.Unmarshal([]byte(value.String), profile); err != nil {
+		return nil, fmt.Errorf("점수 계산 규칙 파싱 실패: %w", err)
+	}
+	return profile, nil
+}
+
+// encodeRegistration 등록 방식을 registration 컬럼 값으로 변환합니다 (기본 방식이면 NULL)
+func encodeRegistration(policy models.RegistrationPolicy) (interface{}, error) {
+	if policy == (models.RegistrationPolicy{}) {
+		return nil, nil
+	}
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("등록 방식 직렬화 실패: %w", err)
+	}
+	return string(data), nil
+}
+
+// decodeRegistration registration 컬럼 값을 등록 방식으로 변환합니다 (NULL이면 기본 방식)
+func decodeRegistration(value sql.NullString) (models.RegistrationPolicy, error) {
+	var policy models.RegistrationPolicy
+	if !value.Valid {
+		return policy, nil
+	}
+	if err := json.Unmarshal([]byte(value.String), &policy); err != nil {
+		return policy, fmt.Errorf("등록 방식 파싱 실패: %w", err)
+	}
+	return policy, nil
+}
+
+// encodeReveal 발표 방식을 reveal 컬럼 값으로 변환합니다 (기본 방식이면 NULL)
+func encodeReveal(settings models.RevealSettings) (interface{}, error) {
+	if settings == (models.RevealSettings{}) {
+		return nil, nil
+	}
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("발표 방식 직렬화 실패: %w", err)
+	}
+	return string(data), nil
+}
+
+// decodeReveal reveal 컬럼 값을 발표 방식으로 변환합니다 (NULL이면 기본 방식)
+func decodeReveal(value sql.NullString) (models.RevealSettings, error) {
+	var settings models.RevealSettings
+	if !value.Valid {
+		return settings, nil
+	}
+	if err := json.Unmarshal([]byte(value.String), &settings); err != nil {
+		return settings, fmt.Errorf("발표 방식 파싱 실패: %w", err)
+	}
+	return settings, nil
+}
+
+func insertParticipant(tx execer, competitionID int, p models.Participant) error {
+	_, err := tx.Exec(`INSERT INTO participants
+		(competition_id, id, name, baekjoon_id, start_tier, start_rating, created_at, start_problem_count, discord_user_id, snapshot_pending)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		competitionID, p.ID, p.Name, p.BaekjoonID, p.StartTier, p.StartRating, formatTime(p.CreatedAt), p.StartProblemCount,
+		p.DiscordUserID, p.SnapshotPending)
+	if err != nil {
+		return err
+	}
+
+	for _, problemID := range p.StartProblemIDs {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO participant_start_problems
+			(competition_id, participant_id, problem_id) VALUES (?, ?, ?)`,
+			competitionID, p.ID, problemID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertSolves 처음 확인된 풀이만 추가하고 추가된 개수를 반환합니다
+func insertSolves(tx execer, records []models.SolveRecord) (int, error) {
+	added := 0
+	for _, record := range records {
+		result, err := tx.Exec(`INSERT OR IGNORE INTO solves
+			(competition_id, participant_id, baekjoon_id, problem_id, level, first_seen_at, backlog) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			record.CompetitionID, record.ParticipantID, record.BaekjoonID, record.ProblemID, record.Level,
+			formatTime(record.FirstSeenAt), record.Backlog)
+		if err != nil {
+			return 0, err
+		}
+		if n, err := result.RowsAffected(); err == nil {
+			added += int(n)
+		}
+	}
+	return added, nil
+}
+
+// putSetting 설정 값을 저장합니다. 빈 값이면 설정을 삭제합니다
+func putSetting(tx execer, key, value string) error {
+	if value == "" {
+		_, err := tx.Exec(`DELETE FROM guild_settings WHERE key = ?`, key)
+		return err
+	}
+	_, err := tx.Exec(`INSERT INTO guild_settings (key, value) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value`, key, value)
+	return err
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(sqliteTimeLayout)
+}
+
+func parseTime(value string) time.Time {
+	t, err := time.Parse(sqliteTimeLayout, value)
+	if err != nil {
+		utils.Warn("Invalid timestamp in database: %q", value)
+		return time.Time{}
+	}
+	return t.Local()
+}
+
+// SaveCompetitions 변경 사항은 즉시 반영되므로 아무 일도 하지 않습니다
+func (s *SQLiteStorage) SaveCompetitions() error {
+	return nil
+}
+
+// SaveSolves 변경 사항은 즉시 반영되므로 아무 일도 하지 않습니다
+func (s *SQLiteStorage) SaveSolves() error {
+	return nil
+}
+
+// Close 데이터베이스 연결을 닫습니다. 닫은 뒤에는 저장소를 사용할 수 없습니다
+func (s *SQLiteStorage) Close() error {
+	return s.db.Close()
+}
+
+// GetGuildSettings 길드 설정을 반환합니다
+func (s *SQLiteStorage) GetGuildSettings() models.GuildSettings {
+	var settings models.GuildSettings
+	err := s.db.QueryRow(`SELECT value FROM guild_settings WHERE key = ?`, settingAnnouncementChannel).
+		Scan(&settings.AnnouncementChannelID)
+	if err != nil && err != sql.ErrNoRows {
+		utils.Error("Failed to load guild settings: %v", err)
+	}
+	return settings
+}
+
+// SetAnnouncementChannel 자동 스코어보드/공지를 보낼 채널을 설정합니다 (빈 문자열이면 해제)
+func (s *SQLiteStorage) SetAnnouncementChannel(channelID string) error {
+	if err := putSetting(s.db, settingAnnouncementChannel, channelID); err != nil {
+		utils.Error("Failed to save guild settings: %v", err)
+		return err
+	}
+	return nil
+}
+
+// AddParticipant 지정된 대회에 새로운 참가자를 추가합니다
+func (s *SQLiteStorage) AddParticipant(ctx context.Context, competitionID int, name, baekjoonID, discordUserID string, startTier, startRating int) error {
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return err
+	}
+
+	// 입력값 검증
+	if err := validateParticipantInput(name, baekjoonID); err != nil {
+		return err
+	}
+
+	// 중복 확인
+	if err := s.checkDuplicateParticipant(competitionID, baekjoonID); err != nil {
+		return err
+	}
+
+	// 시작 문제 데이터 수집 (네트워크 요청이므로 트랜잭션 밖에서 수행)
+	startProblemIDs, pending := loadStartingProblems(ctx, s.apiClient, baekjoonID, competition.DefersSnapshot(time.Now()))
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var nextID int
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(id), 0) + 1 FROM participants WHERE competition_id = ?`,
+		competitionID).Scan(&nextID); err != nil {
+		return err
+	}
+
+	participant := newParticipant(nextID, name, baekjoonID, discordUserID, startTier, startRating, startProblemIDs, len(startProblemIDs))
+	participant.SnapshotPending = pending
+	if err := insertParticipant(tx, competitionID, participant); err != nil {
+		utils.Error("Failed to insert participant %s: %v", baekjoonID, err)
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	utils.Info("Added new participant to competition %d: %s (%s)", competitionID, participant.Name, participant.BaekjoonID)
+	return nil
+}
+
+// checkDuplicateParticipant 대회 내 중복 참가자를 확인합니다
+func (s *SQLiteStorage) checkDuplicateParticipant(competitionID int, baekjoonID string) error {
+	var exists bool
+	err := s.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM participants WHERE competition_id = ? AND baekjoon_id = ?)`,
+		competitionID, baekjoonID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		utils.Warn("Attempt to add duplicate participant %s to competition %d", baekjoonID, competitionID)
+		return fmt.Errorf("백준 ID %s로 이미 등록된 참가자가 있습니다", baekjoonID)
+	}
+	return nil
+}
+
+// GetParticipants 지정된 대회의 참가자 목록을 반환합니다
+func (s *SQLiteStorage) GetParticipants(competitionID int) []models.Participant {
+	participants, err := s.loadParticipants(competitionID)
+	if err != nil {
+		utils.Error("Failed to load participants of competition %d: %v", competitionID, err)
+		return []models.Participant{}
+	}
+	return participants[competitionID]
+}
+
+// loadParticipants 참가자를 대회 ID별로 읽어옵니다. competitionID가 0이면 모든 대회를 읽습니다
+func (s *SQLiteStorage) loadParticipants(competitionID int) (map[int][]models.Participant, error) {
+	rows, err := s.db.Query(`SELECT competition_id, id, name, baekjoon_id, start_tier, start_rating, created_at, start_problem_count,
+		discord_user_id, snapshot_pending
+		FROM participants WHERE ? = 0 OR competition_id = ? ORDER BY competition_id, id`, competitionID, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	type participantKey struct{ competitionID, participantID int }
+	var order []participantKey
+	byKey := make(map[participantKey]*models.Participant)
+	for rows.Next() {
+		var p models.Participant
+		var key participantKey
+		var createdAt string
+		if err := rows.Scan(&key.competitionID, &p.ID, &p.Name, &p.BaekjoonID,
+			&p.StartTier, &p.StartRating, &createdAt, &p.StartProblemCount, &p.DiscordUserID, &p.SnapshotPending); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		key.participantID = p.ID
+		p.CreatedAt = parseTime(createdAt)
+		p.StartProblemIDs = []int{}
+		order = append(order, key)
+		byKey[key] = &p
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	problemRows, err := s.db.Query(`SELECT competition_id, participant_id, problem_id
+		FROM participant_start_problems WHERE ? = 0 OR competition_id = ? ORDER BY problem_id`, competitionID, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer problemRows.Close()
+
+	for problemRows.Next() {
+		var key participantKey
+		var problemID int
+		if err := problemRows.Scan(&key.competitionID, &key.participantID, &problemID); err != nil {
+			return nil, err
+		}
+		if p, exists := byKey[key]; exists {
+			p.StartProblemIDs = append(p.StartProblemIDs, problemID)
+		}
+	}
+	if err := problemRows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := map[int][]models.Participant{competitionID: {}}
+	for _, key := range order {
+		result[key.competitionID] = append(result[key.competitionID], *byKey[key])
+	}
+	return result, nil
+}
+
+// RemoveParticipant는 지정된 대회에서 백준ID로 참가자를 삭제합니다
+func (s *SQLiteStorage) RemoveParticipant(competitionID int, baekjoonID string) error {
+	if _, err := s.findCompetition(competitionID); err != nil {
+		return err
+	}
+
+	result, err := s.db.Exec(`DELETE FROM participants WHERE competition_id = ? AND baekjoon_id = ?`, competitionID, baekjoonID)
+	if err != nil {
+		utils.Error("Failed to remove participant %s: %v", baekjoonID, err)
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("백준 ID %s로 등록된 참가자를 찾을 수 없습니다", baekjoonID)
+	}
+
+	utils.Info("Removed participant from competition %d: %s", competitionID, baekjoonID)
+	return nil
+}
+
+// CreateCompetition 새 대회를 만들고 반환합니다. 기존 대회는 그대로 유지됩니다
+func (s *SQLiteStorage) CreateCompetition(name string, startDate, endDate time.Time) (*models.Competition, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var nextID int
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(id), 0) + 1 FROM competitions`).Scan(&nextID); err != nil {
+		return nil, err
+	}
+
+	competition := newCompetition(nextID, name, startDate, endDate)
+	if err := insertCompetition(tx, competition); err != nil {
+		utils.Error("Failed to insert competition: %v", err)
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return competition, nil
+}
+
+// GetCompetitions 모든 대회를 ID 순으로 반환합니다
+func (s *SQLiteStorage) GetCompetitions() []*models.Competition {
+	competitions, err := s.queryCompetitions(0)
+	if err != nil {
+		utils.Error("Failed to load competitions: %v", err)
+		return []*models.Competition{}
+	}
+	return competitions
+}
+
+// GetCompetition ID로 대회를 찾습니다 (없으면 nil)
+func (s *SQLiteStorage) GetCompetition(competitionID int) *models.Competition {
+	competition, _ := s.findCompetition(competitionID)
+	return competition
+}
+
+func (s *SQLiteStorage) findCompetition(competitionID int) (*models.Competition, error) {
+	competitions, err := s.queryCompetitions(competitionID)
+	if err != nil {
+		utils.Error("Failed to load competition %d: %v", competitionID, err)
+		return nil, err
+	}
+	if len(competitions) == 0 {
+		return nil, fmt.Errorf("ID %d인 대회가 없습니다", competitionID)
+	}
+	return competitions[0], nil
+}
+
+// queryCompetitions 대회와 참가자를 읽어옵니다. competitionID가 0이면 모든 대회를 읽습니다
+func (s *SQLiteStorage) queryCompetitions(competitionID int) ([]*models.Competition, error) {
+	rows, err := s.db.Query(`SELECT id, name, start_date, end_date, blackout_start_date, is_active, show_scoreboard, scoring, registration,
+		finalized_at, reveal, results_pending FROM competitions WHERE ? = 0 OR id = ? ORDER BY id`, competitionID, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	competitions := []*models.Competition{}
+	for rows.Next() {
+		c := &models.Competition{}
+		var startDate, endDate, blackoutStartDate, finalizedAt string
+		var scoring, registration, reveal sql.NullString
+		if err := rows.Scan(&c.ID, &c.Name, &startDate, &endDate, &blackoutStartDate, &c.IsActive, &c.ShowScoreboard,
+			&scoring, &registration, &finalizedAt, &reveal, &c.ResultsPending); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if c.Scoring, err = decodeScoring(scoring); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if c.Registration, err = decodeRegistration(registration); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if c.Reveal, err = decodeReveal(reveal); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		c.StartDate = parseTime(startDate)
+		c.EndDate = parseTime(endDate)
+		c.BlackoutStartDate = parseTime(blackoutStartDate)
+		c.FinalizedAt = parseOptionalTime(finalizedAt)
+		competitions = append(competitions, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	participants, err := s.loadParticipants(competitionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range competitions {
+		c.Participants = participants[c.ID]
+		if c.Participants == nil {
+			c.Participants = []models.Participant{}
+		}
+	}
+	return competitions, nil
+}
+
+// updateCompetition 대회 한 건을 수정합니다. 대회가 없으면 오류를 반환합니다
+func (s *SQLiteStorage) updateCompetition(competitionID int, query string, args ...interface{}) error {
+	result, err := s.db.Exec(query, append(args, competitionID)...)
+	if err != nil {
+		utils.Error("Failed to update competition %d: %v", competitionID, err)
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("ID %d인 대회가 없습니다", competitionID)
+	}
+	return nil
+}
+
+func (s *SQLiteStorage) SetScoreboardVisibility(competitionID int, visible bool) error {
+	return s.updateCompetition(competitionID, `UPDATE competitions SET show_scoreboard = ? WHERE id = ?`, visible)
+}
+
+func (s *SQLiteStorage) IsBlackoutPeriod(competitionID int) bool {
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return false
+	}
+
+	return isBlackout(competition, time.Now())
+}
+
+// UpdateCompetitionName은 대회명을 업데이트합니다
+func (s *SQLiteStorage) UpdateCompetitionName(competitionID int, name string) error {
+	return s.updateCompetition(competitionID, `UPDATE competitions SET name = ? WHERE id = ?`, name)
+}
+
+// UpdateCompetitionScoring는 대회의 점수 계산 규칙을 변경합니다
+func (s *SQLiteStorage) UpdateCompetitionScoring(competitionID int, profile models.ScoringProfile) error {
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+	scoring, err := encodeScoring(&profile)
+	if err != nil {
+		return err
+	}
+	return s.updateCompetition(competitionID, `UPDATE competitions SET scoring = ? WHERE id = ?`, scoring)
+}
+
+// UpdateCompetitionReveal 대회 종료 시 최종 결과 발표 방식을 변경합니다
+func (s *SQLiteStorage) UpdateCompetitionReveal(competitionID int, settings models.RevealSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	reveal, err := encodeReveal(settings)
+	if err != nil {
+		return err
+	}
+	return s.updateCompetition(competitionID, `UPDATE competitions SET reveal = ? WHERE id = ?`, reveal)
+}
+
+// UpdateCompetitionStartDate는 대회 시작일을 업데이트합니다
+func (s *SQLiteStorage) UpdateCompetitionStartDate(competitionID int, startDate time.Time) error {
+	return s.updateCompetition(competitionID, `UPDATE competitions SET start_date = ? WHERE id = ?`, formatTime(startDate))
+}
+
+// UpdateCompetitionEndDate는 대회 종료일을 업데이트하고 블랙아웃 기간도 자동으로 재설정합니다
+func (s *SQLiteStorage) UpdateCompetitionEndDate(competitionID int, endDate time.Time) error {
+	blackoutStartDate := endDate.AddDate(0, 0, -constants.BlackoutDays)
+	return s.updateCompetition(competitionID,
+		`UPDATE competitions SET end_date = ?, blackout_start_date = ? WHERE id = ?`,
+		formatTime(endDate), formatTime(blackoutStartDate))
+}
+
+// RecordSolves 처음 확인된 풀이만 기록에 추가하고 추가된 개수를 반환합니다
+func (s *SQLiteStorage) RecordSolves(records []models.SolveRecord) (int, error) {
+	if len(records) == 0 {
+		return 0, nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	added, err := insertSolves(tx, records)
+	if err != nil {
+		utils.Error("Failed to record solves: %v", err)
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	if added > 0 {
+		utils.Info("Recorded %d new solves", added)
+	}
+	return added, nil
+}
+
+// GetSolveHistory 대회 참가자가 since 이후 처음 해결한 문제 기록을 반환합니다
+func (s *SQLiteStorage) GetSolveHistory(competitionID int, baekjoonID string, since time.Time) []models.SolveRecord {
+	return s.querySolves(`SELECT `+solveColumns+` FROM solves
+		WHERE competition_id = ? AND baekjoon_id = ? AND first_seen_at >= ? ORDER BY first_seen_at`,
+		competitionID, baekjoonID, formatTime(since))
+}
+
+// GetSolvesSince 대회에서 since 이후 처음 확인된 모든 풀이 기록을 반환합니다
+func (s *SQLiteStorage) GetSolvesSince(competitionID int, since time.Time) []models.SolveRecord {
+	return s.querySolves(`SELECT `+solveColumns+` FROM solves
+		WHERE competition_id = ? AND first_seen_at >= ? ORDER BY first_seen_at`, competitionID, formatTime(since))
+}
+
+func (s *SQLiteStorage) querySolves(query string, args ...interface{}) []models.SolveRecord {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		utils.Error("Failed to query solve records: %v", err)
+		return nil
+	}
+	defer rows.Close()
+
+	var records []models.SolveRecord
+	for rows.Next() {
+		var record models.SolveRecord
+		var firstSeenAt string
+		if err := rows.Scan(&record.CompetitionID, &record.ParticipantID, &record.BaekjoonID, &record.ProblemID, &record.Level,
+			&firstSeenAt, &record.Backlog); err != nil {
+			utils.Error("Failed to read solve record: %v", err)
+			return records
+		}
+		record.FirstSeenAt = parseTime(firstSeenAt)
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Error("Failed to read solve records: %v", err)
+	}
+	return records
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"database/sql"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+	"time"
+)
+
+func insertAccountLink(tx execer, link models.AccountLink) error {
+	_, err := tx.Exec(`INSERT INTO account_links (discord_user_id, baekjoon_id, verified_at) VALUES (?, ?, ?)
+		ON CONFLICT(discord_user_id) DO UPDATE SET baekjoon_id = excluded.baekjoon_id, verified_at = excluded.verified_at`,
+		link.DiscordUserID, link.BaekjoonID, formatTime(link.VerifiedAt))
+	return err
+}
+
+// findAccountLinkSQL 트랜잭션 안에서 디스코드 사용자의 계정 연동을 조회합니다 (없으면 nil)
+func findAccountLinkSQL(q queryer, discordUserID string) (*models.AccountLink, error) {
+	link := models.AccountLink{DiscordUserID: discordUserID}
+	err := q.QueryRow(`SELECT baekjoon_id FROM account_links WHERE discord_user_id = ?`, discordUserID).Scan(&link.BaekjoonID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// GetAccountLink 디스코드 사용자의 계정 연동을 반환합니다 (없으면 nil)
+func (s *SQLiteStorage) GetAccountLink(discordUserID string) *models.AccountLink {
+	return s.queryAccountLink(`SELECT discord_user_id, baekjoon_id, verified_at FROM account_links WHERE discord_user_id = ?`, discordUserID)
+}
+
+// FindAccountLinkByBaekjoonID 백준 ID를 인증한 계정 연동을 반환합니다 (없으면 nil)
+func (s *SQLiteStorage) FindAccountLinkByBaekjoonID(baekjoonID string) *models.AccountLink {
+	return s.queryAccountLink(`SELECT discord_user_id, baekjoon_id, verified_at FROM account_links WHERE baekjoon_id = ?`, baekjoonID)
+}
+
+func (s *SQLiteStorage) queryAccountLink(query string, arg string) *models.AccountLink {
+	var link models.AccountLink
+	var verifiedAt string
+	err := s.db.QueryRow(query, arg).Scan(&link.DiscordUserID, &link.BaekjoonID, &verifiedAt)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			utils.Error("Failed to load account link: %v", err)
+		}
+		return nil
+	}
+	link.VerifiedAt = parseTime(verifiedAt)
+	return &link
+}
+
+// LinkAccount 소유를 인증한 백준 ID를 디스코드 사용자에 연동합니다. 기존 연동은 새 백준 ID로 바뀝니다
+func (s *SQLiteStorage) LinkAccount(discordUserID, baekjoonID string) error {
+	if !utils.IsValidBaekjoonID(baekjoonID) {
+		return fmt.Errorf("잘못된 백준 ID: %s", baekjoonID)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var owner string
+	err = tx.QueryRow(`SELECT discord_user_id FROM account_links WHERE baekjoon_id = ?`, baekjoonID).Scan(&owner)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == nil && owner != discordUserID {
+		return errAccountLinkedToOther(baekjoonID)
+	}
+
+	link := models.AccountLink{DiscordUserID: discordUserID, BaekjoonID: baekjoonID, VerifiedAt: time.Now()}
+	if err := insertAccountLink(tx, link); err != nil {
+		utils.Error("Failed to save account link for %s: %v", discordUserID, err)
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	utils.Info("Linked discord user %s to baekjoon ID %s", discordUserID, baekjoonID)
+	return nil
+}
+
+// UnlinkAccount 디스코드 사용자의 계정 연동을 해제합니다
+func (s *SQLiteStorage) UnlinkAccount(discordUserID string) error {
+	result, err := s.db.Exec(`DELETE FROM account_links WHERE discord_user_id = ?`, discordUserID)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("연동된 백준 계정이 없습니다")
+	}
+	utils.Info("Unlinked discord user %s", discordUserID)
+	return nil
+}
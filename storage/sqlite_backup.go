@@ -0,0 +1,178 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"discord-bot/constants"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// restoreTables 복원할 테이블 목록입니다. 외래 키 때문에 부모 테이블이 먼저 와야 합니다
+var restoreTables = []string{
+	"competitions",
+	"participants",
+	"participant_start_problems",
+	"solves",
+	"guild_settings",
+	"account_links",
+	"handle_change_requests",
+	"registration_requests",
+	"final_standings",
+	"audit_log",
+}
+
+// CreateBackup VACUUM INTO로 데이터베이스의 일관된 복사본을 새 백업으로 저장합니다
+func (s *SQLiteStorage) CreateBackup() (models.BackupInfo, error) {
+	backupID, dir, err := newBackupDir(s.dataDir)
+	if err != nil {
+		utils.Error("Failed to create backup directory: %v", err)
+		return models.BackupInfo{}, err
+	}
+
+	if _, err := s.db.Exec(`VACUUM INTO ?`, filepath.Join(dir, constants.SQLiteFileName)); err != nil {
+		utils.Error("Failed to back up database: %v", err)
+		os.RemoveAll(dir)
+		return models.BackupInfo{}, err
+	}
+
+	utils.Info("Created backup %s in %s", backupID, s.dataDir)
+	return readBackupInfo(s.dataDir, backupID), nil
+}
+
+// ListBackups 백업 목록을 최신순으로 반환합니다
+func (s *SQLiteStorage) ListBackups() ([]models.BackupInfo, error) {
+	return listBackups(s.dataDir)
+}
+
+// PruneBackups 최근 keep개를 제외한 오래된 백업을 삭제합니다
+func (s *SQLiteStorage) PruneBackups(keep int) error {
+	return pruneBackups(s.dataDir, keep)
+}
+
+// RestoreBackup 지정된 백업의 내용으로 모든 테이블을 한 트랜잭션 안에서 교체합니다.
+// 되돌리기 전에 현재 상태를 새 백업으로 남기므로 복원도 다시 되돌릴 수 있습니다.
+func (s *SQLiteStorage) RestoreBackup(backupID string) error {
+	dir, err := backupPath(s.dataDir, backupID)
+	if err != nil {
+		return err
+	}
+	backupFile := filepath.Join(dir, constants.SQLiteFileName)
+	if _, err := os.Stat(backupFile); err != nil {
+		return fmt.Errorf("백업 %s에 데이터베이스 파일이 없습니다", backupID)
+	}
+
+	if _, err := s.CreateBackup(); err != nil {
+		return fmt.Errorf("복원 전 현재 상태 백업 실패: %w", err)
+	}
+
+	backupFile, cleanup, err := migratedBackupFile(s.dataDir, backupFile)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	// ATTACH는 연결 단위이므로 같은 연결에서 복원을 끝내야 합니다
+	ctx := context.Background()
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `ATTACH DATABASE ? AS backup`, "file:"+backupFile+"?mode=ro"); err != nil {
+		return fmt.Errorf("백업 데이터베이스 열기 실패: %w", err)
+	}
+	defer conn.ExecContext(ctx, `DETACH DATABASE backup`)
+
+	var currentVersion, backupVersion int
+	if err := conn.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM main.schema_migrations`).Scan(&currentVersion); err != nil {
+		return err
+	}
+	if err := conn.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM backup.schema_migrations`).Scan(&backupVersion); err != nil {
+		return fmt.Errorf("백업 스키마 버전 확인 실패: %w", err)
+	}
+	if backupVersion != currentVersion {
+		return fmt.Errorf("백업의 스키마 버전(%d)이 현재 버전(%d)과 다릅니다", backupVersion, currentVersion)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i := len(restoreTables) - 1; i >= 0; i-- {
+		if _, err := tx.Exec(`DELETE FROM main.` + restoreTables[i]); err != nil {
+			return err
+		}
+	}
+	for _, table := range restoreTables {
+		if _, err := tx.Exec(`INSERT INTO main.` + table + ` SELECT * FROM backup.` + table); err != nil {
+			return fmt.Errorf("%s 테이블 복원 실패: %w", table, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	utils.Info("Restored backup %s in %s", backupID, s.dataDir)
+	return nil
+}
+
+// migratedBackupFile 이전 스키마로 만들어진 백업이면 임시 복사본에 마이그레이션을 적용해 그 경로를 반환합니다.
+// 원본 백업 파일은 수정하지 않습니다.
+func migratedBackupFile(dataDir, backupFile string) (string, func(), error) {
+	noop := func() {}
+
+	db, err := sql.Open("sqlite", "file:"+backupFile+"?mode=ro")
+	if err != nil {
+		return "", noop, fmt.Errorf("백업 데이터베이스 열기 실패: %w", err)
+	}
+	var version int
+	err = db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	db.Close()
+	if err != nil {
+		return "", noop, fmt.Errorf("백업 스키마 버전 확인 실패: %w", err)
+	}
+	if version >= migrations[len(migrations)-1].version {
+		return backupFile, noop, nil
+	}
+
+	data, err := os.ReadFile(backupFile)
+	if err != nil {
+		return "", noop, err
+	}
+	tmp, err := os.CreateTemp(dataDir, "restore-*.db")
+	if err != nil {
+		return "", noop, err
+	}
+	tmpPath := tmp.Name()
+	cleanup := func() { os.Remove(tmpPath) }
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return "", noop, err
+	}
+
+	db, err = sql.Open("sqlite", "file:"+tmpPath)
+	if err != nil {
+		cleanup()
+		return "", noop, err
+	}
+	err = migrate(db)
+	db.Close()
+	if err != nil {
+		cleanup()
+		return "", noop, fmt.Errorf("백업 스키마 마이그레이션 실패: %w", err)
+	}
+
+	utils.Info("Migrated backup %s from schema version %d for restore", filepath.Base(filepath.Dir(backupFile)), version)
+	return tmpPath, cleanup, nil
+}
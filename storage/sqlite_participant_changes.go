@@ -0,0 +1,283 @@
+package storage
+
+import (
+	"database/sql"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+	"time"
+)
+
+// queryer 트랜잭션과 데이터베이스 모두에서 조회 쿼리를 실행하기 위한 인터페이스입니다
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+const handleChangeColumns = `id, competition_id, participant_id, old_baekjoon_id, new_baekjoon_id,
+	requested_by, status, requested_at, resolved_by, resolved_at`
+
+func insertHandleChangeRequest(tx execer, r models.HandleChangeRequest) error {
+	_, err := tx.Exec(`INSERT INTO handle_change_requests (`+handleChangeColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		r.ID, r.CompetitionID, r.ParticipantID, r.OldBaekjoonID, r.NewBaekjoonID,
+		r.RequestedBy, r.Status, formatTime(r.RequestedAt), r.ResolvedBy, formatOptionalTime(r.ResolvedAt))
+	return err
+}
+
+func insertAuditEntry(tx execer, e models.AuditEntry) error {
+	_, err := tx.Exec(`INSERT INTO audit_log
+		(id, competition_id, participant_id, action, actor_id, old_value, new_value, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		e.ID, e.CompetitionID, e.ParticipantID, e.Action, e.ActorID, e.OldValue, e.NewValue, formatTime(e.CreatedAt))
+	return err
+}
+
+// formatOptionalTime 시각이 비어 있으면 빈 문자열로 저장합니다
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return formatTime(t)
+}
+
+func parseOptionalTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	return parseTime(value)
+}
+
+// RenameParticipant 참가자의 표시 이름을 바꿉니다
+func (s *SQLiteStorage) RenameParticipant(competitionID, participantID int, name string) error {
+	if !utils.IsValidUsername(name) {
+		return fmt.Errorf("잘못된 사용자명: %s", name)
+	}
+
+	result, err := s.db.Exec(`UPDATE participants SET name = ? WHERE competition_id = ? AND id = ?`,
+		utils.SanitizeString(name), competitionID, participantID)
+	if err != nil {
+		utils.Error("Failed to rename participant %d: %v", participantID, err)
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("참가자를 찾을 수 없습니다: %d", participantID)
+	}
+
+	utils.Info("Renamed participant %d of competition %d to %s", participantID, competitionID, name)
+	return nil
+}
+
+// CreateHandleChangeRequest 참가자의 백준 ID 변경 요청을 만듭니다. 참가자마다 처리되지 않은 요청은 하나만 둘 수 있습니다
+func (s *SQLiteStorage) CreateHandleChangeRequest(competitionID, participantID int, newBaekjoonID, requestedBy string) (*models.HandleChangeRequest, error) {
+	if !utils.IsValidBaekjoonID(newBaekjoonID) {
+		return nil, fmt.Errorf("잘못된 백준 ID: %s", newBaekjoonID)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	oldBaekjoonID, err := checkHandleChangeSQL(tx, competitionID, participantID, newBaekjoonID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pendingID int
+	err = tx.QueryRow(`SELECT id FROM handle_change_requests
+		WHERE competition_id = ? AND participant_id = ? AND status = ?`,
+		competitionID, participantID, models.RequestPending).Scan(&pendingID)
+	if err == nil {
+		return nil, fmt.Errorf("이미 처리되지 않은 변경 요청이 있습니다 (요청 %d)", pendingID)
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	request := models.HandleChangeRequest{
+		CompetitionID: competitionID,
+		ParticipantID: participantID,
+		OldBaekjoonID: oldBaekjoonID,
+		NewBaekjoonID: newBaekjoonID,
+		RequestedBy:   requestedBy,
+		Status:        models.RequestPending,
+		RequestedAt:   time.Now(),
+	}
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(id), 0) + 1 FROM handle_change_requests`).Scan(&request.ID); err != nil {
+		return nil, err
+	}
+	if err := insertHandleChangeRequest(tx, request); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	utils.Info("Created handle change request %d: %s -> %s", request.ID, oldBaekjoonID, newBaekjoonID)
+	return &request, nil
+}
+
+// checkHandleChangeSQL 참가자의 백준 ID를 newBaekjoonID로 바꿀 수 있는지 확인하고 현재 백준 ID를 반환합니다
+func checkHandleChangeSQL(q queryer, competitionID, participantID int, newBaekjoonID string) (string, error) {
+	var participant models.Participant
+	err := q.QueryRow(`SELECT id, baekjoon_id FROM participants WHERE competition_id = ? AND id = ?`,
+		competitionID, participantID).Scan(&participant.ID, &participant.BaekjoonID)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("참가자를 찾을 수 없습니다: %d", participantID)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	rows, err := q.Query(`SELECT id, baekjoon_id FROM participants WHERE competition_id = ?`, competitionID)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var others []models.Participant
+	for rows.Next() {
+		var p models.Participant
+		if err := rows.Scan(&p.ID, &p.BaekjoonID); err != nil {
+			return "", err
+		}
+		others = append(others, p)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return participant.BaekjoonID, checkHandleChange(participant, others, newBaekjoonID)
+}
+
+// GetHandleChangeRequests 대회의 백준 ID 변경 요청을 요청 순서대로 반환합니다
+func (s *SQLiteStorage) GetHandleChangeRequests(competitionID int) []models.HandleChangeRequest {
+	requests, err := queryHandleChangeRequests(s.db, `WHERE competition_id = ? ORDER BY id`, competitionID)
+	if err != nil {
+		utils.Error("Failed to load handle change requests of competition %d: %v", competitionID, err)
+		return []models.HandleChangeRequest{}
+	}
+	return requests
+}
+
+func queryHandleChangeRequests(q queryer, where string, args ...interface{}) ([]models.HandleChangeRequest, error) {
+	rows, err := q.Query(`SELECT `+handleChangeColumns+` FROM handle_change_requests `+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := []models.HandleChangeRequest{}
+	for rows.Next() {
+		var r models.HandleChangeRequest
+		var requestedAt, resolvedAt string
+		if err := rows.Scan(&r.ID, &r.CompetitionID, &r.ParticipantID, &r.OldBaekjoonID, &r.NewBaekjoonID,
+			&r.RequestedBy, &r.Status, &requestedAt, &r.ResolvedBy, &resolvedAt); err != nil {
+			return nil, err
+		}
+		r.RequestedAt = parseTime(requestedAt)
+		r.ResolvedAt = parseOptionalTime(resolvedAt)
+		requests = append(requests, r)
+	}
+	return requests, rows.Err()
+}
+
+// ResolveHandleChangeRequest 변경 요청을 승인하거나 거절합니다.
+// 승인하면 참가자의 시작 티어와 시작 시점 해결 문제는 그대로 두고 백준 ID만 바꾸며, 이 대회의 풀이 기록도 새 ID로 옮깁니다.
+func (s *SQLiteStorage) ResolveHandleChangeRequest(requestID int, approve bool, resolvedBy string) (*models.HandleChangeRequest, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	requests, err := queryHandleChangeRequests(tx, `WHERE id = ?`, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("변경 요청 %d을(를) 찾을 수 없습니다", requestID)
+	}
+	request := requests[0]
+	if request.Status != models.RequestPending {
+		return nil, fmt.Errorf("이미 처리된 변경 요청입니다 (요청 %d)", requestID)
+	}
+
+	request.Status = models.RequestRejected
+	if approve {
+		link, err := findAccountLinkSQL(tx, request.RequestedBy)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkHandleChangeLink(request, link); err != nil {
+			return nil, err
+		}
+		if _, err := checkHandleChangeSQL(tx, request.CompetitionID, request.ParticipantID, request.NewBaekjoonID); err != nil {
+			return nil, err
+		}
+		if _, err := tx.Exec(`UPDATE participants SET baekjoon_id = ? WHERE competition_id = ? AND id = ?`,
+			request.NewBaekjoonID, request.CompetitionID, request.ParticipantID); err != nil {
+			return nil, err
+		}
+		// 풀이 기록은 대회마다 따로 남으므로 다른 대회의 기록은 그대로 둡니다
+		if _, err := tx.Exec(`UPDATE solves SET baekjoon_id = ? WHERE competition_id = ? AND baekjoon_id = ?`,
+			request.NewBaekjoonID, request.CompetitionID, request.OldBaekjoonID); err != nil {
+			return nil, err
+		}
+		request.Status = models.RequestApproved
+	}
+
+	request.ResolvedBy = resolvedBy
+	request.ResolvedAt = time.Now()
+	if _, err := tx.Exec(`UPDATE handle_change_requests SET status = ?, resolved_by = ?, resolved_at = ? WHERE id = ?`,
+		request.Status, request.ResolvedBy, formatOptionalTime(request.ResolvedAt), request.ID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	utils.Info("Resolved handle change request %d: %s", requestID, request.Status)
+	return &request, nil
+}
+
+// AppendAuditEntry 참가자 정보 변경 기록을 남깁니다
+func (s *SQLiteStorage) AppendAuditEntry(entry models.AuditEntry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	_, err := s.db.Exec(`INSERT INTO audit_log
+		(competition_id, participant_id, action, actor_id, old_value, new_value, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		entry.CompetitionID, entry.ParticipantID, entry.Action, entry.ActorID, entry.OldValue, entry.NewValue, formatTime(entry.CreatedAt))
+	if err != nil {
+		utils.Error("Failed to append audit entry: %v", err)
+	}
+	return err
+}
+
+// GetAuditLog 대회의 참가자 정보 변경 기록을 최신순으로 반환합니다
+func (s *SQLiteStorage) GetAuditLog(competitionID int) []models.AuditEntry {
+	rows, err := s.db.Query(`SELECT id, competition_id, participant_id, action, actor_id, old_value, new_value, created_at
+		FROM audit_log WHERE competition_id = ? ORDER BY id DESC`, competitionID)
+	if err != nil {
+		utils.Error("Failed to load audit log of competition %d: %v", competitionID, err)
+		return []models.AuditEntry{}
+	}
+	defer rows.Close()
+
+	entries := []models.AuditEntry{}
+	for rows.Next() {
+		var e models.AuditEntry
+		var createdAt string
+		if err := rows.Scan(&e.ID, &e.CompetitionID, &e.ParticipantID, &e.Action, &e.ActorID,
+			&e.OldValue, &e.NewValue, &createdAt); err != nil {
+			utils.Error("Failed to read audit entry: %v", err)
+			return entries
+		}
+		e.CreatedAt = parseTime(createdAt)
+		entries = append(entries, e)
+	}
+	return entries
+}
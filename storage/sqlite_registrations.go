@@ -0,0 +1,363 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+	"time"
+)
+
+const registrationRequestColumns = `id, competition_id, name, baekjoon_id, discord_user_id,
+	status, requested_at, resolved_by, resolved_at`
+
+func insertRegistrationRequest(tx execer, r models.RegistrationRequest) error {
+	_, err := tx.Exec(`INSERT INTO registration_requests (`+registrationRequestColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		r.ID, r.CompetitionID, r.Name, r.BaekjoonID, r.DiscordUserID,
+		r.Status, formatTime(r.RequestedAt), r.ResolvedBy, formatOptionalTime(r.ResolvedAt))
+	return err
+}
+
+// UpdateCompetitionRegistration 대회의 등록 기간과 승인 방식을 변경합니다.
+// 대회 시작 전에 시작 기록 시점을 대회 시작으로 바꾸면 이미 등록한 참가자도 시작 시각에 다시 기록합니다.
+func (s *SQLiteStorage) UpdateCompetitionRegistration(competitionID int, policy models.RegistrationPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return err
+	}
+	registration, err := encodeRegistration(policy)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`UPDATE competitions SET registration = ? WHERE id = ?`, registration, competitionID); err != nil {
+		utils.Error("Failed to update competition %d: %v", competitionID, err)
+		return err
+	}
+	competition.Registration = policy
+	if competition.DefersSnapshot(time.Now()) {
+		if _, err := tx.Exec(`UPDATE participants SET snapshot_pending = 1 WHERE competition_id = ?`, competitionID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// CreateRegistrationRequest 승인 방식 대회에 등록 신청을 만듭니다. 같은 사람의 처리되지 않은 신청은 하나만 둘 수 있습니다
+func (s *SQLiteStorage) CreateRegistrationRequest(competitionID int, name, baekjoonID, discordUserID string) (*models.RegistrationRequest, error) {
+	if _, err := s.findCompetition(competitionID); err != nil {
+		return nil, err
+	}
+	if err := validateParticipantInput(name, baekjoonID); err != nil {
+		return nil, err
+	}
+	if err := s.checkDuplicateParticipant(competitionID, baekjoonID); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	pending, err := queryRegistrationRequests(tx, `WHERE competition_id = ? AND status = ?`, competitionID, models.RequestPending)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range pending {
+		if isSameApplicant(r, baekjoonID, discordUserID) {
+			return nil, fmt.Errorf("이미 처리되지 않은 등록 신청이 있습니다 (신청 %d)", r.ID)
+		}
+	}
+
+	request := models.RegistrationRequest{
+		CompetitionID: competitionID,
+		Name:          utils.SanitizeString(name),
+		BaekjoonID:    baekjoonID,
+		DiscordUserID: discordUserID,
+		Status:        models.RequestPending,
+		RequestedAt:   time.Now(),
+	}
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(id), 0) + 1 FROM registration_requests`).Scan(&request.ID); err != nil {
+		return nil, err
+	}
+	if err := insertRegistrationRequest(tx, request); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	utils.Info("Created registration request %d for competition %d: %s", request.ID, competitionID, baekjoonID)
+	return &request, nil
+}
+
+// GetRegistrationRequests 대회의 등록 신청을 신청 순서대로 반환합니다
+func (s *SQLiteStorage) GetRegistrationRequests(competitionID int) []models.RegistrationRequest {
+	requests, err := queryRegistrationRequests(s.db, `WHERE competition_id = ? ORDER BY id`, competitionID)
+	if err != nil {
+		utils.Error("Failed to load registration requests of competition %d: %v", competitionID, err)
+		return []models.RegistrationRequest{}
+	}
+	return requests
+}
+
+// GetRegistrationRequest ID로 등록 신청을 찾습니다 (없으면 nil)
+func (s *SQLiteStorage) GetRegistrationRequest(requestID int) *models.RegistrationRequest {
+	requests, err := queryRegistrationRequests(s.db, `WHERE id = ?`, requestID)
+	if err != nil {
+		utils.Error("Failed to load registration request %d: %v", requestID, err)
+		return nil
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+	return &requests[0]
+}
+
+func queryRegistrationRequests(q queryer, where string, args ...interface{}) ([]models.RegistrationRequest, error) {
+	rows, err := q.Query(`SELECT `+registrationRequestColumns+` FROM registration_requests `+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := []models.RegistrationRequest{}
+	for rows.Next() {
+		var r models.RegistrationRequest
+		var requestedAt, resolvedAt string
+		if err := rows.Scan(&r.ID, &r.CompetitionID, &r.Name, &r.BaekjoonID, &r.DiscordUserID,
+			&r.Status, &requestedAt, &r.ResolvedBy, &resolvedAt); err != nil {
+			return nil, err
+		}
+		r.RequestedAt = parseTime(requestedAt)
+		r.ResolvedAt = parseOptionalTime(resolvedAt)
+		requests = append(requests, r)
+	}
+	return requests, rows.Err()
+}
+
+// ApproveRegistrationRequest 등록 신청을 승인하고 신청자를 참가자로 추가합니다.
+// 참가자 추가와 신청 상태 변경은 한 트랜잭션으로 저장되므로 어느 한쪽만 반영되지 않습니다.
+func (s *SQLiteStorage) ApproveRegistrationRequest(ctx context.Context, requestID int, resolvedBy string, startTier, startRating int) (*models.RegistrationRequest, error) {
+	request, err := checkRegistrationApprovalSQL(s.db, requestID)
+	if err != nil {
+		return nil, err
+	}
+	competition, err := s.findCompetition(request.CompetitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 시작 문제 데이터 수집 (네트워크 요청이므로 트랜잭션 밖에서 수행)
+	startProblemIDs, pending := loadStartingProblems(ctx, s.apiClient, request.BaekjoonID, competition.DefersSnapshot(time.Now()))
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// 요청 중에 신청이 처리되거나 계정 연동이 바뀌었을 수 있으므로 다시 확인
+	if request, err = checkRegistrationApprovalSQL(tx, requestID); err != nil {
+		return nil, err
+	}
+
+	var nextID int
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(id), 0) + 1 FROM participants WHERE competition_id = ?`,
+		request.CompetitionID).Scan(&nextID); err != nil {
+		return nil, err
+	}
+	participant := newParticipant(nextID, request.Name, request.BaekjoonID, request.DiscordUserID,
+		startTier, startRating, startProblemIDs, len(startProblemIDs))
+	participant.SnapshotPending = pending
+	if err := insertParticipant(tx, request.CompetitionID, participant); err != nil {
+		utils.Error("Failed to insert participant %s: %v", request.BaekjoonID, err)
+		return nil, err
+	}
+
+	request.Status = models.RequestApproved
+	request.ResolvedBy = resolvedBy
+	request.ResolvedAt = time.Now()
+	if err := updateRegistrationStatus(tx, request); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	utils.Info("Added new participant to competition %d: %s (%s)", request.CompetitionID, participant.Name, participant.BaekjoonID)
+	utils.Info("Resolved registration request %d: %s", requestID, request.Status)
+	return &request, nil
+}
+
+// checkRegistrationApprovalSQL 승인할 등록 신청을 찾고 신청자를 참가자로 추가할 수 있는지 확인합니다
+func checkRegistrationApprovalSQL(q queryer, requestID int) (models.RegistrationRequest, error) {
+	requests, err := queryRegistrationRequests(q, `WHERE id = ?`, requestID)
+	if err != nil {
+		return models.RegistrationRequest{}, err
+	}
+	if len(requests) == 0 {
+		return models.RegistrationRequest{}, fmt.Errorf("등록 신청 %d을(를) 찾을 수 없습니다", requestID)
+	}
+	request := requests[0]
+
+	var finalizedAt string
+	err = q.QueryRow(`SELECT finalized_at FROM competitions WHERE id = ?`, request.CompetitionID).Scan(&finalizedAt)
+	if err == sql.ErrNoRows {
+		return request, fmt.Errorf("ID %d인 대회가 없습니다", request.CompetitionID)
+	}
+	if err != nil {
+		return request, err
+	}
+
+	link, err := findAccountLinkSQL(q, request.DiscordUserID)
+	if err != nil {
+		return request, err
+	}
+	if err := checkRegistrationApproval(request, finalizedAt != "", link); err != nil {
+		return request, err
+	}
+
+	var exists bool
+	if err := q.QueryRow(`SELECT EXISTS(SELECT 1 FROM participants WHERE competition_id = ? AND baekjoon_id = ?)`,
+		request.CompetitionID, request.BaekjoonID).Scan(&exists); err != nil {
+		return request, err
+	}
+	if exists {
+		return request, fmt.Errorf("백준 ID %s로 이미 등록된 참가자가 있습니다", request.BaekjoonID)
+	}
+	return request, nil
+}
+
+// RejectRegistrationRequest 등록 신청을 거절 상태로 바꿉니다
+func (s *SQLiteStorage) RejectRegistrationRequest(requestID int, resolvedBy string) (*models.RegistrationRequest, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	requests, err := queryRegistrationRequests(tx, `WHERE id = ?`, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("등록 신청 %d을(를) 찾을 수 없습니다", requestID)
+	}
+	request := requests[0]
+	if request.Status != models.RequestPending {
+		return nil, fmt.Errorf("이미 처리된 등록 신청입니다 (신청 %d)", requestID)
+	}
+
+	request.Status = models.RequestRejected
+	request.ResolvedBy = resolvedBy
+	request.ResolvedAt = time.Now()
+	if err := updateRegistrationStatus(tx, request); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	utils.Info("Resolved registration request %d: %s", requestID, request.Status)
+	return &request, nil
+}
+
+func updateRegistrationStatus(tx execer, r models.RegistrationRequest) error {
+	_, err := tx.Exec(`UPDATE registration_requests SET status = ?, resolved_by = ?, resolved_at = ? WHERE id = ?`,
+		r.Status, r.ResolvedBy, formatOptionalTime(r.ResolvedAt), r.ID)
+	return err
+}
+
+// CaptureStartSnapshot 참가자의 현재 티어, 레이팅, 해결한 문제를 시작 시점 기록으로 저장하고 대기 표시를 지웁니다
+func (s *SQLiteStorage) CaptureStartSnapshot(ctx context.Context, competitionID, participantID int) error {
+	var baekjoonID string
+	err := s.db.QueryRow(`SELECT baekjoon_id FROM participants WHERE competition_id = ? AND id = ?`,
+		competitionID, participantID).Scan(&baekjoonID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("참가자를 찾을 수 없습니다: %d", participantID)
+	}
+	if err != nil {
+		return err
+	}
+
+	// 네트워크 요청이므로 트랜잭션 밖에서 수행
+	snapshot, err := fetchStartSnapshot(ctx, s.apiClient, baekjoonID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	solvedDuring, err := solvedDuringSQL(tx, competitionID, baekjoonID)
+	if err != nil {
+		return err
+	}
+	snapshot = snapshot.without(solvedDuring)
+
+	// 요청 중에 참가자가 삭제되거나 백준 ID가 바뀌었다면 갱신되는 행이 없습니다
+	result, err := tx.Exec(`UPDATE participants
+		SET start_tier = ?, start_rating = ?, start_problem_count = ?, snapshot_pending = 0
+		WHERE competition_id = ? AND id = ? AND baekjoon_id = ?`,
+		snapshot.tier, snapshot.rating, len(snapshot.problemIDs), competitionID, participantID, baekjoonID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("기록 중에 참가자 %d이(가) 삭제되었거나 백준 ID가 바뀌었습니다", participantID)
+	}
+	if _, err := tx.Exec(`DELETE FROM participant_start_problems WHERE competition_id = ? AND participant_id = ?`,
+		competitionID, participantID); err != nil {
+		return err
+	}
+	for _, problemID := range snapshot.problemIDs {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO participant_start_problems
+			(competition_id, participant_id, problem_id) VALUES (?, ?, ?)`,
+			competitionID, participantID, problemID); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	utils.Info("Captured start snapshot for participant %s of competition %d", baekjoonID, competitionID)
+	return nil
+}
+
+// solvedDuringSQL 대회가 시작한 뒤 풀이 기록에 처음 확인된 참가자의 문제를 반환합니다
+func solvedDuringSQL(q queryer, competitionID int, baekjoonID string) (map[int]bool, error) {
+	rows, err := q.Query(`SELECT s.problem_id FROM solves s JOIN competitions c ON c.id = s.competition_id
+		WHERE s.competition_id = ? AND s.baekjoon_id = ? AND s.first_seen_at >= c.start_date`, competitionID, baekjoonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	solved := make(map[int]bool)
+	for rows.Next() {
+		var problemID int
+		if err := rows.Scan(&problemID); err != nil {
+			return nil, err
+		}
+		solved[problemID] = true
+	}
+	return solved, rows.Err()
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"discord-bot/models"
+	"discord-bot/utils"
+	"fmt"
+)
+
+func insertFinalStandings(tx execer, standings models.FinalStandings) error {
+	for i, score := range standings.Scores {
+		if _, err := tx.Exec(`INSERT INTO final_standings
+			(competition_id, rank, participant_id, name, baekjoon_id, score, current_tier, current_rating, problem_count)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			standings.CompetitionID, i+1, score.ParticipantID, score.Name, score.BaekjoonID,
+			score.Score, score.CurrentTier, score.CurrentRating, score.ProblemCount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FinalizeCompetition 대회의 최종 순위를 저장하고 대회를 종료 상태로 바꿉니다.
+// 한 번 확정된 최종 순위는 바꿀 수 없으며, 발표를 마칠 때까지 발표 대기로 표시합니다.
+func (s *SQLiteStorage) FinalizeCompetition(standings models.FinalStandings) error {
+	if _, err := s.findCompetition(standings.CompetitionID); err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 확정 여부 확인과 갱신을 한 문장으로 처리해 두 번 확정되지 않도록 합니다
+	result, err := tx.Exec(`UPDATE competitions SET is_active = 0, finalized_at = ?, results_pending = 1
+		WHERE id = ? AND finalized_at = ''`,
+		formatOptionalTime(standings.FinalizedAt), standings.CompetitionID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("이미 최종 결과가 확정된 대회입니다")
+	}
+	if err := insertFinalStandings(tx, standings); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	utils.Info("Finalized competition %d with %d participants", standings.CompetitionID, len(standings.Scores))
+	return nil
+}
+
+// GetFinalStandings 대회의 최종 순위를 반환합니다 (확정되지 않았으면 nil)
+func (s *SQLiteStorage) GetFinalStandings(competitionID int) *models.FinalStandings {
+	var finalizedAt string
+	err := s.db.QueryRow(`SELECT finalized_at FROM competitions WHERE id = ?`, competitionID).Scan(&finalizedAt)
+	if err != nil || finalizedAt == "" {
+		return nil
+	}
+
+	rows, err := s.db.Query(`SELECT participant_id, name, baekjoon_id, score, current_tier, current_rating, problem_count
+		FROM final_standings WHERE competition_id = ? ORDER BY rank`, competitionID)
+	if err != nil {
+		utils.Error("Failed to load final standings of competition %d: %v", competitionID, err)
+		return nil
+	}
+	defer rows.Close()
+
+	standings := &models.FinalStandings{
+		CompetitionID: competitionID,
+		FinalizedAt:   parseOptionalTime(finalizedAt),
+		Scores:        []models.ScoreData{},
+	}
+	for rows.Next() {
+		var score models.ScoreData
+		if err := rows.Scan(&score.ParticipantID, &score.Name, &score.BaekjoonID, &score.Score,
+			&score.CurrentTier, &score.CurrentRating, &score.ProblemCount); err != nil {
+			utils.Error("Failed to read final standings of competition %d: %v", competitionID, err)
+			return nil
+		}
+		standings.Scores = append(standings.Scores, score)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Error("Failed to read final standings of competition %d: %v", competitionID, err)
+		return nil
+	}
+	return standings
+}
+
+// MarkResultsAnnounced 대회의 최종 결과 발표 대기 표시를 지웁니다
+func (s *SQLiteStorage) MarkResultsAnnounced(competitionID int) error {
+	return s.updateCompetition(competitionID, `UPDATE competitions SET results_pending = 0 WHERE id = ?`)
+}
@@ -1,192 +1,492 @@
 package storage
 
 import (
+	"context"
 	"discord-bot/constants"
 	"discord-bot/interfaces"
 	"discord-bot/models"
 	"discord-bot/utils"
 	"encoding/json"
 	"fmt"
-	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"sync"
 	"time"
 )
 
-// Storage 참가자와 대회 데이터를 관리하는 저장소입니다
+// Storage 한 길드의 대회와 대회별 참가자, 풀이 기록, 길드 설정을 관리하는 저장소입니다.
+// 디스코드 핸들러 고루틴과 스케줄러가 동시에 사용하므로 모든 공개 메서드는 mu로 보호됩니다.
 type Storage struct {
-	participants []models.Participant
-	competition  *models.Competition
+	mu           sync.RWMutex
+	competitions []*models.Competition // ID 오름차순, 각 대회가 자신의 참가자 목록을 가짐
+	solves       []models.SolveRecord
+	solveIndex   map[solveKey]bool // 대회별로 이미 기록된 풀이
+	settings     models.GuildSettings
+	accounts     []models.AccountLink // 인증된 디스코드 계정 연동
+	changes      []models.HandleChangeRequest
+	auditLog     []models.AuditEntry
+	applications []models.RegistrationRequest // 승인 방식 대회의 등록 신청
+	results      []models.FinalStandings      // 종료된 대회의 최종 순위
 	apiClient    interfaces.APIClient
+	dataDir      string // 이 저장소의 데이터 파일이 위치한 디렉터리
+	legacyDir    string // 길드 분리 이전 데이터 파일 위치 (마이그레이션 대상이 아니면 빈 문자열)
 }
 
-// NewStorage 새로운 Storage 인스턴스를 생성하고 데이터를 로드합니다
-func NewStorage(apiClient interfaces.APIClient) interfaces.StorageRepository {
-	utils.Info("Initializing storage system")
+// NewStorage 새로운 Storage 인스턴스를 생성하고 dataDir에서 데이터를 로드합니다.
+// legacyDir가 지정되면 dataDir에 데이터가 없을 때 해당 위치의 이전 형식 파일을 가져옵니다.
+func NewStorage(apiClient interfaces.APIClient, dataDir, legacyDir string) (interfaces.StorageRepository, error) {
+	utils.Info("Initializing storage system in %s", dataDir)
+	if err := os.MkdirAll(dataDir, constants.DirPermission); err != nil {
+		return nil, fmt.Errorf("데이터 디렉터리 생성 실패: %w", err)
+	}
+
 	s := &Storage{
 		apiClient: apiClient,
+		dataDir:   dataDir,
+		legacyDir: legacyDir,
 	}
 	s.loadData()
 	utils.Info("Storage system initialized successfully")
-	return s
+	return s, nil
+}
+
+// path 데이터 디렉터리 안의 파일 경로를 반환합니다
+func (s *Storage) path(fileName string) string {
+	return filepath.Join(s.dataDir, fileName)
 }
 
+// dataRepairs 데이터 파일을 읽으면서 생긴, 디스크에 다시 써야 하는 변경 사항입니다
+type dataRepairs struct {
+	corrupted    map[string][]byte // 손상되어 따로 보관할 파일 경로와 원래 내용
+	competitions bool              // 이전 형식에서 옮기거나 백업에서 복구한 대회 데이터
+	solves       bool              // 이전 형식에서 옮기거나 백업에서 복구하거나 대회별로 나눈 풀이 기록
+}
+
+// loadData 데이터 파일을 읽고, 읽으면서 옮기거나 복구한 데이터를 저장합니다
 func (s *Storage) loadData() {
-	s.loadParticipants()
-	s.loadCompetition()
+	s.applyRepairs(s.readData())
+}
+
+// readData 데이터 파일을 메모리로 읽습니다. 디스크에는 아무것도 쓰지 않고 다시 써야 할 변경 사항을 반환합니다
+func (s *Storage) readData() dataRepairs {
+	repairs := dataRepairs{corrupted: make(map[string][]byte)}
+	s.loadCompetitions(&repairs)
+	s.loadSolves(&repairs)
+	if scoped, changed := scopeLegacySolves(s.competitions, s.solves); changed {
+		s.solves = scoped
+		s.rebuildSolveIndex()
+		utils.Info("Split legacy solve records into %d per-competition records", len(s.solves))
+		repairs.solves = true
+	}
+	s.loadSettings()
+	s.accounts = []models.AccountLink{}
+	s.loadOptionalFile(constants.AccountsFileName, &s.accounts)
+	s.changes = []models.HandleChangeRequest{}
+	s.loadOptionalFile(constants.HandleChangeFileName, &s.changes)
+	s.auditLog = []models.AuditEntry{}
+	s.loadOptionalFile(constants.AuditLogFileName, &s.auditLog)
+	s.applications = []models.RegistrationRequest{}
+	s.loadOptionalFile(constants.RegistrationFileName, &s.applications)
+	s.results = []models.FinalStandings{}
+	s.loadOptionalFile(constants.ResultsFileName, &s.results)
+	return repairs
+}
+
+// applyRepairs readData가 반환한 변경 사항을 디스크에 씁니다
+func (s *Storage) applyRepairs(repairs dataRepairs) {
+	for fileName, data := range repairs.corrupted {
+		backupFile := fileName + constants.BackupFileSuffix
+		os.WriteFile(backupFile, data, constants.FilePermission)
+		utils.Warn("Corrupted file %s backed up as %s", fileName, backupFile)
+	}
+	if repairs.competitions {
+		if err := s.saveCompetitions(); err != nil {
+			utils.Error("Failed to save migrated or recovered competitions: %v", err)
+		}
+	}
+	if repairs.solves {
+		if err := s.saveSolves(); err != nil {
+			utils.Error("Failed to save migrated or recovered solve records: %v", err)
+		}
+	}
 }
 
-// loadParticipants 참가자 데이터를 파일에서 로드합니다
-func (s *Storage) loadParticipants() {
-	utils.Debug("Loading participants from file: %s", constants.ParticipantsFileName)
-	file, err := os.Open(constants.ParticipantsFileName)
+// loadCompetitions 대회 목록을 파일에서 로드합니다
+func (s *Storage) loadCompetitions(repairs *dataRepairs) {
+	s.competitions = []*models.Competition{}
+
+	fileName := s.path(constants.CompetitionsFileName)
+	utils.Debug("Loading competitions from file: %s", fileName)
+	data, err := os.ReadFile(fileName)
 	if err != nil {
 		if os.IsNotExist(err) {
-			utils.Warn("Participants file not found, starting with empty list")
-			s.participants = []models.Participant{}
+			utils.Warn("Competitions file not found, checking legacy files")
+			repairs.competitions = s.migrateLegacyFiles()
 			return
 		}
-		utils.Error("Failed to open participants file: %v", err)
-		// 파일이 존재하지만 열 수 없는 경우 빈 슬라이스로 초기화하지 않음
-		s.participants = []models.Participant{}
+		utils.Error("Failed to read competitions file: %v", err)
+		return
+	}
+
+	// 빈 파일 처리
+	if len(data) == 0 {
+		utils.Info("Empty competitions file, starting with no competitions")
 		return
 	}
-	defer file.Close()
 
-	data, err := io.ReadAll(file)
+	if err := json.Unmarshal(data, &s.competitions); err != nil {
+		utils.Error("Failed to parse competitions data: %v", err)
+		// JSON 파싱 실패 시 원래 파일을 따로 보관하고 백업에서 복구
+		repairs.corrupted[fileName] = data
+		s.competitions = []*models.Competition{}
+		repairs.competitions = recoverFromBackups(s.dataDir, constants.CompetitionsFileName, &s.competitions)
+		return
+	}
+
+	utils.Info("Loaded %d competitions", len(s.competitions))
+}
+
+// migrateLegacyFiles 길드 분리 이전(작업 디렉터리)의 데이터를 이 저장소로 읽어 옵니다.
+// competitions.json이 있으면 그대로, 없으면 단일 대회 시절의 competition.json/participants.json을 변환합니다.
+// 옮긴 데이터가 있으면 true를 반환합니다.
+func (s *Storage) migrateLegacyFiles() bool {
+	if s.legacyDir == "" {
+		return false
+	}
+
+	if readJSONFile(filepath.Join(s.legacyDir, constants.CompetitionsFileName), &s.competitions) {
+		utils.Info("Migrated %d legacy competitions from %s", len(s.competitions), s.legacyDir)
+	} else {
+		var competition models.Competition
+		if !readJSONFile(filepath.Join(s.legacyDir, constants.CompetitionFileName), &competition) {
+			return false
+		}
+
+		var participants []models.Participant
+		if readJSONFile(filepath.Join(s.legacyDir, constants.ParticipantsFileName), &participants) {
+			competition.Participants = participants
+		}
+		if competition.ID == 0 {
+			competition.ID = 1
+		}
+
+		s.competitions = append(s.competitions, &competition)
+		utils.Info("Migrated legacy competition %s with %d participants", competition.Name, len(competition.Participants))
+	}
+	return true
+}
+
+// readJSONFile JSON 데이터 파일을 읽습니다 (파일이 없거나 손상되면 false)
+func readJSONFile(fileName string, v interface{}) bool {
+	data, err := os.ReadFile(fileName)
+	if err != nil || len(data) == 0 {
+		return false
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		utils.Warn("Failed to parse file %s: %v", fileName, err)
+		return false
+	}
+	return true
+}
+
+// loadSolves 풀이 기록을 파일에서 로드합니다
+func (s *Storage) loadSolves(repairs *dataRepairs) {
+	s.solves = []models.SolveRecord{}
+	defer s.rebuildSolveIndex()
+
+	fileName := s.path(constants.SolvesFileName)
+	utils.Debug("Loading solve records from file: %s", fileName)
+	data, err := os.ReadFile(fileName)
 	if err != nil {
-		utils.Error("Failed to read participants file: %v", err)
-		// 읽기 실패 시에도 기존 데이터 보존을 위해 빈 슬라이스로 초기화하지 않음
-		s.participants = []models.Participant{}
+		if os.IsNotExist(err) {
+			utils.Warn("Solve records file not found, starting with empty ledger")
+			if s.legacyDir != "" && readJSONFile(filepath.Join(s.legacyDir, constants.SolvesFileName), &s.solves) {
+				utils.Info("Migrated %d legacy solve records from %s", len(s.solves), s.legacyDir)
+				repairs.solves = true
+			}
+		} else {
+			utils.Error("Failed to read solve records file: %v", err)
+		}
 		return
 	}
 
 	// 빈 파일 처리
 	if len(data) == 0 {
-		utils.Info("Empty participants file, starting with empty list")
-		s.participants = []models.Participant{}
+		utils.Info("Empty solve records file, starting with empty ledger")
 		return
 	}
 
-	if err := json.Unmarshal(data, &s.participants); err != nil {
-		utils.Error("Failed to parse participants data: %v", err)
-		// JSON 파싱 실패 시 백업 파일 생성
-		backupFile := constants.ParticipantsFileName + constants.BackupFileSuffix
-		os.WriteFile(backupFile, data, constants.FilePermission)
-		utils.Warn("Corrupted participants file backed up as %s", backupFile)
-		s.participants = []models.Participant{}
+	if err := json.Unmarshal(data, &s.solves); err != nil {
+		utils.Error("Failed to parse solve records: %v", err)
+		// JSON 파싱 실패 시 원래 파일을 따로 보관하고 백업에서 복구
+		repairs.corrupted[fileName] = data
+		s.solves = []models.SolveRecord{}
+		repairs.solves = recoverFromBackups(s.dataDir, constants.SolvesFileName, &s.solves)
 		return
 	}
 
-	utils.Info("Loaded %d participants", len(s.participants))
+	utils.Info("Loaded %d solve records", len(s.solves))
 }
 
-// loadCompetition 대회 데이터를 파일에서 로드합니다
-func (s *Storage) loadCompetition() {
-	utils.Debug("Loading competition from file: %s", constants.CompetitionFileName)
-	file, err := os.Open(constants.CompetitionFileName)
+// loadSettings 길드 설정을 파일에서 로드합니다
+func (s *Storage) loadSettings() {
+	s.settings = models.GuildSettings{}
+
+	fileName := s.path(constants.SettingsFileName)
+	data, err := os.ReadFile(fileName)
 	if err != nil {
-		if os.IsNotExist(err) {
-			utils.Warn("Competition file not found")
-		} else {
-			utils.Error("Failed to open competition file: %v", err)
+		if !os.IsNotExist(err) {
+			utils.Error("Failed to read settings file: %v", err)
 		}
-		s.competition = nil
 		return
 	}
-	defer file.Close()
 
-	data, err := io.ReadAll(file)
+	if len(data) == 0 {
+		return
+	}
+
+	if err := json.Unmarshal(data, &s.settings); err != nil {
+		utils.Error("Failed to parse settings data: %v", err)
+		s.settings = models.GuildSettings{}
+		recoverFromBackups(s.dataDir, constants.SettingsFileName, &s.settings)
+	}
+}
+
+// loadOptionalFile 없어도 되는 데이터 파일을 v에 로드합니다. 손상되었으면 백업에서 복구합니다
+func (s *Storage) loadOptionalFile(fileName string, v interface{}) {
+	data, err := os.ReadFile(s.path(fileName))
 	if err != nil {
-		utils.Error("Failed to read competition file: %v", err)
-		s.competition = nil
+		if !os.IsNotExist(err) {
+			utils.Error("Failed to read %s: %v", fileName, err)
+		}
 		return
 	}
 
-	// 빈 파일 처리
 	if len(data) == 0 {
-		utils.Info("Empty competition file")
-		s.competition = nil
 		return
 	}
 
-	if err := json.Unmarshal(data, &s.competition); err != nil {
-		utils.Error("Failed to parse competition data: %v", err)
-		// JSON 파싱 실패 시 백업 파일 생성
-		backupFile := constants.CompetitionFileName + constants.BackupFileSuffix
-		os.WriteFile(backupFile, data, constants.FilePermission)
-		utils.Warn("Corrupted competition file backed up as %s", backupFile)
-		s.competition = nil
-		return
+	if err := json.Unmarshal(data, v); err != nil {
+		utils.Error("Failed to parse %s: %v", fileName, err)
+		recoverFromBackups(s.dataDir, fileName, v)
+	}
+}
+
+// saveDataFile 호출자가 mu를 잡은 상태에서 v를 데이터 파일에 저장합니다
+func (s *Storage) saveDataFile(fileName string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", constants.JSONIndentSpaces)
+	if err != nil {
+		utils.Error("Failed to marshal %s: %v", fileName, err)
+		return err
+	}
+
+	if err := writeFileAtomic(s.path(fileName), data, constants.FilePermission); err != nil {
+		utils.Error("Failed to save %s: %v", fileName, err)
+		return err
+	}
+	return nil
+}
+
+// restoreDataFile 여러 파일을 이어서 저장하다 실패했을 때 먼저 저장한 파일을 메모리의 상태로 다시 씁니다.
+// 다시 쓰지 못하면 파일끼리 어긋난 채로 남으므로 따로 기록합니다.
+func (s *Storage) restoreDataFile(fileName string, v interface{}) {
+	if err := s.saveDataFile(fileName, v); err != nil {
+		utils.Error("Failed to roll back %s; it may disagree with the other data files: %v", fileName, err)
+	}
+}
+
+// solveKey 풀이 기록의 중복 여부를 판단하는 키입니다
+type solveKey struct {
+	competitionID int
+	baekjoonID    string
+	problemID     int
+}
+
+func solveKeyOf(record models.SolveRecord) solveKey {
+	return solveKey{record.CompetitionID, record.BaekjoonID, record.ProblemID}
+}
+
+// rebuildSolveIndex 중복 기록 방지를 위한 인덱스를 다시 만듭니다
+func (s *Storage) rebuildSolveIndex() {
+	s.solveIndex = make(map[solveKey]bool, len(s.solves))
+	for _, record := range s.solves {
+		s.solveIndex[solveKeyOf(record)] = true
+	}
+}
+
+// scopeLegacySolves 대회 구분 없이 저장된 이전 형식의 풀이 기록을 그 백준 ID로 등록된 대회마다 나누어 옮깁니다.
+// 참가자의 시작 시점 기록에 포함된 문제는 그 대회에서 해결한 문제가 아니므로 옮기지 않습니다.
+// 이전 형식의 기록이 있었으면 두 번째 반환값이 true입니다.
+func scopeLegacySolves(competitions []*models.Competition, solves []models.SolveRecord) ([]models.SolveRecord, bool) {
+	scoped := make([]models.SolveRecord, 0, len(solves))
+	changed := false
+	for _, record := range solves {
+		if record.CompetitionID != 0 {
+			scoped = append(scoped, record)
+			continue
+		}
+		changed = true
+		for _, c := range competitions {
+			for _, p := range c.Participants {
+				if p.BaekjoonID != record.BaekjoonID || slices.Contains(p.StartProblemIDs, record.ProblemID) {
+					continue
+				}
+				moved := record
+				moved.CompetitionID = c.ID
+				moved.ParticipantID = p.ID
+				scoped = append(scoped, moved)
+			}
+		}
 	}
+	return scoped, changed
+}
 
-	utils.Info("Loaded competition: %s", s.competition.Name)
+// SaveCompetitions 대회와 참가자 데이터를 파일에 저장합니다
+func (s *Storage) SaveCompetitions() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.saveCompetitions()
 }
 
-// SaveParticipants 참가자 데이터를 파일에 저장합니다
-func (s *Storage) SaveParticipants() error {
-	utils.Debug("Saving participants to file: %s", constants.ParticipantsFileName)
-	data, err := json.MarshalIndent(s.participants, "", constants.JSONIndentSpaces)
+// saveCompetitions 호출자가 mu를 잡은 상태에서 대회 데이터를 저장합니다
+func (s *Storage) saveCompetitions() error {
+	fileName := s.path(constants.CompetitionsFileName)
+	utils.Debug("Saving competitions to file: %s", fileName)
+	data, err := json.MarshalIndent(s.competitions, "", constants.JSONIndentSpaces)
 	if err != nil {
-		utils.Error("Failed to marshal participants data: %v", err)
+		utils.Error("Failed to marshal competitions data: %v", err)
 		return err
 	}
 
-	err = os.WriteFile(constants.ParticipantsFileName, data, constants.FilePermission)
+	err = writeFileAtomic(fileName, data, constants.FilePermission)
 	if err != nil {
-		utils.Error("Failed to save participants file: %v", err)
+		utils.Error("Failed to save competitions file: %v", err)
 		return err
 	}
 
-	utils.Info("Successfully saved %d participants", len(s.participants))
+	utils.Info("Successfully saved %d competitions", len(s.competitions))
 	return nil
 }
 
-// SaveCompetition 대회 데이터를 파일에 저장합니다
-func (s *Storage) SaveCompetition() error {
-	if s.competition == nil {
-		utils.Debug("No competition to save")
-		return nil
+// SaveSolves 풀이 기록을 파일에 저장합니다
+func (s *Storage) SaveSolves() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.saveSolves()
+}
+
+// isEmpty 불러온 데이터가 하나도 없는지 확인합니다
+func (s *Storage) isEmpty() bool {
+	return len(s.competitions) == 0 && len(s.solves) == 0 && s.settings == (models.GuildSettings{}) &&
+		len(s.accounts) == 0 && len(s.changes) == 0 && len(s.auditLog) == 0 &&
+		len(s.applications) == 0 && len(s.results) == 0
+}
+
+// Close 변경 사항은 즉시 파일에 저장되므로 아무 일도 하지 않습니다
+func (s *Storage) Close() error {
+	return nil
+}
+
+// saveSolves 호출자가 mu를 잡은 상태에서 풀이 기록을 저장합니다
+func (s *Storage) saveSolves() error {
+	fileName := s.path(constants.SolvesFileName)
+	utils.Debug("Saving solve records to file: %s", fileName)
+	data, err := json.MarshalIndent(s.solves, "", constants.JSONIndentSpaces)
+	if err != nil {
+		utils.Error("Failed to marshal solve records: %v", err)
+		return err
 	}
 
-	utils.Debug("Saving competition to file: %s", constants.CompetitionFileName)
-	data, err := json.MarshalIndent(s.competition, "", constants.JSONIndentSpaces)
+	err = writeFileAtomic(fileName, data, constants.FilePermission)
 	if err != nil {
-		utils.Error("Failed to marshal competition data: %v", err)
+		utils.Error("Failed to save solve records file: %v", err)
 		return err
 	}
 
-	err = os.WriteFile(constants.CompetitionFileName, data, constants.FilePermission)
+	utils.Debug("Successfully saved %d solve records", len(s.solves))
+	return nil
+}
+
+// saveSettings 길드 설정을 파일에 저장합니다
+func (s *Storage) saveSettings() error {
+	data, err := json.MarshalIndent(s.settings, "", constants.JSONIndentSpaces)
 	if err != nil {
-		utils.Error("Failed to save competition file: %v", err)
+		utils.Error("Failed to marshal settings data: %v", err)
 		return err
 	}
 
-	utils.Info("Successfully saved competition: %s", s.competition.Name)
+	if err := writeFileAtomic(s.path(constants.SettingsFileName), data, constants.FilePermission); err != nil {
+		utils.Error("Failed to save settings file: %v", err)
+		return err
+	}
 	return nil
 }
 
-// AddParticipant 새로운 참가자를 추가합니다
-func (s *Storage) AddParticipant(name, baekjoonID string, startTier, startRating int) error {
+// GetGuildSettings 길드 설정을 반환합니다
+func (s *Storage) GetGuildSettings() models.GuildSettings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.settings
+}
+
+// SetAnnouncementChannel 자동 스코어보드/공지를 보낼 채널을 설정합니다 (빈 문자열이면 해제)
+func (s *Storage) SetAnnouncementChannel(channelID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings.AnnouncementChannelID = channelID
+	return s.saveSettings()
+}
+
+// AddParticipant 지정된 대회에 새로운 참가자를 추가합니다
+func (s *Storage) AddParticipant(ctx context.Context, competitionID int, name, baekjoonID, discordUserID string, startTier, startRating int) error {
 	// 입력값 검증
-	if err := s.validateParticipantInput(name, baekjoonID); err != nil {
+	if err := validateParticipantInput(name, baekjoonID); err != nil {
 		return err
 	}
 
-	// 중복 확인
-	if err := s.checkDuplicateParticipant(baekjoonID); err != nil {
+	// 대회 존재 및 중복 확인
+	deferred, err := s.checkNewParticipant(competitionID, baekjoonID)
+	if err != nil {
 		return err
 	}
 
-	// 시작 문제 데이터 수집
-	startProblemIDs, startProblemCount := s.fetchStartingProblems(baekjoonID)
+	// 시작 문제 데이터 수집 (네트워크 요청이므로 잠금 없이 수행)
+	startProblemIDs, pending := loadStartingProblems(ctx, s.apiClient, baekjoonID, deferred)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 요청 중에 대회가 삭제되거나 같은 ID가 등록되었을 수 있으므로 다시 확인
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkDuplicateParticipant(competition, baekjoonID); err != nil {
+		return err
+	}
 
 	// 참가자 생성 및 저장
-	participant := s.createParticipant(name, baekjoonID, startTier, startRating, startProblemIDs, startProblemCount)
-	return s.saveNewParticipant(participant)
+	participant := s.createParticipant(competition, name, baekjoonID, discordUserID, startTier, startRating, startProblemIDs, len(startProblemIDs))
+	participant.SnapshotPending = pending
+	return s.saveNewParticipant(competition, participant)
+}
+
+// checkNewParticipant 대회가 존재하고 같은 백준 ID가 등록되지 않았는지 확인합니다.
+// 시작 시점 기록을 대회 시작까지 미뤄야 하면 true를 반환합니다.
+func (s *Storage) checkNewParticipant(competitionID int, baekjoonID string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return false, err
+	}
+	return competition.DefersSnapshot(time.Now()), s.checkDuplicateParticipant(competition, baekjoonID)
 }
 
 // validateParticipantInput 참가자 입력값을 검증합니다
-func (s *Storage) validateParticipantInput(name, baekjoonID string) error {
+func validateParticipantInput(name, baekjoonID string) error {
 	if !utils.IsValidUsername(name) {
 		return fmt.Errorf("잘못된 사용자명: %s", name)
 	}
@@ -196,11 +496,11 @@ func (s *Storage) validateParticipantInput(name, baekjoonID string) error {
 	return nil
 }
 
-// checkDuplicateParticipant 중복 참가자를 확인합니다
-func (s *Storage) checkDuplicateParticipant(baekjoonID string) error {
-	for _, p := range s.participants {
+// checkDuplicateParticipant 대회 내 중복 참가자를 확인합니다
+func (s *Storage) checkDuplicateParticipant(competition *models.Competition, baekjoonID string) error {
+	for _, p := range competition.Participants {
 		if p.BaekjoonID == baekjoonID {
-			utils.Warn("Attempt to add duplicate participant: %s", baekjoonID)
+			utils.Warn("Attempt to add duplicate participant %s to competition %d", baekjoonID, competition.ID)
 			return fmt.Errorf("백준 ID %s로 이미 등록된 참가자가 있습니다", baekjoonID)
 		}
 	}
@@ -208,30 +508,58 @@ func (s *Storage) checkDuplicateParticipant(baekjoonID string) error {
 }
 
 // fetchStartingProblems 참가 시점의 해결한 문제들을 가져옵니다
-func (s *Storage) fetchStartingProblems(baekjoonID string) ([]int, int) {
+func fetchStartingProblems(ctx context.Context, apiClient interfaces.APIClient, baekjoonID string) ([]int, error) {
+	if cache, ok := apiClient.(interfaces.CacheInvalidator); ok {
+		cache.Invalidate(baekjoonID)
+	}
+
+	solved, err := apiClient.GetUserSolvedProblems(ctx, baekjoonID)
+	if err != nil {
+		return nil, fmt.Errorf("%s 해결 문제 조회 실패: %w", baekjoonID, err)
+	}
+
 	startProblemIDs := []int{}
-	startProblemCount := 0
+	for _, problem := range solved.Items {
+		startProblemIDs = append(startProblemIDs, problem.ProblemID)
+	}
+	utils.Info("Loaded %d starting problems for participant %s", len(startProblemIDs), baekjoonID)
+	return startProblemIDs, nil
+}
+
+// loadStartingProblems 등록 시점의 시작 문제 목록을 가져옵니다.
+// 기록을 대회 시작까지 미루거나 가져오지 못하면 빈 기록 대신 대기 상태(true)를 반환해
+// 대회가 진행 중일 때 다음 스코어보드 갱신에서 다시 기록하게 합니다.
+func loadStartingProblems(ctx context.Context, apiClient interfaces.APIClient, baekjoonID string, deferred bool) ([]int, bool) {
+	if deferred {
+		return []int{}, true
+	}
+	startProblemIDs, err := fetchStartingProblems(ctx, apiClient, baekjoonID)
+	if err != nil {
+		utils.Warn("Failed to load starting problems for participant %s, leaving the start snapshot pending: %v", baekjoonID, err)
+		return []int{}, true
+	}
+	return startProblemIDs, false
+}
 
-	top100, err := s.apiClient.GetUserTop100(baekjoonID)
-	if err == nil {
-		for _, problem := range top100.Items {
-			startProblemIDs = append(startProblemIDs, problem.ProblemID)
+// createParticipant 참가자 객체를 생성합니다
+func (s *Storage) createParticipant(competition *models.Competition, name, baekjoonID, discordUserID string, startTier, startRating int, startProblemIDs []int, startProblemCount int) models.Participant {
+	nextID := 1
+	for _, p := range competition.Participants {
+		if p.ID >= nextID {
+			nextID = p.ID + 1
 		}
-		startProblemCount = len(startProblemIDs)
-		utils.Info("Loaded %d starting problems for participant %s", startProblemCount, baekjoonID)
-	} else {
-		utils.Warn("Failed to load starting problems for participant %s: %v", baekjoonID, err)
 	}
 
-	return startProblemIDs, startProblemCount
+	return newParticipant(nextID, name, baekjoonID, discordUserID, startTier, startRating, startProblemIDs, startProblemCount)
 }
 
-// createParticipant 참가자 객체를 생성합니다
-func (s *Storage) createParticipant(name, baekjoonID string, startTier, startRating int, startProblemIDs []int, startProblemCount int) models.Participant {
+// newParticipant 등록 시점 정보로 참가자 객체를 만듭니다
+func newParticipant(id int, name, baekjoonID, discordUserID string, startTier, startRating int, startProblemIDs []int, startProblemCount int) models.Participant {
 	return models.Participant{
-		ID:                len(s.participants) + 1,
+		ID:                id,
 		Name:              utils.SanitizeString(name),
 		BaekjoonID:        baekjoonID,
+		DiscordUserID:     discordUserID,
 		StartTier:         startTier,
 		StartRating:       startRating,
 		CreatedAt:         time.Now(),
@@ -241,96 +569,315 @@ func (s *Storage) createParticipant(name, baekjoonID string, startTier, startRat
 }
 
 // saveNewParticipant 새 참가자를 저장합니다
-func (s *Storage) saveNewParticipant(participant models.Participant) error {
-	s.participants = append(s.participants, participant)
-	utils.Info("Added new participant: %s (%s)", participant.Name, participant.BaekjoonID)
-	return s.SaveParticipants()
+func (s *Storage) saveNewParticipant(competition *models.Competition, participant models.Participant) error {
+	competition.Participants = append(competition.Participants, participant)
+	utils.Info("Added new participant to competition %d: %s (%s)", competition.ID, participant.Name, participant.BaekjoonID)
+	return s.saveCompetitions()
 }
 
-func (s *Storage) GetParticipants() []models.Participant {
-	return s.participants
+// GetParticipants 지정된 대회의 참가자 목록 복사본을 반환합니다
+func (s *Storage) GetParticipants(competitionID int) []models.Participant {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return []models.Participant{}
+	}
+	return cloneParticipants(competition.Participants)
 }
 
-func (s *Storage) CreateCompetition(name string, startDate, endDate time.Time) error {
-	blackoutStart := endDate.AddDate(0, 0, -constants.BlackoutDays)
+// RemoveParticipant는 지정된 대회에서 백준ID로 참가자를 삭제합니다
+func (s *Storage) RemoveParticipant(competitionID int, baekjoonID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	s.competition = &models.Competition{
-		ID:                1,
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return err
+	}
+
+	for i, p := range competition.Participants {
+		if p.BaekjoonID == baekjoonID {
+			// 슬라이스에서 해당 참가자 제거
+			competition.Participants = append(competition.Participants[:i], competition.Participants[i+1:]...)
+			utils.Info("Removed participant from competition %d: %s (%s)", competition.ID, p.Name, baekjoonID)
+			return s.saveCompetitions()
+		}
+	}
+	return fmt.Errorf("백준 ID %s로 등록된 참가자를 찾을 수 없습니다", baekjoonID)
+}
+
+// CreateCompetition 새 대회를 만들고 반환합니다. 기존 대회는 그대로 유지됩니다
+func (s *Storage) CreateCompetition(name string, startDate, endDate time.Time) (*models.Competition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nextID := 1
+	for _, c := range s.competitions {
+		if c.ID >= nextID {
+			nextID = c.ID + 1
+		}
+	}
+
+	competition := newCompetition(nextID, name, startDate, endDate)
+
+	s.competitions = append(s.competitions, competition)
+	if err := s.saveCompetitions(); err != nil {
+		return nil, err
+	}
+	return cloneCompetition(competition), nil
+}
+
+// newCompetition 기본 설정이 적용된 새 대회 객체를 만듭니다
+func newCompetition(id int, name string, startDate, endDate time.Time) *models.Competition {
+	// 이후 기본 규칙이 바뀌어도 진행 중인 대회의 점수가 바뀌지 않도록 생성 시점의 규칙을 저장
+	scoring := models.DefaultScoringProfile()
+	return &models.Competition{
+		ID:                id,
 		Name:              name,
 		StartDate:         startDate,
 		EndDate:           endDate,
-		BlackoutStartDate: blackoutStart,
+		BlackoutStartDate: endDate.AddDate(0, 0, -constants.BlackoutDays),
 		IsActive:          true,
 		ShowScoreboard:    true,
-		Participants:      s.participants,
+		Participants:      []models.Participant{},
+		Scoring:           &scoring,
 	}
+}
+
+// GetCompetitions 모든 대회의 복사본을 ID 순으로 반환합니다
+func (s *Storage) GetCompetitions() []*models.Competition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	return s.SaveCompetition()
+	competitions := make([]*models.Competition, len(s.competitions))
+	for i, c := range s.competitions {
+		competitions[i] = cloneCompetition(c)
+	}
+	return competitions
 }
 
-func (s *Storage) GetCompetition() *models.Competition {
-	return s.competition
+// GetCompetition ID로 대회를 찾아 복사본을 반환합니다 (없으면 nil)
+func (s *Storage) GetCompetition(competitionID int) *models.Competition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return nil
+	}
+	return cloneCompetition(competition)
+}
+
+// cloneCompetition 잠금 밖에서 안전하게 읽을 수 있도록 대회를 깊은 복사합니다
+func cloneCompetition(c *models.Competition) *models.Competition {
+	clone := *c
+	clone.Participants = cloneParticipants(c.Participants)
+	if c.Scoring != nil {
+		scoring := c.Scoring.Clone()
+		clone.Scoring = &scoring
+	}
+	return &clone
 }
 
-func (s *Storage) SetScoreboardVisibility(visible bool) error {
-	if s.competition == nil {
-		return fmt.Errorf("활성화된 대회가 없습니다")
+func cloneParticipants(participants []models.Participant) []models.Participant {
+	clones := make([]models.Participant, len(participants))
+	for i, p := range participants {
+		clones[i] = p
+		clones[i].StartProblemIDs = append([]int(nil), p.StartProblemIDs...)
 	}
+	return clones
+}
 
-	s.competition.ShowScoreboard = visible
-	return s.SaveCompetition()
+func (s *Storage) findCompetition(competitionID int) (*models.Competition, error) {
+	for _, c := range s.competitions {
+		if c.ID == competitionID {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("ID %d인 대회가 없습니다", competitionID)
+}
+
+// withCompetition 호출자가 mu를 잡은 상태에서 같은 ID의 대회만 changed로 바꾼 대회 목록 사본을 반환합니다.
+// 저장에 성공한 뒤에 s.competitions에 반영하면 저장에 실패해도 메모리의 상태가 그대로 남습니다.
+func (s *Storage) withCompetition(changed *models.Competition) []*models.Competition {
+	competitions := slices.Clone(s.competitions)
+	for i, c := range competitions {
+		if c.ID == changed.ID {
+			competitions[i] = changed
+		}
+	}
+	return competitions
 }
 
-func (s *Storage) IsBlackoutPeriod() bool {
-	if s.competition == nil {
+func (s *Storage) SetScoreboardVisibility(competitionID int, visible bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return err
+	}
+
+	competition.ShowScoreboard = visible
+	return s.saveCompetitions()
+}
+
+func (s *Storage) IsBlackoutPeriod(competitionID int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
 		return false
 	}
 
-	now := time.Now()
-	return now.After(s.competition.BlackoutStartDate) && now.Before(s.competition.EndDate)
+	return isBlackout(competition, time.Now())
+}
+
+// isBlackout 대회가 now 시점에 블랙아웃 기간인지 확인합니다
+func isBlackout(competition *models.Competition, now time.Time) bool {
+	return now.After(competition.BlackoutStartDate) && now.Before(competition.EndDate)
 }
 
 // UpdateCompetitionName은 대회명을 업데이트합니다
-func (s *Storage) UpdateCompetitionName(name string) error {
-	if s.competition == nil {
-		return fmt.Errorf("활성화된 대회가 없습니다")
+func (s *Storage) UpdateCompetitionName(competitionID int, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return err
+	}
+
+	competition.Name = name
+	return s.saveCompetitions()
+}
+
+// UpdateCompetitionScoring는 대회의 점수 계산 규칙을 변경합니다
+func (s *Storage) UpdateCompetitionScoring(competitionID int, profile models.ScoringProfile) error {
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return err
+	}
+
+	scoring := profile.Clone()
+	competition.Scoring = &scoring
+	return s.saveCompetitions()
+}
+
+// UpdateCompetitionReveal 대회 종료 시 최종 결과 발표 방식을 변경합니다
+func (s *Storage) UpdateCompetitionReveal(competitionID int, settings models.RevealSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
 	}
 
-	s.competition.Name = name
-	return s.SaveCompetition()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return err
+	}
+
+	competition.Reveal = settings
+	return s.saveCompetitions()
 }
 
 // UpdateCompetitionStartDate는 대회 시작일을 업데이트합니다
-func (s *Storage) UpdateCompetitionStartDate(startDate time.Time) error {
-	if s.competition == nil {
-		return fmt.Errorf("활성화된 대회가 없습니다")
+func (s *Storage) UpdateCompetitionStartDate(competitionID int, startDate time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return err
 	}
 
-	s.competition.StartDate = startDate
-	return s.SaveCompetition()
+	competition.StartDate = startDate
+	return s.saveCompetitions()
 }
 
 // UpdateCompetitionEndDate는 대회 종료일을 업데이트하고 블랙아웃 기간도 자동으로 재설정합니다
-func (s *Storage) UpdateCompetitionEndDate(endDate time.Time) error {
-	if s.competition == nil {
-		return fmt.Errorf("활성화된 대회가 없습니다")
+func (s *Storage) UpdateCompetitionEndDate(competitionID int, endDate time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	competition, err := s.findCompetition(competitionID)
+	if err != nil {
+		return err
 	}
 
-	s.competition.EndDate = endDate
+	competition.EndDate = endDate
 	// 블랙아웃 기간도 자동으로 재설정 (종료일 3일 전부터)
-	s.competition.BlackoutStartDate = endDate.AddDate(0, 0, -constants.BlackoutDays)
-	return s.SaveCompetition()
+	competition.BlackoutStartDate = endDate.AddDate(0, 0, -constants.BlackoutDays)
+	return s.saveCompetitions()
 }
 
-// RemoveParticipant는 백준ID로 참가자를 삭제합니다
-func (s *Storage) RemoveParticipant(baekjoonID string) error {
-	for i, p := range s.participants {
-		if p.BaekjoonID == baekjoonID {
-			// 슬라이스에서 해당 참가자 제거
-			s.participants = append(s.participants[:i], s.participants[i+1:]...)
-			utils.Info("Removed participant: %s (%s)", p.Name, baekjoonID)
-			return s.SaveParticipants()
+// RecordSolves 처음 확인된 풀이만 기록에 추가하고 추가된 개수를 반환합니다.
+// 새 기록은 사본에 추가해 저장한 뒤에만 반영하므로, 저장에 실패하면 다음 호출에서 같은 풀이를 다시 기록합니다.
+func (s *Storage) RecordSolves(records []models.SolveRecord) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := make(map[solveKey]bool)
+	solves := slices.Clone(s.solves)
+	for _, record := range records {
+		key := solveKeyOf(record)
+		if s.solveIndex[key] || added[key] {
+			continue
 		}
+		solves = append(solves, record)
+		added[key] = true
 	}
-	return fmt.Errorf("백준 ID %s로 등록된 참가자를 찾을 수 없습니다", baekjoonID)
+
+	if len(added) == 0 {
+		return 0, nil
+	}
+	if err := s.saveDataFile(constants.SolvesFileName, solves); err != nil {
+		return 0, err
+	}
+
+	s.solves = solves
+	for key := range added {
+		s.solveIndex[key] = true
+	}
+	utils.Info("Recorded %d new solves", len(added))
+	return len(added), nil
+}
+
+// GetSolveHistory 대회 참가자가 since 이후 처음 해결한 문제 기록을 반환합니다
+func (s *Storage) GetSolveHistory(competitionID int, baekjoonID string, since time.Time) []models.SolveRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var history []models.SolveRecord
+	for _, record := range s.solves {
+		if record.CompetitionID == competitionID && record.BaekjoonID == baekjoonID && !record.FirstSeenAt.Before(since) {
+			history = append(history, record)
+		}
+	}
+	return history
+}
+
+// GetSolvesSince 대회에서 since 이후 처음 확인된 모든 풀이 기록을 반환합니다
+func (s *Storage) GetSolvesSince(competitionID int, since time.Time) []models.SolveRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var records []models.SolveRecord
+	for _, record := range s.solves {
+		if record.CompetitionID == competitionID && !record.FirstSeenAt.Before(since) {
+			records = append(records, record)
+		}
+	}
+	return records
 }
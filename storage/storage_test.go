@@ -0,0 +1,1162 @@
+package storage
+
+import (
+	"context"
+	"discord-bot/api"
+	"discord-bot/constants"
+	"discord-bot/interfaces"
+	"discord-bot/models"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAPIClient 네트워크 없이 고정된 풀이 목록을 돌려주는 APIClient입니다
+type fakeAPIClient struct{}
+
+func (fakeAPIClient) GetUserInfo(_ context.Context, handle string) (*api.UserInfo, error) {
+	return &api.UserInfo{Handle: handle}, nil
+}
+
+func (fakeAPIClient) GetUserTop100(_ context.Context, handle string) (*api.Top100Response, error) {
+	return &api.Top100Response{}, nil
+}
+
+func (fakeAPIClient) GetUserSolvedProblems(_ context.Context, handle string) (*api.SolvedProblemsResponse, error) {
+	return &api.SolvedProblemsResponse{
+		Count: 2,
+		Items: []api.ProblemInfo{{ProblemID: 1000, Level: 1}, {ProblemID: 1001, Level: 2}},
+	}, nil
+}
+
+// storageBackends 두 저장소 구현에 같은 테스트를 실행하기 위한 생성자 목록입니다
+var storageBackends = map[string]func(interfaces.APIClient, string, string) (interfaces.StorageRepository, error){
+	constants.StorageBackendJSON:   NewStorage,
+	constants.StorageBackendSQLite: NewSQLiteStorage,
+}
+
+// flakyAPIClient fail이 true인 동안 해결 문제 조회가 실패하는 APIClient입니다
+type flakyAPIClient struct {
+	fakeAPIClient
+	fail bool
+}
+
+func (c *flakyAPIClient) GetUserSolvedProblems(ctx context.Context, handle string) (*api.SolvedProblemsResponse, error) {
+	if c.fail {
+		return nil, fmt.Errorf("solved.ac unavailable")
+	}
+	return c.fakeAPIClient.GetUserSolvedProblems(ctx, handle)
+}
+
+// forEachBackend 두 저장소 구현 각각에 대해 빈 데이터 디렉터리로 저장소를 열고 fn을 실행합니다
+func forEachBackend(t *testing.T, fn func(t *testing.T, s interfaces.StorageRepository)) {
+	t.Helper()
+	forEachBackendWith(t, fakeAPIClient{}, fn)
+}
+
+// forEachBackendWith forEachBackend와 같지만 저장소가 apiClient를 사용합니다
+func forEachBackendWith(t *testing.T, apiClient interfaces.APIClient, fn func(t *testing.T, s interfaces.StorageRepository)) {
+	t.Helper()
+	for name, newStorage := range storageBackends {
+		t.Run(name, func(t *testing.T) {
+			s, err := newStorage(apiClient, t.TempDir(), "")
+			if err != nil {
+				t.Fatalf("new storage: %v", err)
+			}
+			fn(t, s)
+		})
+	}
+}
+
+// reopenStorage s와 같은 구현으로 같은 데이터 디렉터리를 다시 엽니다
+func reopenStorage(t *testing.T, s interfaces.StorageRepository) interfaces.StorageRepository {
+	t.Helper()
+	var (
+		reopened interfaces.StorageRepository
+		err      error
+	)
+	switch s := s.(type) {
+	case *Storage:
+		reopened, err = NewStorage(s.apiClient, s.dataDir, "")
+	case *SQLiteStorage:
+		reopened, err = NewSQLiteStorage(s.apiClient, s.dataDir, "")
+	default:
+		t.Fatalf("unknown storage backend %T", s)
+	}
+	if err != nil {
+		t.Fatalf("reopen storage: %v", err)
+	}
+	return reopened
+}
+
+func newTestCompetition(t *testing.T, s interfaces.StorageRepository) *models.Competition {
+	t.Helper()
+	now := time.Now()
+	competition, err := s.CreateCompetition("테스트 대회", now, now.AddDate(0, 0, 30))
+	if err != nil {
+		t.Fatalf("CreateCompetition: %v", err)
+	}
+	return competition
+}
+
+func TestConcurrentAddRemoveParticipants(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		competition := newTestCompetition(t, s)
+
+		const workers = 16
+		const rounds = 10
+
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for r := 0; r < rounds; r++ {
+					handle := fmt.Sprintf("user%d_%d", w, r)
+					if err := s.AddParticipant(context.Background(), competition.ID, "참가자", handle, "", 1, 100); err != nil {
+						t.Errorf("AddParticipant(%s): %v", handle, err)
+						continue
+					}
+					// 읽기와 다른 쓰기가 섞이도록 짝수 라운드만 삭제합니다
+					s.GetParticipants(competition.ID)
+					s.GetCompetitions()
+					if r%2 == 0 {
+						if err := s.RemoveParticipant(competition.ID, handle); err != nil {
+							t.Errorf("RemoveParticipant(%s): %v", handle, err)
+						}
+					}
+				}
+			}(w)
+		}
+		wg.Wait()
+
+		participants := s.GetParticipants(competition.ID)
+		if want := workers * rounds / 2; len(participants) != want {
+			t.Fatalf("participants = %d, want %d", len(participants), want)
+		}
+
+		ids := make(map[int]bool)
+		for _, p := range participants {
+			if ids[p.ID] {
+				t.Fatalf("duplicate participant ID %d", p.ID)
+			}
+			ids[p.ID] = true
+		}
+
+		// 디스크에 저장된 상태도 메모리와 같아야 합니다
+		reloaded := reopenStorage(t, s)
+		if got := len(reloaded.GetParticipants(competition.ID)); got != len(participants) {
+			t.Fatalf("reloaded participants = %d, want %d", got, len(participants))
+		}
+	})
+}
+
+func TestConcurrentDuplicateAddParticipant(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		competition := newTestCompetition(t, s)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.AddParticipant(context.Background(), competition.ID, "참가자", "samehandle", "", 1, 100)
+			}()
+		}
+		wg.Wait()
+
+		if got := len(s.GetParticipants(competition.ID)); got != 1 {
+			t.Fatalf("participants = %d, want 1", got)
+		}
+	})
+}
+
+func TestConcurrentRecordSolves(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		competition := newTestCompetition(t, s)
+		now := time.Now()
+		var wg sync.WaitGroup
+		for w := 0; w < 8; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// 모든 고루틴이 같은 기록을 넣으므로 한 번씩만 저장되어야 합니다
+				var records []models.SolveRecord
+				for p := 0; p < 20; p++ {
+					records = append(records, models.SolveRecord{CompetitionID: competition.ID, BaekjoonID: "solver", ProblemID: 2000 + p, Level: 5, FirstSeenAt: now})
+				}
+				if _, err := s.RecordSolves(records); err != nil {
+					t.Errorf("RecordSolves: %v", err)
+				}
+				s.GetSolvesSince(competition.ID, now.Add(-time.Hour))
+			}()
+		}
+		wg.Wait()
+
+		if got := len(s.GetSolveHistory(competition.ID, "solver", now.Add(-time.Hour))); got != 20 {
+			t.Fatalf("solve records = %d, want 20", got)
+		}
+	})
+}
+
+func TestSolvesAreScopedByCompetition(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		first := newTestCompetition(t, s)
+		second := newTestCompetition(t, s)
+		for _, add := range []struct {
+			competitionID int
+			handle        string
+		}{{first.ID, "shared"}, {second.ID, "other"}, {second.ID, "shared"}} {
+			if err := s.AddParticipant(context.Background(), add.competitionID, "참가자", add.handle, "", 1, 100); err != nil {
+				t.Fatalf("AddParticipant(%s): %v", add.handle, err)
+			}
+		}
+		inFirst := s.GetParticipants(first.ID)[0]
+		inSecond := s.GetParticipants(second.ID)[1]
+
+		now := time.Now()
+		records := []models.SolveRecord{
+			{CompetitionID: first.ID, ParticipantID: inFirst.ID, BaekjoonID: "shared", ProblemID: 3000, Level: 5, FirstSeenAt: now},
+			{CompetitionID: second.ID, ParticipantID: inSecond.ID, BaekjoonID: "shared", ProblemID: 3000, Level: 5, FirstSeenAt: now, Backlog: true},
+		}
+		if added, err := s.RecordSolves(records); err != nil || added != 2 {
+			t.Fatalf("RecordSolves = %d, %v, want one record per competition", added, err)
+		}
+		if added, _ := s.RecordSolves(records); added != 0 {
+			t.Fatalf("recording again added %d records", added)
+		}
+
+		reopened := reopenStorage(t, s)
+		history := reopened.GetSolveHistory(second.ID, "shared", time.Time{})
+		if len(history) != 1 || history[0].ParticipantID != inSecond.ID || !history[0].Backlog {
+			t.Fatalf("history in second competition = %+v", history)
+		}
+		if since := reopened.GetSolvesSince(first.ID, now.Add(-time.Minute)); len(since) != 1 || since[0].ParticipantID != inFirst.ID || since[0].Backlog {
+			t.Fatalf("solves in first competition = %+v", since)
+		}
+	})
+}
+
+func TestLegacySolvesAreSplitByCompetition(t *testing.T) {
+	seenAt := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	// 시작 기록에 1000, 1001이 있으므로 2000만 각 대회로 옮겨져야 합니다
+	check := func(t *testing.T, s interfaces.StorageRepository, competitions []*models.Competition) {
+		for _, c := range competitions {
+			history := s.GetSolveHistory(c.ID, "legacy", time.Time{})
+			if len(history) != 1 || history[0].ProblemID != 2000 || !history[0].FirstSeenAt.Equal(seenAt) {
+				t.Fatalf("history in competition %d = %+v", c.ID, history)
+			}
+			participants := s.GetParticipants(c.ID)
+			if want := participants[len(participants)-1].ID; history[0].ParticipantID != want {
+				t.Fatalf("participant ID = %d, want %d", history[0].ParticipantID, want)
+			}
+		}
+	}
+	setup := func(t *testing.T, s interfaces.StorageRepository) []*models.Competition {
+		first := newTestCompetition(t, s)
+		second := newTestCompetition(t, s)
+		for _, add := range []struct {
+			competitionID int
+			handle        string
+		}{{first.ID, "legacy"}, {second.ID, "other"}, {second.ID, "legacy"}} {
+			if err := s.AddParticipant(context.Background(), add.competitionID, "참가자", add.handle, "", 1, 100); err != nil {
+				t.Fatalf("AddParticipant(%s): %v", add.handle, err)
+			}
+		}
+		return []*models.Competition{first, second}
+	}
+
+	t.Run(constants.StorageBackendJSON, func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := NewStorage(fakeAPIClient{}, dir, "")
+		if err != nil {
+			t.Fatalf("new storage: %v", err)
+		}
+		competitions := setup(t, s)
+		legacy := fmt.Sprintf(`[{"participant_id":1,"baekjoon_id":"legacy","problem_id":1000,"level":1,"first_seen_at":%[1]q},
+			{"participant_id":1,"baekjoon_id":"legacy","problem_id":2000,"level":5,"first_seen_at":%[1]q}]`, seenAt.Format(time.RFC3339))
+		if err := os.WriteFile(filepath.Join(dir, constants.SolvesFileName), []byte(legacy), constants.FilePermission); err != nil {
+			t.Fatal(err)
+		}
+		check(t, reopenStorage(t, s), competitions)
+	})
+
+	t.Run(constants.StorageBackendSQLite, func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := NewSQLiteStorage(fakeAPIClient{}, dir, "")
+		if err != nil {
+			t.Fatalf("new storage: %v", err)
+		}
+		competitions := setup(t, s)
+
+		// 풀이 기록을 대회별로 나누기 전 스키마로 되돌립니다
+		db := s.(*SQLiteStorage).db
+		for _, statement := range []string{
+			`DROP TABLE solves`,
+			`CREATE TABLE solves (
+				baekjoon_id    TEXT    NOT NULL,
+				problem_id     INTEGER NOT NULL,
+				participant_id INTEGER NOT NULL,
+				level          INTEGER NOT NULL,
+				first_seen_at  TEXT    NOT NULL,
+				PRIMARY KEY (baekjoon_id, problem_id)
+			)`,
+			`ALTER TABLE competitions DROP COLUMN results_pending`,
+			`DELETE FROM schema_migrations WHERE version >= 9`,
+		} {
+			if _, err := db.Exec(statement); err != nil {
+				t.Fatalf("downgrade schema: %v", err)
+			}
+		}
+		for _, problemID := range []int{1000, 2000} {
+			if _, err := db.Exec(`INSERT INTO solves (baekjoon_id, problem_id, participant_id, level, first_seen_at) VALUES (?, ?, 1, 5, ?)`,
+				"legacy", problemID, formatTime(seenAt)); err != nil {
+				t.Fatalf("insert legacy solve: %v", err)
+			}
+		}
+		db.Close()
+
+		upgraded, err := NewSQLiteStorage(fakeAPIClient{}, dir, "")
+		if err != nil {
+			t.Fatalf("reopen storage: %v", err)
+		}
+		check(t, upgraded, competitions)
+	})
+}
+
+func TestReturnedCompetitionIsCopy(t *testing.T) {
+	s, err := NewStorage(fakeAPIClient{}, t.TempDir(), "")
+	if err != nil {
+		t.Fatalf("new storage: %v", err)
+	}
+	competition := newTestCompetition(t, s)
+	if err := s.AddParticipant(context.Background(), competition.ID, "참가자", "copycheck", "", 1, 100); err != nil {
+		t.Fatalf("AddParticipant: %v", err)
+	}
+
+	got := s.GetCompetition(competition.ID)
+	got.Name = "변경됨"
+	got.Participants[0].StartProblemIDs[0] = -1
+
+	again := s.GetCompetition(competition.ID)
+	if again.Name != "테스트 대회" || again.Participants[0].StartProblemIDs[0] != 1000 {
+		t.Fatalf("storage state was modified through a returned competition: %+v", again)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "data.json")
+
+	if err := os.WriteFile(fileName, []byte(`{"old":true}`), constants.FilePermission); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(fileName, []byte(`{"new":true}`), constants.FilePermission); err != nil {
+		t.Fatalf("writeFileAtomic: %v", err)
+	}
+
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v map[string]bool
+	if err := json.Unmarshal(data, &v); err != nil || !v["new"] {
+		t.Fatalf("unexpected content %q (err %v)", data, err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("temporary files left behind: %d entries", len(entries))
+	}
+}
+
+func TestCompetitionScoringPersists(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		competition := newTestCompetition(t, s)
+		if competition.Scoring == nil {
+			t.Fatal("new competition should store the default scoring profile")
+		}
+
+		profile := models.DefaultScoringProfile()
+		profile.TierPoints = map[int]int{11: 30}
+		profile.SetWeightBands([]models.WeightBand{{MinDistance: -2, MaxDistance: 2, Weight: 1}})
+		profile.MaxTotalScore = 500
+		if err := s.UpdateCompetitionScoring(competition.ID, profile); err != nil {
+			t.Fatalf("UpdateCompetitionScoring: %v", err)
+		}
+
+		invalid := profile.Clone()
+		invalid.WeightBands[0].Weight = -1
+		if err := s.UpdateCompetitionScoring(competition.ID, invalid); err == nil {
+			t.Fatal("expected negative weight to be rejected")
+		}
+
+		// 다시 열어도 유지되어야 합니다
+		reopened := reopenStorage(t, s)
+		got := reopened.GetCompetition(competition.ID).ScoringRules()
+		if got.TierPoints[11] != 30 || len(got.WeightBands) != 1 || got.WeightBands[0].MinDistance != -2 || got.MaxTotalScore != 500 {
+			t.Fatalf("scoring after reopen = %+v", got)
+		}
+	})
+}
+
+func TestParticipantDiscordUserIDPersists(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		competition := newTestCompetition(t, s)
+		if err := s.AddParticipant(context.Background(), competition.ID, "참가자", "linked", "123456789", 1, 100); err != nil {
+			t.Fatalf("AddParticipant: %v", err)
+		}
+
+		reopened := reopenStorage(t, s)
+		participants := reopened.GetParticipants(competition.ID)
+		if len(participants) != 1 || participants[0].DiscordUserID != "123456789" {
+			t.Fatalf("participants after reopen = %+v", participants)
+		}
+	})
+}
+
+func TestAccountLinks(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		if err := s.LinkAccount("user-1", "owner"); err != nil {
+			t.Fatalf("LinkAccount: %v", err)
+		}
+		if err := s.LinkAccount("user-2", "OWNER"); err == nil {
+			t.Fatal("expected a handle linked to another user to be rejected")
+		}
+		// 같은 사용자가 다른 ID를 인증하면 기존 연동을 대체합니다
+		if err := s.LinkAccount("user-1", "renamed"); err != nil {
+			t.Fatalf("relink: %v", err)
+		}
+		if s.FindAccountLinkByBaekjoonID("owner") != nil {
+			t.Fatal("previous handle should be released after relinking")
+		}
+
+		reopened := reopenStorage(t, s)
+		link := reopened.GetAccountLink("user-1")
+		if link == nil || link.BaekjoonID != "renamed" || link.VerifiedAt.IsZero() {
+			t.Fatalf("link after reopen = %+v", link)
+		}
+		if found := reopened.FindAccountLinkByBaekjoonID("RENAMED"); found == nil || found.DiscordUserID != "user-1" {
+			t.Fatalf("lookup by handle should ignore case: %+v", found)
+		}
+
+		if err := reopened.UnlinkAccount("user-1"); err != nil {
+			t.Fatalf("UnlinkAccount: %v", err)
+		}
+		if err := reopened.UnlinkAccount("user-1"); err == nil {
+			t.Fatal("expected unlinking twice to fail")
+		}
+	})
+}
+
+func TestHandleChangeKeepsStartSnapshot(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		competition := newTestCompetition(t, s)
+		for _, handle := range []string{"oldhandle", "taken"} {
+			if err := s.AddParticipant(context.Background(), competition.ID, "참가자", handle, "", 7, 900); err != nil {
+				t.Fatalf("AddParticipant(%s): %v", handle, err)
+			}
+		}
+		participant := s.GetParticipants(competition.ID)[0]
+		if _, err := s.RecordSolves([]models.SolveRecord{{CompetitionID: competition.ID, ParticipantID: participant.ID, BaekjoonID: "oldhandle", ProblemID: 2000, Level: 8, FirstSeenAt: time.Now()}}); err != nil {
+			t.Fatalf("RecordSolves: %v", err)
+		}
+
+		if err := s.RenameParticipant(competition.ID, participant.ID, "새이름"); err != nil {
+			t.Fatalf("RenameParticipant: %v", err)
+		}
+		if _, err := s.CreateHandleChangeRequest(competition.ID, participant.ID, "taken", "user"); err == nil {
+			t.Fatal("expected a handle already registered in the competition to be rejected")
+		}
+
+		if err := s.LinkAccount("user", "newhandle"); err != nil {
+			t.Fatalf("LinkAccount: %v", err)
+		}
+		request, err := s.CreateHandleChangeRequest(competition.ID, participant.ID, "newhandle", "user")
+		if err != nil {
+			t.Fatalf("CreateHandleChangeRequest: %v", err)
+		}
+		if _, err := s.CreateHandleChangeRequest(competition.ID, participant.ID, "another", "user"); err == nil {
+			t.Fatal("expected a second pending request to be rejected")
+		}
+
+		resolved, err := s.ResolveHandleChangeRequest(request.ID, true, "admin")
+		if err != nil {
+			t.Fatalf("ResolveHandleChangeRequest: %v", err)
+		}
+		if resolved.Status != models.RequestApproved || resolved.ResolvedBy != "admin" {
+			t.Fatalf("resolved request = %+v", resolved)
+		}
+		if _, err := s.ResolveHandleChangeRequest(request.ID, false, "admin"); err == nil {
+			t.Fatal("expected resolving twice to fail")
+		}
+
+		reopened := reopenStorage(t, s)
+		got := reopened.GetParticipants(competition.ID)[0]
+		if got.BaekjoonID != "newhandle" || got.Name != "새이름" || got.StartTier != 7 || len(got.StartProblemIDs) != 2 {
+			t.Fatalf("participant after handle change = %+v", got)
+		}
+		if history := reopened.GetSolveHistory(competition.ID, "newhandle", time.Time{}); len(history) != 1 {
+			t.Fatalf("solve history under new handle = %+v", history)
+		}
+		if requests := reopened.GetHandleChangeRequests(competition.ID); len(requests) != 1 || requests[0].ResolvedAt.IsZero() {
+			t.Fatalf("requests after reopen = %+v", requests)
+		}
+	})
+}
+
+func TestFailedHandleChangeLeavesStateUnchanged(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewStorage(fakeAPIClient{}, dir, "")
+	if err != nil {
+		t.Fatalf("new storage: %v", err)
+	}
+	competition := newTestCompetition(t, s)
+	if err := s.AddParticipant(context.Background(), competition.ID, "참가자", "oldhandle", "", 7, 900); err != nil {
+		t.Fatalf("AddParticipant: %v", err)
+	}
+	if _, err := s.RecordSolves([]models.SolveRecord{{CompetitionID: competition.ID, ParticipantID: 1, BaekjoonID: "oldhandle", ProblemID: 2000, Level: 8, FirstSeenAt: time.Now()}}); err != nil {
+		t.Fatalf("RecordSolves: %v", err)
+	}
+	if err := s.LinkAccount("user", "newhandle"); err != nil {
+		t.Fatalf("LinkAccount: %v", err)
+	}
+	request, err := s.CreateHandleChangeRequest(competition.ID, 1, "newhandle", "user")
+	if err != nil {
+		t.Fatalf("CreateHandleChangeRequest: %v", err)
+	}
+
+	// 풀이 기록 파일 자리에 디렉터리를 두어 저장이 실패하게 합니다
+	solvesFile := filepath.Join(dir, constants.SolvesFileName)
+	if err := os.Remove(solvesFile); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(solvesFile, constants.DirPermission); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ResolveHandleChangeRequest(request.ID, true, "admin"); err == nil {
+		t.Fatal("expected the approval to fail when the solve records cannot be saved")
+	}
+
+	if got := s.GetParticipants(competition.ID)[0].BaekjoonID; got != "oldhandle" {
+		t.Fatalf("participant handle after failed approval = %q", got)
+	}
+	if history := s.GetSolveHistory(competition.ID, "oldhandle", time.Time{}); len(history) != 1 {
+		t.Fatalf("solve history after failed approval = %+v", history)
+	}
+	if status := s.GetHandleChangeRequests(competition.ID)[0].Status; status != models.RequestPending {
+		t.Fatalf("request status after failed approval = %s", status)
+	}
+	if got := reopenStorage(t, s).GetParticipants(competition.ID)[0].BaekjoonID; got != "oldhandle" {
+		t.Fatalf("participant handle on disk after failed approval = %q", got)
+	}
+
+	if err := os.Remove(solvesFile); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ResolveHandleChangeRequest(request.ID, true, "admin"); err != nil {
+		t.Fatalf("ResolveHandleChangeRequest after fixing the file: %v", err)
+	}
+	if history := reopenStorage(t, s).GetSolveHistory(competition.ID, "newhandle", time.Time{}); len(history) != 1 {
+		t.Fatalf("solve history under new handle = %+v", history)
+	}
+}
+
+func TestHandleChangeApprovalRechecksLink(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		competition := newTestCompetition(t, s)
+		if err := s.AddParticipant(context.Background(), competition.ID, "참가자", "oldhandle", "", 7, 900); err != nil {
+			t.Fatalf("AddParticipant: %v", err)
+		}
+		if err := s.LinkAccount("user", "newhandle"); err != nil {
+			t.Fatalf("LinkAccount: %v", err)
+		}
+		request, err := s.CreateHandleChangeRequest(competition.ID, 1, "newhandle", "user")
+		if err != nil {
+			t.Fatalf("CreateHandleChangeRequest: %v", err)
+		}
+		assertPending := func() {
+			t.Helper()
+			if got := s.GetParticipants(competition.ID)[0].BaekjoonID; got != "oldhandle" {
+				t.Fatalf("participant handle after failed approval = %q", got)
+			}
+			if status := s.GetHandleChangeRequests(competition.ID)[0].Status; status != models.RequestPending {
+				t.Fatalf("request status after failed approval = %s", status)
+			}
+		}
+
+		// 요청 뒤에 연동을 해제했거나 다른 사용자가 그 백준 ID를 연동했으면 승인하지 않습니다
+		if err := s.UnlinkAccount("user"); err != nil {
+			t.Fatalf("UnlinkAccount: %v", err)
+		}
+		if _, err := s.ResolveHandleChangeRequest(request.ID, true, "admin"); err == nil {
+			t.Fatal("expected approval after the link was revoked to fail")
+		}
+		assertPending()
+		if err := s.LinkAccount("other", "newhandle"); err != nil {
+			t.Fatalf("LinkAccount: %v", err)
+		}
+		if _, err := s.ResolveHandleChangeRequest(request.ID, true, "admin"); err == nil {
+			t.Fatal("expected approval with the handle linked to someone else to fail")
+		}
+		assertPending()
+
+		// 거절은 연동과 상관없이 할 수 있습니다
+		rejected, err := s.ResolveHandleChangeRequest(request.ID, false, "admin")
+		if err != nil {
+			t.Fatalf("ResolveHandleChangeRequest(reject): %v", err)
+		}
+		if rejected.Status != models.RequestRejected {
+			t.Fatalf("rejected request = %+v", rejected)
+		}
+	})
+}
+
+func TestAuditLog(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		for _, action := range []models.AuditAction{models.AuditRename, models.AuditWithdraw} {
+			if err := s.AppendAuditEntry(models.AuditEntry{CompetitionID: 1, ParticipantID: 3, Action: action, ActorID: "user"}); err != nil {
+				t.Fatalf("AppendAuditEntry: %v", err)
+			}
+		}
+		if err := s.AppendAuditEntry(models.AuditEntry{CompetitionID: 2, Action: models.AuditRemove}); err != nil {
+			t.Fatalf("AppendAuditEntry: %v", err)
+		}
+
+		reopened := reopenStorage(t, s)
+		entries := reopened.GetAuditLog(1)
+		if len(entries) != 2 || entries[0].Action != models.AuditWithdraw || entries[0].CreatedAt.IsZero() {
+			t.Fatalf("audit log = %+v, want newest first", entries)
+		}
+	})
+}
+
+func TestRegistrationRequests(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		competition := newTestCompetition(t, s)
+		if err := s.AddParticipant(context.Background(), competition.ID, "참가자", "registered", "", 7, 900); err != nil {
+			t.Fatalf("AddParticipant: %v", err)
+		}
+
+		if _, err := s.CreateRegistrationRequest(competition.ID, "참가자", "registered", "user0"); err == nil {
+			t.Fatal("expected a request for an already registered handle to be rejected")
+		}
+		first, err := s.CreateRegistrationRequest(competition.ID, "신청자", "applicant", "user1")
+		if err != nil {
+			t.Fatalf("CreateRegistrationRequest: %v", err)
+		}
+		if _, err := s.CreateRegistrationRequest(competition.ID, "신청자", "APPLICANT", "user2"); err == nil {
+			t.Fatal("expected a second pending request for the same handle to be rejected")
+		}
+		second, err := s.CreateRegistrationRequest(competition.ID, "다른신청자", "other", "user2")
+		if err != nil {
+			t.Fatalf("CreateRegistrationRequest: %v", err)
+		}
+
+		if err := s.LinkAccount("user1", "applicant"); err != nil {
+			t.Fatalf("LinkAccount: %v", err)
+		}
+		if _, err := s.ApproveRegistrationRequest(context.Background(), first.ID, "admin", 8, 1000); err != nil {
+			t.Fatalf("ApproveRegistrationRequest: %v", err)
+		}
+		if _, err := s.RejectRegistrationRequest(first.ID, "admin"); err == nil {
+			t.Fatal("expected resolving twice to fail")
+		}
+		if _, err := s.RejectRegistrationRequest(second.ID, "admin"); err != nil {
+			t.Fatalf("RejectRegistrationRequest: %v", err)
+		}
+
+		reopened := reopenStorage(t, s)
+		got := reopened.GetRegistrationRequest(second.ID)
+		if got == nil || got.Status != models.RequestRejected || got.ResolvedBy != "admin" || got.DiscordUserID != "user2" {
+			t.Fatalf("request after reopen = %+v", got)
+		}
+		if requests := reopened.GetRegistrationRequests(competition.ID); len(requests) != 2 || requests[0].Status != models.RequestApproved {
+			t.Fatalf("requests after reopen = %+v", requests)
+		}
+		if reopened.GetRegistrationRequest(99) != nil {
+			t.Fatal("expected an unknown request ID to return nil")
+		}
+	})
+}
+
+func TestRegistrationApprovalRechecksLinkAndFinalization(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		competition := newTestCompetition(t, s)
+		request, err := s.CreateRegistrationRequest(competition.ID, "신청자", "applicant", "user1")
+		if err != nil {
+			t.Fatalf("CreateRegistrationRequest: %v", err)
+		}
+		assertPending := func() {
+			t.Helper()
+			if len(s.GetParticipants(competition.ID)) != 0 {
+				t.Fatalf("participants after failed approval = %+v", s.GetParticipants(competition.ID))
+			}
+			if got := s.GetRegistrationRequest(request.ID); got == nil || got.Status != models.RequestPending {
+				t.Fatalf("request after failed approval = %+v", got)
+			}
+		}
+
+		// 신청 뒤에 연동을 해제했거나 다른 백준 ID로 바꿨으면 승인하지 않습니다
+		if _, err := s.ApproveRegistrationRequest(context.Background(), request.ID, "admin", 8, 1000); err == nil {
+			t.Fatal("expected approval without an account link to fail")
+		}
+		assertPending()
+		if err := s.LinkAccount("user1", "someoneelse"); err != nil {
+			t.Fatalf("LinkAccount: %v", err)
+		}
+		if _, err := s.ApproveRegistrationRequest(context.Background(), request.ID, "admin", 8, 1000); err == nil {
+			t.Fatal("expected approval with a link to another handle to fail")
+		}
+		assertPending()
+
+		if err := s.LinkAccount("user1", "applicant"); err != nil {
+			t.Fatalf("LinkAccount: %v", err)
+		}
+		approved, err := s.ApproveRegistrationRequest(context.Background(), request.ID, "admin", 8, 1000)
+		if err != nil {
+			t.Fatalf("ApproveRegistrationRequest: %v", err)
+		}
+		if approved.Status != models.RequestApproved || approved.ResolvedBy != "admin" {
+			t.Fatalf("approved request = %+v", approved)
+		}
+		participants := reopenStorage(t, s).GetParticipants(competition.ID)
+		if len(participants) != 1 || participants[0].BaekjoonID != "applicant" || participants[0].DiscordUserID != "user1" || participants[0].StartTier != 8 {
+			t.Fatalf("participants after approval = %+v", participants)
+		}
+
+		// 결과가 확정된 대회의 신청은 승인하지 않습니다
+		if err := s.LinkAccount("user2", "latecomer"); err != nil {
+			t.Fatalf("LinkAccount: %v", err)
+		}
+		late, err := s.CreateRegistrationRequest(competition.ID, "늦은신청자", "latecomer", "user2")
+		if err != nil {
+			t.Fatalf("CreateRegistrationRequest: %v", err)
+		}
+		if err := s.FinalizeCompetition(models.FinalStandings{CompetitionID: competition.ID, FinalizedAt: time.Now()}); err != nil {
+			t.Fatalf("FinalizeCompetition: %v", err)
+		}
+		if _, err := s.ApproveRegistrationRequest(context.Background(), late.ID, "admin", 8, 1000); err == nil {
+			t.Fatal("expected approval for a finalized competition to fail")
+		}
+		if got := s.GetRegistrationRequest(late.ID); got == nil || got.Status != models.RequestPending {
+			t.Fatalf("request for finalized competition = %+v", got)
+		}
+		if len(s.GetParticipants(competition.ID)) != 1 {
+			t.Fatalf("participants after rejected approval = %+v", s.GetParticipants(competition.ID))
+		}
+	})
+}
+
+func TestDeferredStartSnapshot(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		now := time.Now()
+		competition, err := s.CreateCompetition("시작 전 대회", now.AddDate(0, 0, 1), now.AddDate(0, 0, 30))
+		if err != nil {
+			t.Fatalf("CreateCompetition: %v", err)
+		}
+		policy := models.RegistrationPolicy{
+			ClosesAt:        now.AddDate(0, 0, 1),
+			RequireApproval: true,
+			SnapshotTiming:  models.SnapshotAtStart,
+		}
+		if err := s.UpdateCompetitionRegistration(competition.ID, policy); err != nil {
+			t.Fatalf("UpdateCompetitionRegistration: %v", err)
+		}
+		if err := s.UpdateCompetitionRegistration(competition.ID, models.RegistrationPolicy{SnapshotTiming: "later"}); err == nil {
+			t.Fatal("expected an unknown snapshot timing to be rejected")
+		}
+
+		if err := s.AddParticipant(context.Background(), competition.ID, "참가자", "early", "", 7, 900); err != nil {
+			t.Fatalf("AddParticipant: %v", err)
+		}
+		participant := s.GetParticipants(competition.ID)[0]
+		if !participant.SnapshotPending || len(participant.StartProblemIDs) != 0 {
+			t.Fatalf("participant before start = %+v, want a pending snapshot", participant)
+		}
+
+		if err := s.CaptureStartSnapshot(context.Background(), competition.ID, participant.ID); err != nil {
+			t.Fatalf("CaptureStartSnapshot: %v", err)
+		}
+
+		reopened := reopenStorage(t, s)
+		got := reopened.GetCompetition(competition.ID)
+		if got.Registration.Timing() != models.SnapshotAtStart || !got.Registration.RequireApproval {
+			t.Fatalf("registration policy after reopen = %+v", got.Registration)
+		}
+		captured := got.Participants[0]
+		if captured.SnapshotPending || captured.StartProblemCount != 2 || len(captured.StartProblemIDs) != 2 || captured.StartTier != 0 {
+			t.Fatalf("participant after capture = %+v", captured)
+		}
+	})
+}
+
+func TestFailedStartSnapshotStaysPending(t *testing.T) {
+	client := &flakyAPIClient{}
+	forEachBackendWith(t, client, func(t *testing.T, s interfaces.StorageRepository) {
+		competition := newTestCompetition(t, s)
+
+		client.fail = true
+		if err := s.AddParticipant(context.Background(), competition.ID, "참가자", "unlucky", "", 7, 900); err != nil {
+			t.Fatalf("AddParticipant: %v", err)
+		}
+		participant := reopenStorage(t, s).GetParticipants(competition.ID)[0]
+		if !participant.SnapshotPending || len(participant.StartProblemIDs) != 0 {
+			t.Fatalf("participant after failed fetch = %+v, want a pending snapshot", participant)
+		}
+
+		client.fail = false
+		if err := s.CaptureStartSnapshot(context.Background(), competition.ID, participant.ID); err != nil {
+			t.Fatalf("CaptureStartSnapshot: %v", err)
+		}
+		if got := s.GetParticipants(competition.ID)[0]; got.SnapshotPending || got.StartProblemCount != 2 {
+			t.Fatalf("participant after retry = %+v", got)
+		}
+	})
+}
+
+func TestLateStartSnapshotKeepsCompetitionSolves(t *testing.T) {
+	client := &flakyAPIClient{}
+	forEachBackendWith(t, client, func(t *testing.T, s interfaces.StorageRepository) {
+		competition := newTestCompetition(t, s)
+
+		client.fail = true
+		if err := s.AddParticipant(context.Background(), competition.ID, "참가자", "late", "", 7, 900); err != nil {
+			t.Fatalf("AddParticipant: %v", err)
+		}
+		participant := s.GetParticipants(competition.ID)[0]
+
+		// 시작 기록을 남기기 전에 대회 중 풀이로 확인된 문제는 시작 기록에 넣지 않습니다
+		if _, err := s.RecordSolves([]models.SolveRecord{{CompetitionID: competition.ID, ParticipantID: participant.ID,
+			BaekjoonID: "late", ProblemID: 1001, Level: 2, FirstSeenAt: time.Now()}}); err != nil {
+			t.Fatalf("RecordSolves: %v", err)
+		}
+
+		client.fail = false
+		if err := s.CaptureStartSnapshot(context.Background(), competition.ID, participant.ID); err != nil {
+			t.Fatalf("CaptureStartSnapshot: %v", err)
+		}
+		got := reopenStorage(t, s).GetParticipants(competition.ID)[0]
+		if got.SnapshotPending || got.StartProblemCount != 1 || len(got.StartProblemIDs) != 1 || got.StartProblemIDs[0] != 1000 {
+			t.Fatalf("participant after late snapshot = %+v, want only problem 1000 in the start set", got)
+		}
+	})
+}
+
+func TestSwitchingToStartSnapshotMarksParticipants(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		now := time.Now()
+		upcoming, err := s.CreateCompetition("시작 전 대회", now.AddDate(0, 0, 1), now.AddDate(0, 0, 30))
+		if err != nil {
+			t.Fatalf("CreateCompetition: %v", err)
+		}
+		running, err := s.CreateCompetition("진행 중 대회", now.AddDate(0, 0, -1), now.AddDate(0, 0, 30))
+		if err != nil {
+			t.Fatalf("CreateCompetition: %v", err)
+		}
+		for _, id := range []int{upcoming.ID, running.ID} {
+			if err := s.AddParticipant(context.Background(), id, "참가자", "early", "", 7, 900); err != nil {
+				t.Fatalf("AddParticipant: %v", err)
+			}
+		}
+
+		policy := models.RegistrationPolicy{SnapshotTiming: models.SnapshotAtStart}
+		for _, id := range []int{upcoming.ID, running.ID} {
+			if err := s.UpdateCompetitionRegistration(id, policy); err != nil {
+				t.Fatalf("UpdateCompetitionRegistration: %v", err)
+			}
+		}
+
+		reopened := reopenStorage(t, s)
+		if p := reopened.GetParticipants(upcoming.ID)[0]; !p.SnapshotPending {
+			t.Fatalf("participant of upcoming competition = %+v, want a pending snapshot", p)
+		}
+		if p := reopened.GetParticipants(running.ID)[0]; p.SnapshotPending {
+			t.Fatalf("participant of running competition = %+v, want the registration snapshot kept", p)
+		}
+	})
+}
+
+func TestFinalizeCompetition(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		competition := newTestCompetition(t, s)
+		if s.GetFinalStandings(competition.ID) != nil {
+			t.Fatal("expected no final standings before finalization")
+		}
+
+		finalizedAt := time.Date(2024, 1, 21, 0, 0, 30, 0, time.UTC)
+		standings := models.FinalStandings{
+			CompetitionID: competition.ID,
+			FinalizedAt:   finalizedAt,
+			Scores: []models.ScoreData{
+				{ParticipantID: 2, Name: "우승자", BaekjoonID: "winner", Score: 120.5, CurrentTier: 12, ProblemCount: 9},
+				{ParticipantID: 1, Name: "준우승", BaekjoonID: "second", Score: 80},
+			},
+		}
+		if err := s.FinalizeCompetition(standings); err != nil {
+			t.Fatalf("FinalizeCompetition: %v", err)
+		}
+		again := standings
+		again.Scores = nil
+		if err := s.FinalizeCompetition(again); err == nil {
+			t.Fatal("expected finalizing twice to fail")
+		}
+
+		reopened := reopenStorage(t, s)
+		got := reopened.GetCompetition(competition.ID)
+		if got.IsActive || !got.FinalizedAt.Equal(finalizedAt) || !got.ResultsPending {
+			t.Fatalf("competition after finalization = active %v, finalized at %v, pending %v", got.IsActive, got.FinalizedAt, got.ResultsPending)
+		}
+		final := reopened.GetFinalStandings(competition.ID)
+		if final == nil || len(final.Scores) != 2 || !final.FinalizedAt.Equal(finalizedAt) {
+			t.Fatalf("final standings = %+v", final)
+		}
+		if final.Scores[0] != standings.Scores[0] || final.Scores[1] != standings.Scores[1] {
+			t.Fatalf("final scores = %+v, want %+v", final.Scores, standings.Scores)
+		}
+
+		if err := reopened.MarkResultsAnnounced(competition.ID); err != nil {
+			t.Fatalf("MarkResultsAnnounced: %v", err)
+		}
+		if reopenStorage(t, reopened).GetCompetition(competition.ID).ResultsPending {
+			t.Fatal("expected the announcement to persist")
+		}
+	})
+}
+
+func TestFailedSolveRecordingCanBeRetried(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewStorage(fakeAPIClient{}, dir, "")
+	if err != nil {
+		t.Fatalf("new storage: %v", err)
+	}
+	competition := newTestCompetition(t, s)
+	record := models.SolveRecord{CompetitionID: competition.ID, ParticipantID: 1, BaekjoonID: "solver", ProblemID: 2000, Level: 8, FirstSeenAt: time.Now()}
+
+	// 풀이 기록 파일 자리에 디렉터리를 두어 저장이 실패하게 합니다
+	solvesFile := filepath.Join(dir, constants.SolvesFileName)
+	if err := os.RemoveAll(solvesFile); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(solvesFile, constants.DirPermission); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.RecordSolves([]models.SolveRecord{record}); err == nil {
+		t.Fatal("expected recording to fail when the solve records cannot be saved")
+	}
+	if history := s.GetSolveHistory(competition.ID, "solver", time.Time{}); len(history) != 0 {
+		t.Fatalf("solve history after failed recording = %+v", history)
+	}
+
+	// 저장하지 못한 풀이는 다음 호출에서 새 풀이로 다시 기록합니다
+	if err := os.Remove(solvesFile); err != nil {
+		t.Fatal(err)
+	}
+	added, err := s.RecordSolves([]models.SolveRecord{record, record})
+	if err != nil || added != 1 {
+		t.Fatalf("RecordSolves after fixing the file = %d, %v; want 1 new solve", added, err)
+	}
+	if history := reopenStorage(t, s).GetSolveHistory(competition.ID, "solver", time.Time{}); len(history) != 1 {
+		t.Fatalf("solve history on disk after retry = %+v", history)
+	}
+}
+
+func TestFailedFinalizationCanBeRetried(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewStorage(fakeAPIClient{}, dir, "")
+	if err != nil {
+		t.Fatalf("new storage: %v", err)
+	}
+	competition := newTestCompetition(t, s)
+
+	// 대회 파일 자리에 디렉터리를 두어 저장이 실패하게 합니다
+	competitionsFile := filepath.Join(dir, constants.CompetitionsFileName)
+	if err := os.Remove(competitionsFile); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(competitionsFile, constants.DirPermission); err != nil {
+		t.Fatal(err)
+	}
+	standings := models.FinalStandings{CompetitionID: competition.ID, FinalizedAt: time.Now()}
+	if err := s.FinalizeCompetition(standings); err == nil {
+		t.Fatal("expected finalization to fail when the competitions cannot be saved")
+	}
+	if got := s.GetCompetition(competition.ID); !got.IsActive || got.IsFinalized() || got.ResultsPending {
+		t.Fatalf("competition after failed finalization = %+v", got)
+	}
+
+	if err := os.Remove(competitionsFile); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.FinalizeCompetition(standings); err != nil {
+		t.Fatalf("FinalizeCompetition after fixing the file: %v", err)
+	}
+	if got := reopenStorage(t, s).GetCompetition(competition.ID); got.IsActive || !got.IsFinalized() {
+		t.Fatalf("competition on disk after retry = %+v", got)
+	}
+}
+
+func TestRevealSettingsPersist(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s interfaces.StorageRepository) {
+		competition := newTestCompetition(t, s)
+
+		settings := models.RevealSettings{Enabled: true, DelaySeconds: 20, GroupSize: 4}
+		if err := s.UpdateCompetitionReveal(competition.ID, settings); err != nil {
+			t.Fatalf("UpdateCompetitionReveal: %v", err)
+		}
+		if err := s.UpdateCompetitionReveal(competition.ID, models.RevealSettings{GroupSize: -1}); err == nil {
+			t.Fatal("expected invalid reveal settings to be rejected")
+		}
+
+		reopened := reopenStorage(t, s)
+		if got := reopened.GetCompetition(competition.ID).Reveal; got != settings {
+			t.Fatalf("reveal settings after reopen = %+v, want %+v", got, settings)
+		}
+	})
+}
+
+func TestSQLiteImportsJSONData(t *testing.T) {
+	dir := t.TempDir()
+	source, err := NewStorage(fakeAPIClient{}, dir, "")
+	if err != nil {
+		t.Fatalf("new storage: %v", err)
+	}
+	competition := newTestCompetition(t, source)
+	if err := source.AddParticipant(context.Background(), competition.ID, "참가자", "imported", "", 7, 900); err != nil {
+		t.Fatalf("AddParticipant: %v", err)
+	}
+	record := models.SolveRecord{CompetitionID: competition.ID, ParticipantID: 1, BaekjoonID: "imported", ProblemID: 2000, Level: 8, FirstSeenAt: time.Now()}
+	if _, err := source.RecordSolves([]models.SolveRecord{record}); err != nil {
+		t.Fatalf("RecordSolves: %v", err)
+	}
+	if err := source.LinkAccount("user-1", "imported"); err != nil {
+		t.Fatalf("LinkAccount: %v", err)
+	}
+	if err := source.SetAnnouncementChannel("channel-1"); err != nil {
+		t.Fatalf("SetAnnouncementChannel: %v", err)
+	}
+
+	s, err := NewSQLiteStorage(fakeAPIClient{}, dir, "")
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage: %v", err)
+	}
+	participants := s.GetParticipants(competition.ID)
+	if len(participants) != 1 || participants[0].BaekjoonID != "imported" || len(participants[0].StartProblemIDs) != 2 {
+		t.Fatalf("imported participants = %+v", participants)
+	}
+	if history := s.GetSolveHistory(competition.ID, "imported", time.Time{}); len(history) != 1 || history[0].ProblemID != 2000 {
+		t.Fatalf("imported solve history = %+v", history)
+	}
+	if link := s.GetAccountLink("user-1"); link == nil || link.BaekjoonID != "imported" {
+		t.Fatalf("imported account link = %+v", link)
+	}
+	if got := s.GetGuildSettings().AnnouncementChannelID; got != "channel-1" {
+		t.Fatalf("imported announcement channel = %q", got)
+	}
+
+	// 데이터베이스가 비어 있지 않으면 JSON 데이터가 바뀌어도 다시 가져오지 않습니다
+	if _, err := source.CreateCompetition("나중 대회", competition.StartDate, competition.EndDate); err != nil {
+		t.Fatalf("CreateCompetition: %v", err)
+	}
+	if got := len(reopenStorage(t, s).GetCompetitions()); got != 1 {
+		t.Fatalf("competitions after reopening = %d, want 1", got)
+	}
+}
+
+func TestSQLiteImportSkipsDatabaseWithAnyData(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewSQLiteStorage(fakeAPIClient{}, dir, "")
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage: %v", err)
+	}
+	// 등록 신청만 남은 데이터베이스도 비어 있지 않은 것으로 봅니다
+	db := s.(*SQLiteStorage).db
+	if _, err := db.Exec(`PRAGMA foreign_keys = OFF`); err != nil {
+		t.Fatal(err)
+	}
+	if err := insertRegistrationRequest(db, models.RegistrationRequest{ID: 1, CompetitionID: 1, Name: "신청자", BaekjoonID: "applicant",
+		DiscordUserID: "user", Status: models.RequestPending, RequestedAt: time.Now()}); err != nil {
+		t.Fatalf("insertRegistrationRequest: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	source, err := NewStorage(fakeAPIClient{}, dir, "")
+	if err != nil {
+		t.Fatalf("new storage: %v", err)
+	}
+	newTestCompetition(t, source)
+
+	reopened, err := NewSQLiteStorage(fakeAPIClient{}, dir, "")
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage: %v", err)
+	}
+	defer reopened.Close()
+	if got := len(reopened.GetCompetitions()); got != 0 {
+		t.Fatalf("competitions after reopening = %d, want the JSON data not imported", got)
+	}
+}
+
+func TestRegistryCloseClosesStorages(t *testing.T) {
+	registry := NewRegistry(fakeAPIClient{}, t.TempDir(), constants.StorageBackendSQLite, "")
+	s, err := registry.ForGuild("1")
+	if err != nil {
+		t.Fatalf("ForGuild: %v", err)
+	}
+	if err := registry.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := s.(*SQLiteStorage).db.Ping(); err == nil {
+		t.Fatal("expected the database to be closed")
+	}
+
+	// 닫은 뒤에 다시 요청하면 새로 엽니다
+	reopened, err := registry.ForGuild("1")
+	if err != nil {
+		t.Fatalf("ForGuild after Close: %v", err)
+	}
+	defer registry.Close()
+	if reopened == s {
+		t.Fatal("expected a new storage after Close")
+	}
+}
+
+func TestSQLiteImportDoesNotWriteJSONFiles(t *testing.T) {
+	// 이전 형식 데이터를 JSON 저장소로 열면 옮긴 파일을 저장하지만, SQLite로 가져올 때는 읽기만 해야 합니다
+	legacyDir := t.TempDir()
+	now := time.Now()
+	legacy := []*models.Competition{{
+		ID: 1, Name: "이전 대회", StartDate: now, EndDate: now.AddDate(0, 0, 30), IsActive: true,
+		Participants: []models.Participant{{ID: 1, Name: "참가자", BaekjoonID: "legacy", StartProblemIDs: []int{1000}}},
+	}}
+	solves := []models.SolveRecord{{ParticipantID: 1, BaekjoonID: "legacy", ProblemID: 2000, Level: 5, FirstSeenAt: now}}
+	for fileName, v := range map[string]interface{}{constants.CompetitionsFileName: legacy, constants.SolvesFileName: solves} {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(legacyDir, fileName), data, constants.FilePermission); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dir := t.TempDir()
+	s, err := NewSQLiteStorage(fakeAPIClient{}, dir, legacyDir)
+	if err != nil {
+		t.Fatalf("NewSQLiteStorage: %v", err)
+	}
+	if history := s.GetSolveHistory(1, "legacy", time.Time{}); len(history) != 1 {
+		t.Fatalf("imported solve history = %+v", history)
+	}
+	if written, _ := filepath.Glob(filepath.Join(dir, "*.json")); len(written) != 0 {
+		t.Fatalf("importing wrote JSON files: %v", written)
+	}
+}
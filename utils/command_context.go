@@ -1,18 +1,20 @@
 package utils
 
 import (
+	"discord-bot/errors"
+
 	"github.com/bwmarrin/discordgo"
 )
 
 // CommandContext 명령어 처리를 위한 컨텍스트 정보를 담고 있습니다
 type CommandContext struct {
-	Session     *discordgo.Session
+	Session     errors.MessageSender
 	Message     *discordgo.MessageCreate
 	ErrorHelper *ErrorHandlerFactory
 }
 
 // NewCommandContext 새로운 CommandContext를 생성합니다
-func NewCommandContext(s *discordgo.Session, m *discordgo.MessageCreate) *CommandContext {
+func NewCommandContext(s errors.MessageSender, m *discordgo.MessageCreate) *CommandContext {
 	return &CommandContext{
 		Session:     s,
 		Message:     m,
@@ -3,18 +3,16 @@ package utils
 import (
 	"discord-bot/errors"
 	"fmt"
-
-	"github.com/bwmarrin/discordgo"
 )
 
 // ValidationErrorHelper 검증 에러 처리를 위한 헬퍼
 type ValidationErrorHelper struct {
-	session   *discordgo.Session
+	session   errors.MessageSender
 	channelID string
 }
 
 // NewValidationErrorHelper ValidationErrorHelper 생성자
-func NewValidationErrorHelper(session *discordgo.Session, channelID string) *ValidationErrorHelper {
+func NewValidationErrorHelper(session errors.MessageSender, channelID string) *ValidationErrorHelper {
 	return &ValidationErrorHelper{
 		session:   session,
 		channelID: channelID,
@@ -53,12 +51,12 @@ func (v *ValidationErrorHelper) HandleInvalidDateRange() {
 
 // SystemErrorHelper 시스템 에러 처리를 위한 헬퍼
 type SystemErrorHelper struct {
-	session   *discordgo.Session
+	session   errors.MessageSender
 	channelID string
 }
 
 // NewSystemErrorHelper SystemErrorHelper 생성자
-func NewSystemErrorHelper(session *discordgo.Session, channelID string) *SystemErrorHelper {
+func NewSystemErrorHelper(session errors.MessageSender, channelID string) *SystemErrorHelper {
 	return &SystemErrorHelper{
 		session:   session,
 		channelID: channelID,
@@ -98,12 +96,12 @@ func (s *SystemErrorHelper) HandleScoreboardGenerationFailed(err error) {
 
 // APIErrorHelper API 에러 처리를 위한 헬퍼
 type APIErrorHelper struct {
-	session   *discordgo.Session
+	session   errors.MessageSender
 	channelID string
 }
 
 // NewAPIErrorHelper APIErrorHelper 생성자
-func NewAPIErrorHelper(session *discordgo.Session, channelID string) *APIErrorHelper {
+func NewAPIErrorHelper(session errors.MessageSender, channelID string) *APIErrorHelper {
 	return &APIErrorHelper{
 		session:   session,
 		channelID: channelID,
@@ -120,12 +118,12 @@ func (a *APIErrorHelper) HandleBaekjoonUserNotFound(baekjoonID string, err error
 
 // DataErrorHelper 데이터 관련 에러 처리를 위한 헬퍼
 type DataErrorHelper struct {
-	session   *discordgo.Session
+	session   errors.MessageSender
 	channelID string
 }
 
 // NewDataErrorHelper DataErrorHelper 생성자
-func NewDataErrorHelper(session *discordgo.Session, channelID string) *DataErrorHelper {
+func NewDataErrorHelper(session errors.MessageSender, channelID string) *DataErrorHelper {
 	return &DataErrorHelper{
 		session:   session,
 		channelID: channelID,
@@ -158,12 +156,12 @@ func (d *DataErrorHelper) HandleNoActiveCompetition() {
 
 // ErrorHandlerFactory 에러 핸들러들을 생성하는 팩토리
 type ErrorHandlerFactory struct {
-	session   *discordgo.Session
+	session   errors.MessageSender
 	channelID string
 }
 
 // NewErrorHandlerFactory ErrorHandlerFactory 생성자
-func NewErrorHandlerFactory(session *discordgo.Session, channelID string) *ErrorHandlerFactory {
+func NewErrorHandlerFactory(session errors.MessageSender, channelID string) *ErrorHandlerFactory {
 	return &ErrorHandlerFactory{
 		session:   session,
 		channelID: channelID,
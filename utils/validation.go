@@ -118,6 +118,23 @@ func PadStringByWidth(s string, targetWidth int) string {
 	return s + strings.Repeat(" ", padding)
 }
 
+// TruncateByWidth 표시 폭이 maxWidth를 넘지 않도록 글자 단위로 자릅니다 (잘리면 끝에 ... 표시)
+func TruncateByWidth(s string, maxWidth int) string {
+	if GetDisplayWidth(s) <= maxWidth {
+		return s
+	}
+	limit := maxWidth - len(constants.TruncateIndicator)
+	width := 0
+	for i, r := range s {
+		w := GetDisplayWidth(string(r))
+		if width+w > limit {
+			return s[:i] + constants.TruncateIndicator
+		}
+		width += w
+	}
+	return s
+}
+
 func SanitizeString(s string) string {
 	// Discord 메시지에서 문제가 될 수 있는 특수문자 제거/변경
 	s = strings.ReplaceAll(s, "`", "'")